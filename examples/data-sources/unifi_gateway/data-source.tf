@@ -0,0 +1,13 @@
+# Shared modules can branch on whether a UniFi gateway routes the site
+data "unifi_gateway" "current" {}
+
+resource "unifi_network" "iot" {
+  name    = "IoT"
+  purpose = data.unifi_gateway.current.has_gateway ? "corporate" : "vlan-only"
+  vlan_id = 100
+
+  # Only a UniFi gateway serves DHCP; behind a third-party gateway these stay unset
+  subnet     = data.unifi_gateway.current.has_gateway ? "10.0.100.1/24" : null
+  dhcp_start = data.unifi_gateway.current.has_gateway ? "10.0.100.10" : null
+  dhcp_stop  = data.unifi_gateway.current.has_gateway ? "10.0.100.254" : null
+}
@@ -0,0 +1,6 @@
+# Read the site-wide mDNS reflector settings
+data "unifi_setting_mdns" "current" {}
+
+output "mdns_network_ids" {
+  value = data.unifi_setting_mdns.current.enabled_for_network_ids
+}
@@ -10,3 +10,93 @@ resource "unifi_device" "ap" {
   name         = "Lobby AP"
   led_override = "off"
 }
+
+# Adopt a new device and forget it on destroy
+resource "unifi_device" "rack_switch" {
+  mac               = "aa:bb:cc:dd:ee:03"
+  name              = "Rack Switch"
+  adopt             = true
+  forget_on_destroy = true
+
+  timeouts {
+    create = "10m"
+  }
+}
+
+# Adopt a device previously managed by another controller
+resource "unifi_device" "migrated_ap" {
+  mac   = "aa:bb:cc:dd:ee:04"
+  adopt = true
+
+  adopt_credentials = {
+    username = "admin"
+    password = var.old_device_password
+  }
+}
+
+# Static management address on the management VLAN
+resource "unifi_device" "core_switch" {
+  mac  = "aa:bb:cc:dd:ee:05"
+  name = "Core Switch"
+
+  management = {
+    type            = "static"
+    ip              = "10.0.10.2"
+    netmask         = "255.255.255.0"
+    gateway         = "10.0.10.1"
+    dns1            = "10.0.10.1"
+    vlan_network_id = unifi_network.management.id
+  }
+}
+
+# Switch-wide settings on a core switch
+resource "unifi_device" "aggregation_switch" {
+  mac = "aa:bb:cc:dd:ee:06"
+
+  switch = {
+    stp_version           = "rstp"
+    stp_priority          = 4096
+    jumbo_frames_enabled  = true
+    dhcp_snooping_enabled = true
+
+    igmp_querier = {
+      (unifi_network.iot.id) = "10.0.20.2"
+    }
+  }
+}
+
+# Keep access points on the latest firmware, one AP per group at a time,
+# only between 02:00 and 05:00 UTC
+resource "unifi_device" "lobby_ap" {
+  mac = "aa:bb:cc:dd:ee:07"
+
+  firmware = {
+    channel              = "latest"
+    upgrade_window_start = "02:00"
+    upgrade_window_end   = "05:00"
+    upgrade_strategy     = "rolling"
+    ap_group_id          = data.unifi_ap_group.lobby.id
+  }
+
+  timeouts {
+    update = "20m"
+  }
+}
+
+# Silence the guest SSID on an AP in the server room
+resource "unifi_device" "server_room_ap" {
+  mac = "aa:bb:cc:dd:ee:08"
+
+  wlan_overrides = [
+    {
+      wlan_id = unifi_wlan.guest.id
+      radio   = "ng"
+      enabled = false
+    },
+    {
+      wlan_id = unifi_wlan.guest.id
+      radio   = "na"
+      enabled = false
+    },
+  ]
+}
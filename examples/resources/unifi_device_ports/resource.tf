@@ -0,0 +1,23 @@
+# Own the whole port table of a 24-port switch
+resource "unifi_device_ports" "access_switch" {
+  mac = "aa:bb:cc:dd:ee:01"
+
+  ports = {
+    # Desk ports share one profile
+    "1-22" = {
+      port_profile_id = unifi_port_profile.desks.id
+      poe_mode        = "auto"
+    }
+
+    "23" = {
+      name            = "AP Uplink"
+      port_profile_id = unifi_port_profile.ap_trunk.id
+    }
+
+    "24" = {
+      name = "Core Uplink"
+    }
+  }
+
+  wait_for_provision = true
+}
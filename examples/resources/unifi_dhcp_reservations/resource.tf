@@ -0,0 +1,30 @@
+resource "unifi_dhcp_reservations" "printers" {
+  reservations = {
+    "00:1b:a9:4e:10:01" = {
+      name       = "printer-floor-1"
+      fixed_ip   = "10.0.20.201"
+      network_id = unifi_network.iot.id
+    }
+    "00:1b:a9:4e:10:02" = {
+      name             = "printer-floor-2"
+      fixed_ip         = "10.0.20.202"
+      network_id       = unifi_network.iot.id
+      local_dns_record = "printer-floor-2.lan"
+    }
+  }
+}
+
+# Reservations built from a CSV inventory
+locals {
+  cameras = csvdecode(file("${path.module}/cameras.csv"))
+}
+
+resource "unifi_dhcp_reservations" "cameras" {
+  reservations = {
+    for c in local.cameras : lower(c.mac) => {
+      name       = c.name
+      fixed_ip   = c.ip
+      network_id = unifi_network.cameras.id
+    }
+  }
+}
@@ -0,0 +1,15 @@
+# Family filtering with safe search for the kids' network
+resource "unifi_dns_filter" "kids" {
+  network_id    = unifi_network.kids.id
+  filter        = "family"
+  safe_search   = true
+  blocked_sites = ["example-games.com"]
+  allowed_sites = ["school.example.edu"]
+}
+
+# Work filtering for the office network, managed from another module
+resource "unifi_dns_filter" "office" {
+  network_id  = unifi_network.office.id
+  filter      = "work"
+  blocked_tld = ["zip", "mov"]
+}
@@ -31,6 +31,23 @@ resource "unifi_dynamic_dns" "custom" {
   options  = "myip=<ip>&hostname=<h>"
 }
 
+# Several hostnames on one account
+resource "unifi_dynamic_dns" "multi" {
+  service   = "dyndns"
+  hostnames = ["home.example.com", "vpn.example.com", "nas.example.com"]
+  login     = "username"
+  password  = "password"
+}
+
+# Self-hosted update endpoint
+resource "unifi_dynamic_dns" "self_hosted" {
+  service      = "custom"
+  hostname     = "home.example.com"
+  url_template = "dyn.example.com/nic/update?hostname=%h&myip=%i"
+  login        = "username"
+  password     = "password"
+}
+
 # Using WAN2 interface
 resource "unifi_dynamic_dns" "wan2" {
   service   = "dyndns"
@@ -39,3 +56,8 @@ resource "unifi_dynamic_dns" "wan2" {
   password  = "password"
   interface = "wan2"
 }
+
+# Alert when updates stop
+output "ddns_last_update" {
+  value = unifi_dynamic_dns.cloudflare.last_update
+}
@@ -0,0 +1,19 @@
+resource "unifi_ip_allocation" "camera" {
+  network_id = unifi_network.cameras.id
+}
+
+resource "unifi_dhcp_reservations" "cameras" {
+  reservations = {
+    "00:1b:a9:4e:20:01" = {
+      name       = "camera-lobby"
+      fixed_ip   = unifi_ip_allocation.camera.ip_address
+      network_id = unifi_network.cameras.id
+    }
+  }
+}
+
+# Skip addresses used by devices the controller does not know about
+resource "unifi_ip_allocation" "plc" {
+  network_id  = unifi_network.ot.id
+  exclude_ips = ["10.0.40.250", "10.0.40.251"]
+}
@@ -0,0 +1,7 @@
+resource "unifi_setting_mdns" "example" {
+  mode = "custom"
+  enabled_for_network_ids = [
+    unifi_network.lan.id,
+    unifi_network.iot.id,
+  ]
+}
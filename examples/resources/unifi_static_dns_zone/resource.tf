@@ -0,0 +1,21 @@
+resource "unifi_static_dns_zone" "home" {
+  origin = "home.example.com"
+  zone   = <<-EOT
+    $TTL 1h
+    @          A      10.0.10.1
+    nas        A      10.0.10.20
+               AAAA   fd00:10::20
+    files      CNAME  nas
+    @          MX     10 mail.example.com.
+    _ipp._tcp  SRV    0 0 631 printer
+    printer    A      10.0.10.30
+    @          TXT    "v=spf1 mx -all"
+  EOT
+}
+
+# Records kept in a zone file alongside the configuration
+resource "unifi_static_dns_zone" "lab" {
+  origin      = "lab.example.com"
+  zone        = file("${path.module}/lab.example.com.zone")
+  default_ttl = 300
+}
@@ -0,0 +1,9 @@
+data "unifi_device" "core_switch" {
+  mac = "aa:bb:cc:dd:ee:ff"
+}
+
+# Bond ports 25 and 26 to the NAS
+resource "unifi_switch_lag" "nas" {
+  device_id    = data.unifi_device.core_switch.id
+  member_ports = [25, 26]
+}
@@ -0,0 +1,10 @@
+data "unifi_device" "core_switch" {
+  mac = "aa:bb:cc:dd:ee:ff"
+}
+
+# Copy the uplink's traffic to an analyzer on port 24
+resource "unifi_switch_port_mirror" "uplink_capture" {
+  device_id        = data.unifi_device.core_switch.id
+  destination_port = 24
+  source_port      = 1
+}
@@ -0,0 +1,14 @@
+resource "unifi_vlan_allocation" "tenant" {
+  for_each = toset(["acme", "globex"])
+
+  pool_start = 200
+  pool_end   = 299
+}
+
+resource "unifi_network" "tenant" {
+  for_each = unifi_vlan_allocation.tenant
+
+  name    = "tenant-${each.key}"
+  purpose = "vlan-only"
+  vlan_id = each.value.vlan_id
+}
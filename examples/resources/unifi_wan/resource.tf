@@ -0,0 +1,35 @@
+# Primary WAN on PPPoE with a tagged VLAN, as many fibre ISPs require
+resource "unifi_wan" "primary" {
+  name            = "Fibre"
+  network_group   = "WAN"
+  connection_type = "pppoe"
+  pppoe_username  = "customer@isp.example"
+  pppoe_password  = var.pppoe_password
+  vlan_id         = 7
+
+  smart_queue_enabled   = true
+  smart_queue_up_rate   = 95000
+  smart_queue_down_rate = 475000
+
+  # Request a /56 so several networks can each take a /64 from this WAN
+  ipv6 = {
+    connection_type = "dhcpv6"
+    pd_size         = 56
+  }
+
+  failover_priority = 1
+}
+
+# Backup WAN with a static address, used only when the primary fails
+resource "unifi_wan" "backup" {
+  name            = "LTE"
+  network_group   = "WAN2"
+  connection_type = "static"
+  ip_address      = "203.0.113.10"
+  netmask         = "255.255.255.248"
+  gateway         = "203.0.113.9"
+  dns_servers     = ["1.1.1.1", "9.9.9.9"]
+
+  load_balance_type = "failover-only"
+  failover_priority = 2
+}
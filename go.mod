@@ -9,7 +9,7 @@ require (
 	github.com/hashicorp/terraform-plugin-framework-validators v0.19.0
 	github.com/hashicorp/terraform-plugin-go v0.29.0
 	github.com/hashicorp/terraform-plugin-testing v1.14.0
-	github.com/resnickio/unifi-go-sdk v0.13.0
+	github.com/resnickio/unifi-go-sdk v0.20.0
 )
 
 require (
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// adoptExistingAttribute returns the per-resource adopt_existing attribute.
+// It is Optional without a default so an unset value falls through to the
+// provider-level adopt_existing setting.
+func adoptExistingAttribute(resourceType, naturalKey string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("When the controller rejects Create because a %s with the same %s already exists, "+
+			"update that object to match the configuration and manage it instead of failing. "+
+			"Overrides the provider-level adopt_existing setting. Only affects Create.", resourceType, naturalKey),
+		Optional: true,
+	}
+}
+
+// shouldAdoptExisting reports whether a Create that failed with err should
+// fall back to adopting the conflicting object. The resource's own
+// adopt_existing wins over the provider-level default when it is set.
+func (c *AutoLoginClient) shouldAdoptExisting(err error, adoptExisting types.Bool) bool {
+	if !errors.Is(err, unifi.ErrConflict) {
+		return false
+	}
+	if adoptExisting.IsNull() || adoptExisting.IsUnknown() {
+		return c.adoptExisting
+	}
+	return adoptExisting.ValueBool()
+}
+
+// addAdoptedWarning tells the user that Create took over an existing object
+// rather than creating a new one, so the adoption is visible in the apply
+// output.
+func addAdoptedWarning(diags *diag.Diagnostics, resourceType, naturalKey, id string) {
+	diags.AddWarning(
+		fmt.Sprintf("Adopted existing %s", resourceType),
+		fmt.Sprintf("A %s with %s already existed on the controller (ID %s). It was updated to match the configuration "+
+			"and is now managed by Terraform; destroying the resource will delete it from the controller.",
+			resourceType, naturalKey, id),
+	)
+}
+
+// findUserByMAC returns the client with the given MAC address, comparing
+// case-insensitively since the controller normalises MACs to lower case.
+func findUserByMAC(users []unifi.User, mac string) *unifi.User {
+	for i := range users {
+		if strings.EqualFold(users[i].MAC, mac) {
+			return &users[i]
+		}
+	}
+	return nil
+}
+
+// findStaticDNSByKey returns the static DNS record with the given key and
+// record type. Hostnames are case-insensitive.
+func findStaticDNSByKey(records []unifi.StaticDNS, key, recordType string) *unifi.StaticDNS {
+	for i := range records {
+		if strings.EqualFold(records[i].Key, key) && records[i].RecordType == recordType {
+			return &records[i]
+		}
+	}
+	return nil
+}
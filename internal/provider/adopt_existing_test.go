@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func TestShouldAdoptExisting(t *testing.T) {
+	conflict := fmt.Errorf("create user: %w", unifi.ErrConflict)
+	cases := []struct {
+		name            string
+		providerDefault bool
+		err             error
+		adoptExisting   types.Bool
+		want            bool
+	}{
+		{name: "unset uses provider default off", err: conflict, adoptExisting: types.BoolNull()},
+		{name: "unset uses provider default on", providerDefault: true, err: conflict, adoptExisting: types.BoolNull(), want: true},
+		{name: "resource enables", err: conflict, adoptExisting: types.BoolValue(true), want: true},
+		{name: "resource disables", providerDefault: true, err: conflict, adoptExisting: types.BoolValue(false)},
+		{name: "other errors never adopt", providerDefault: true, err: unifi.ErrBadRequest, adoptExisting: types.BoolValue(true)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &AutoLoginClient{adoptExisting: tc.providerDefault}
+			if got := c.shouldAdoptExisting(tc.err, tc.adoptExisting); got != tc.want {
+				t.Fatalf("shouldAdoptExisting = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindUserByMAC(t *testing.T) {
+	users := []unifi.User{
+		{ID: "a", MAC: "aa:bb:cc:dd:ee:01"},
+		{ID: "b", MAC: "aa:bb:cc:dd:ee:02"},
+	}
+	if got := findUserByMAC(users, "AA:BB:CC:DD:EE:02"); got == nil || got.ID != "b" {
+		t.Fatalf("findUserByMAC returned %+v, want client b", got)
+	}
+	if got := findUserByMAC(users, "aa:bb:cc:dd:ee:03"); got != nil {
+		t.Fatalf("findUserByMAC returned %+v for an unknown MAC", got)
+	}
+}
+
+func TestFindStaticDNSByKey(t *testing.T) {
+	records := []unifi.StaticDNS{
+		{ID: "a", Key: "nas.home.lan", RecordType: "A"},
+		{ID: "b", Key: "nas.home.lan", RecordType: "AAAA"},
+	}
+	if got := findStaticDNSByKey(records, "NAS.home.lan", "AAAA"); got == nil || got.ID != "b" {
+		t.Fatalf("findStaticDNSByKey returned %+v, want record b", got)
+	}
+	if got := findStaticDNSByKey(records, "nas.home.lan", "CNAME"); got != nil {
+		t.Fatalf("findStaticDNSByKey returned %+v for a missing record type", got)
+	}
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// allocationGroupPrefix starts the name of the firewall groups that record
+// allocations on the controller. The rest of the name is the allocation key.
+const allocationGroupPrefix = "tf-allocation:"
+
+// claimAllocation records key (such as "ip:10.0.0.5" or "vlan:100") as
+// handed out and reports whether it was free. Allocations made in the same
+// run call it for each candidate, so two of them never pick the same value
+// before either is recorded on the controller.
+func (c *AutoLoginClient) claimAllocation(key string) bool {
+	c.allocationMu.Lock()
+	defer c.allocationMu.Unlock()
+
+	if c.allocations == nil {
+		c.allocations = make(map[string]bool)
+	}
+	if c.allocations[key] {
+		return false
+	}
+	c.allocations[key] = true
+	return true
+}
+
+// releaseAllocation frees a key recorded by claimAllocation.
+func (c *AutoLoginClient) releaseAllocation(key string) {
+	c.allocationMu.Lock()
+	defer c.allocationMu.Unlock()
+
+	delete(c.allocations, key)
+}
+
+// listAllocations returns the allocations recorded on the controller by
+// recordAllocation, as a map of allocation key to firewall group ID.
+func (c *AutoLoginClient) listAllocations(ctx context.Context) (map[string]string, error) {
+	groups, err := c.ListFirewallGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string)
+	for _, g := range groups {
+		if key, ok := strings.CutPrefix(g.Name, allocationGroupPrefix); ok {
+			result[key] = g.ID
+		}
+	}
+	return result, nil
+}
+
+// recordAllocation stores an allocation on the controller as a firewall group
+// named after its key, so later applies skip it even before it is used. The
+// group has a single member: the address for an address-group, the VLAN ID
+// for a port-group. Nothing references the group.
+func (c *AutoLoginClient) recordAllocation(ctx context.Context, key, groupType, member string) error {
+	_, err := c.CreateFirewallGroup(ctx, &unifi.FirewallGroup{
+		Name:         allocationGroupPrefix + key,
+		GroupType:    groupType,
+		GroupMembers: []string{member},
+	})
+	return err
+}
+
+// deleteAllocation removes the firewall group recorded for key, if any.
+func (c *AutoLoginClient) deleteAllocation(ctx context.Context, key string) error {
+	recorded, err := c.listAllocations(ctx)
+	if err != nil {
+		return err
+	}
+	id, ok := recorded[key]
+	if !ok {
+		return nil
+	}
+	if err := c.DeleteFirewallGroup(ctx, id); err != nil && !isNotFoundError(err) {
+		return err
+	}
+	return nil
+}
+
+// nextFreeIP returns the lowest host address in subnet for which taken
+// reports false, or nil when the subnet is exhausted. The network and
+// broadcast addresses are never returned.
+func nextFreeIP(subnet *net.IPNet, taken func(net.IP) bool) net.IP {
+	base := subnet.IP.To4()
+	if base == nil {
+		return nil
+	}
+	ones, bits := subnet.Mask.Size()
+	size := uint32(1) << uint(bits-ones)
+	if size < 4 {
+		return nil
+	}
+
+	start := binary.BigEndian.Uint32(base)
+	for offset := uint32(1); offset < size-1; offset++ {
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, start+offset)
+		if !taken(ip) {
+			return ip
+		}
+	}
+	return nil
+}
+
+// nextFreeVLAN returns the lowest VLAN ID from start to end, inclusive, for
+// which taken reports false, or 0 when the pool is exhausted.
+func nextFreeVLAN(start, end int, taken func(int) bool) int {
+	for vlan := start; vlan <= end; vlan++ {
+		if !taken(vlan) {
+			return vlan
+		}
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"net"
+	"testing"
+)
+
+func TestNextFreeIP(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/29")
+
+	cases := []struct {
+		name  string
+		taken []string
+		want  string
+	}{
+		{name: "first host", want: "10.0.0.1"},
+		{name: "skips taken", taken: []string{"10.0.0.1", "10.0.0.2"}, want: "10.0.0.3"},
+		{name: "never broadcast", taken: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			taken := make(map[string]bool)
+			for _, ip := range tc.taken {
+				taken[ip] = true
+			}
+			got := nextFreeIP(subnet, func(ip net.IP) bool { return taken[ip.String()] })
+			if tc.want == "" {
+				if got != nil {
+					t.Fatalf("nextFreeIP() = %s, want nil", got)
+				}
+				return
+			}
+			if got.String() != tc.want {
+				t.Fatalf("nextFreeIP() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+
+	_, tiny, _ := net.ParseCIDR("10.0.0.0/31")
+	if got := nextFreeIP(tiny, func(net.IP) bool { return false }); got != nil {
+		t.Fatalf("nextFreeIP(/31) = %s, want nil", got)
+	}
+}
+
+func TestNextFreeVLAN(t *testing.T) {
+	taken := map[int]bool{100: true, 101: true}
+	isTaken := func(vlan int) bool { return taken[vlan] }
+
+	if got := nextFreeVLAN(100, 110, isTaken); got != 102 {
+		t.Fatalf("nextFreeVLAN(100, 110) = %d, want 102", got)
+	}
+	if got := nextFreeVLAN(100, 101, isTaken); got != 0 {
+		t.Fatalf("nextFreeVLAN(100, 101) = %d, want 0", got)
+	}
+}
+
+func TestClaimAllocation(t *testing.T) {
+	c := &AutoLoginClient{}
+
+	if !c.claimAllocation("vlan:100") {
+		t.Fatal("claimAllocation() = false on first claim, want true")
+	}
+	if c.claimAllocation("vlan:100") {
+		t.Fatal("claimAllocation() = true on second claim, want false")
+	}
+
+	// Two allocations in the same run must not pick the same VLAN.
+	isTaken := func(vlan int) bool { return !c.claimAllocation(vlanAllocationKey(vlan)) }
+	first := nextFreeVLAN(200, 210, isTaken)
+	second := nextFreeVLAN(200, 210, isTaken)
+	if first != 200 || second != 201 {
+		t.Fatalf("nextFreeVLAN() = %d then %d, want 200 then 201", first, second)
+	}
+
+	c.releaseAllocation(vlanAllocationKey(200))
+	if got := nextFreeVLAN(200, 210, isTaken); got != 200 {
+		t.Fatalf("nextFreeVLAN() after release = %d, want 200", got)
+	}
+}
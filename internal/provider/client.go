@@ -20,6 +20,36 @@ type AutoLoginClient struct {
 	lastAuthTime time.Time
 	authSem      chan struct{}
 	deviceMu     sync.Map // map[string]*sync.Mutex for per-device locking
+
+	// apGroupUpgradeMu holds a *sync.Mutex per AP group ID for rolling
+	// firmware upgrades.
+	apGroupUpgradeMu sync.Map
+
+	// settingMu holds a *sync.Mutex per setting key for resources that
+	// merge their part into a shared setting, such as unifi_dns_filter in
+	// setting/ips.
+	settingMu sync.Map
+
+	// batchMu guards deviceBatches, the device writes waiting to be sent
+	// together by coalesceDeviceUpdate.
+	batchMu       sync.Mutex
+	deviceBatches map[string]*deviceWriteBatch
+
+	// plannedNetworks records unifi_network plans seen during the current
+	// plan walk (map[string]networkAddressing) so cross-resource validators
+	// can compare against networks that do not exist on the controller yet.
+	// destroyedNetworks holds the IDs of networks planned for destruction.
+	plannedNetworks   sync.Map
+	destroyedNetworks sync.Map
+
+	// allocationMu guards allocations, the IP addresses and VLAN IDs handed
+	// out by unifi_ip_allocation and unifi_vlan_allocation in this process.
+	allocationMu sync.Mutex
+	allocations  map[string]bool
+
+	// adoptExisting is the provider-level default for resources' adopt_existing
+	// attribute. It is set once in provider Configure.
+	adoptExisting bool
 }
 
 // NewAutoLoginClient creates a new auto-login wrapper around the SDK client.
@@ -137,6 +167,43 @@ func (c *AutoLoginClient) DeleteNetwork(ctx context.Context, id string) error {
 	})
 }
 
+// recordPlannedNetwork stores the planned addressing of a unifi_network under
+// key (the network ID, or "name:<name>" for networks not yet created).
+func (c *AutoLoginClient) recordPlannedNetwork(key string, network networkAddressing) {
+	c.plannedNetworks.Store(key, network)
+}
+
+// plannedNetworkSnapshot returns every network recorded by recordPlannedNetwork.
+func (c *AutoLoginClient) plannedNetworkSnapshot() map[string]networkAddressing {
+	result := make(map[string]networkAddressing)
+	c.plannedNetworks.Range(func(key, value any) bool {
+		result[key.(string)] = value.(networkAddressing)
+		return true
+	})
+	return result
+}
+
+// recordDestroyedNetwork marks the network with the given ID as destroyed in
+// the current run and drops any plan recorded for it.
+func (c *AutoLoginClient) recordDestroyedNetwork(id string) {
+	c.plannedNetworks.Delete(id)
+	c.destroyedNetworks.Store(id, true)
+}
+
+// plannedNetworkByID returns the plan recorded for an existing network.
+// destroyed is true when the network is planned for destruction; ok is false
+// when neither has been recorded.
+func (c *AutoLoginClient) plannedNetworkByID(id string) (network networkAddressing, destroyed, ok bool) {
+	if _, gone := c.destroyedNetworks.Load(id); gone {
+		return networkAddressing{}, true, true
+	}
+	v, ok := c.plannedNetworks.Load(id)
+	if !ok {
+		return networkAddressing{}, false, false
+	}
+	return v.(networkAddressing), false, true
+}
+
 // Firewall Rule operations
 
 func (c *AutoLoginClient) ListFirewallRules(ctx context.Context) ([]unifi.FirewallRule, error) {
@@ -677,6 +744,18 @@ func (c *AutoLoginClient) DeleteDynamicDNS(ctx context.Context, id string) error
 	})
 }
 
+// ListDynamicDNSStatus returns the controller's per-interface update status
+// for the site's dynamic DNS configurations.
+func (c *AutoLoginClient) ListDynamicDNSStatus(ctx context.Context) ([]unifi.DynamicDNSStatus, error) {
+	var result []unifi.DynamicDNSStatus
+	err := c.withRetry(ctx, func() error {
+		var err error
+		result, err = c.client.ListDynamicDNSStatus(ctx)
+		return err
+	})
+	return result, err
+}
+
 // NAT Rule operations
 
 func (c *AutoLoginClient) ListNatRules(ctx context.Context) ([]unifi.NatRule, error) {
@@ -877,6 +956,13 @@ func (c *AutoLoginClient) getDeviceLock(deviceID string) *sync.Mutex {
 	return actual.(*sync.Mutex)
 }
 
+// getAPGroupUpgradeLock returns a mutex that serializes rolling firmware
+// upgrades of the devices in an AP group.
+func (c *AutoLoginClient) getAPGroupUpgradeLock(groupID string) *sync.Mutex {
+	actual, _ := c.apGroupUpgradeMu.LoadOrStore(groupID, &sync.Mutex{})
+	return actual.(*sync.Mutex)
+}
+
 func (c *AutoLoginClient) ListDevices(ctx context.Context) (*unifi.DeviceList, error) {
 	var result *unifi.DeviceList
 	err := c.withRetry(ctx, func() error {
@@ -971,6 +1057,30 @@ func (c *AutoLoginClient) ForgetDevice(ctx context.Context, mac string) error {
 	})
 }
 
+func (c *AutoLoginClient) AdoptDevice(ctx context.Context, mac string) error {
+	return c.withRetry(ctx, func() error {
+		return c.client.AdoptDevice(ctx, mac)
+	})
+}
+
+func (c *AutoLoginClient) AdvancedAdoptDevice(ctx context.Context, adopt *unifi.DeviceAdvancedAdopt) error {
+	return c.withRetry(ctx, func() error {
+		return c.client.AdvancedAdoptDevice(ctx, adopt)
+	})
+}
+
+func (c *AutoLoginClient) UpgradeDevice(ctx context.Context, mac string) error {
+	return c.withRetry(ctx, func() error {
+		return c.client.UpgradeDevice(ctx, mac)
+	})
+}
+
+func (c *AutoLoginClient) UpgradeDeviceExternal(ctx context.Context, mac, url string) error {
+	return c.withRetry(ctx, func() error {
+		return c.client.UpgradeDeviceExternal(ctx, mac, url)
+	})
+}
+
 // Site operations
 
 func (c *AutoLoginClient) ListSites(ctx context.Context) ([]unifi.NetworkSite, error) {
@@ -1065,6 +1175,13 @@ func (c *AutoLoginClient) DeleteRADIUSAccount(ctx context.Context, id string) er
 
 // Settings operations
 
+// getSettingLock returns a mutex for the given setting key, creating one if
+// needed. Hold it across the read and the write of a read-modify-write update.
+func (c *AutoLoginClient) getSettingLock(key string) *sync.Mutex {
+	actual, _ := c.settingMu.LoadOrStore(key, &sync.Mutex{})
+	return actual.(*sync.Mutex)
+}
+
 func (c *AutoLoginClient) GetSettingMgmt(ctx context.Context) (*unifi.SettingMgmt, error) {
 	var result *unifi.SettingMgmt
 	err := c.withRetry(ctx, func() error {
@@ -1273,6 +1390,28 @@ func (c *AutoLoginClient) UpdateSettingTeleport(ctx context.Context, setting *un
 	return result, err
 }
 
+// Setting mDNS operations
+
+func (c *AutoLoginClient) GetSettingMDNS(ctx context.Context) (*unifi.SettingMDNS, error) {
+	var result *unifi.SettingMDNS
+	err := c.withRetry(ctx, func() error {
+		var err error
+		result, err = c.client.GetSettingMDNS(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *AutoLoginClient) UpdateSettingMDNS(ctx context.Context, setting *unifi.SettingMDNS) (*unifi.SettingMDNS, error) {
+	var result *unifi.SettingMDNS
+	err := c.withRetry(ctx, func() error {
+		var err error
+		result, err = c.client.UpdateSettingMDNS(ctx, setting)
+		return err
+	})
+	return result, err
+}
+
 // Setting Magic Site-to-Site VPN operations
 
 func (c *AutoLoginClient) GetSettingMagicSiteToSiteVPN(ctx context.Context) (*unifi.SettingMagicSiteToSiteVPN, error) {
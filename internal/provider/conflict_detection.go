@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values for the conflict_detection attribute.
+const (
+	conflictDetectionError = "error"
+	conflictDetectionWarn  = "warn"
+	conflictDetectionOff   = "off"
+)
+
+// lastAppliedPrivateKey is the private state key holding the controller
+// object as Terraform last saw it, after Create, Read or Update.
+const lastAppliedPrivateKey = "last_applied"
+
+// maxConflictDiffFields caps how many changed fields are listed in a
+// conflict diagnostic.
+const maxConflictDiffFields = 20
+
+// lastAppliedSnapshot is the private state representation of a controller
+// object. Object keeps the top-level JSON fields so a mismatch can be
+// reported field by field; Hash makes the common no-change case cheap.
+type lastAppliedSnapshot struct {
+	Hash   string                     `json:"hash"`
+	Object map[string]json.RawMessage `json:"object"`
+}
+
+// conflictDetectionAttribute returns the conflict_detection schema attribute
+// for resources that check for out-of-band edits before Update.
+func conflictDetectionAttribute(resourceType string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("How Update handles edits made to the %s outside Terraform since it was last read. "+
+			"The object is re-read before every update and compared with the copy saved at the last refresh or apply. "+
+			"'error' fails the update and lists the changed fields, 'warn' lists them and overwrites, 'off' skips the check. "+
+			"Defaults to 'warn'.", resourceType),
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(conflictDetectionWarn),
+		Validators: []validator.String{
+			stringvalidator.OneOf(conflictDetectionError, conflictDetectionWarn, conflictDetectionOff),
+		},
+	}
+}
+
+// conflictDetectionOrDefault fills in conflict_detection for state written
+// before the attribute existed, and for imported resources.
+func conflictDetectionOrDefault(current types.String) types.String {
+	if current.IsNull() || current.IsUnknown() {
+		return types.StringValue(conflictDetectionWarn)
+	}
+	return current
+}
+
+// conflictDetectionEnabled reports whether Update should re-read the object
+// and compare it with the saved snapshot.
+func conflictDetectionEnabled(mode types.String) bool {
+	return mode.IsNull() || mode.IsUnknown() || mode.ValueString() != conflictDetectionOff
+}
+
+// snapshotObject encodes a controller object into a lastAppliedSnapshot.
+func snapshotObject(obj any) (lastAppliedSnapshot, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return lastAppliedSnapshot{}, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return lastAppliedSnapshot{}, err
+	}
+
+	// Re-encoding the map sorts the keys, so the hash does not depend on the
+	// field order of the controller's response.
+	canonical, err := json.Marshal(fields)
+	if err != nil {
+		return lastAppliedSnapshot{}, err
+	}
+	sum := sha256.Sum256(canonical)
+
+	return lastAppliedSnapshot{Hash: hex.EncodeToString(sum[:]), Object: fields}, nil
+}
+
+// saveLastApplied records the controller object Terraform has just read or
+// written so the next Update can tell whether it was changed elsewhere.
+func saveLastApplied(ctx context.Context, private privateStateSetter, obj any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	snapshot, err := snapshotObject(obj)
+	if err != nil {
+		diags.AddError("Unable to save last applied object", fmt.Sprintf("Encoding the controller object for conflict detection failed: %s", err))
+		return diags
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		diags.AddError("Unable to save last applied object", fmt.Sprintf("Encoding the conflict detection snapshot failed: %s", err))
+		return diags
+	}
+
+	diags.Append(private.SetKey(ctx, lastAppliedPrivateKey, data)...)
+	return diags
+}
+
+// checkOutOfBandEdits compares the object just re-read from the controller
+// with the snapshot saved at the last refresh or apply. When they differ it
+// adds an error or a warning, depending on mode, listing the changed fields.
+// Objects without a snapshot (imported, or written by an older provider
+// version) are not checked.
+func checkOutOfBandEdits(ctx context.Context, mode types.String, private privateStateGetter, current any, resourceType string, diags *diag.Diagnostics) {
+	if !conflictDetectionEnabled(mode) {
+		return
+	}
+
+	data, d := private.GetKey(ctx, lastAppliedPrivateKey)
+	diags.Append(d...)
+	if diags.HasError() || len(data) == 0 {
+		return
+	}
+
+	var saved lastAppliedSnapshot
+	if err := json.Unmarshal(data, &saved); err != nil {
+		// A snapshot we cannot read is treated like a missing one.
+		return
+	}
+
+	now, err := snapshotObject(current)
+	if err != nil {
+		diags.AddError("Unable to check for conflicting edits", fmt.Sprintf("Encoding the current %s failed: %s", resourceType, err))
+		return
+	}
+	if now.Hash == saved.Hash {
+		return
+	}
+
+	changes := diffSnapshotFields(saved.Object, now.Object)
+	if len(changes) == 0 {
+		return
+	}
+
+	summary := fmt.Sprintf("%s changed outside Terraform", resourceType)
+	detail := fmt.Sprintf("The %s was modified on the controller after Terraform last read it:\n\n%s\n\n",
+		resourceType, strings.Join(changes, "\n"))
+
+	if mode.ValueString() == conflictDetectionError {
+		diags.AddError(summary, detail+"The update was not applied. Run terraform apply again to review a plan against the current "+
+			"controller values, or set conflict_detection = \"warn\" to overwrite them.")
+		return
+	}
+	diags.AddWarning(summary, detail+"These changes are being overwritten by the planned configuration.")
+}
+
+// diffSnapshotFields lists the top-level fields that differ between two
+// snapshots, one "field: old -> new" line each, sorted by field name. Fields
+// prefixed with "x_" hold secrets on the UniFi API and are redacted.
+func diffSnapshotFields(before, after map[string]json.RawMessage) []string {
+	keys := make(map[string]struct{}, len(before)+len(after))
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+	for k := range after {
+		keys[k] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []string
+	for _, k := range sorted {
+		b, a := before[k], after[k]
+		if bytes.Equal(b, a) {
+			continue
+		}
+		if len(changes) == maxConflictDiffFields {
+			changes = append(changes, "  ... (more fields changed)")
+			break
+		}
+		if strings.HasPrefix(k, "x_") {
+			changes = append(changes, fmt.Sprintf("  %s: (sensitive value changed)", k))
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("  %s: %s -> %s", k, snapshotValue(b), snapshotValue(a)))
+	}
+	return changes
+}
+
+// snapshotValue renders a raw JSON field for a diagnostic, shortening long
+// values.
+func snapshotValue(raw json.RawMessage) string {
+	if raw == nil {
+		return "(unset)"
+	}
+	s := string(raw)
+	if len(s) > 80 {
+		s = s[:77] + "..."
+	}
+	return s
+}
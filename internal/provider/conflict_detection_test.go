@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type testWLAN struct {
+	ID         string `json:"_id"`
+	Name       string `json:"name"`
+	HideSSID   bool   `json:"hide_ssid"`
+	Passphrase string `json:"x_passphrase,omitempty"`
+}
+
+func TestCheckOutOfBandEdits(t *testing.T) {
+	ctx := context.Background()
+	applied := &testWLAN{ID: "w1", Name: "Office", Passphrase: "secret"}
+
+	saved := fakePrivateState{}
+	if diags := saveLastApplied(ctx, saved, applied); diags.HasError() {
+		t.Fatalf("saveLastApplied: %v", diags)
+	}
+
+	edited := &testWLAN{ID: "w1", Name: "Office", HideSSID: true, Passphrase: "changed"}
+
+	cases := []struct {
+		name        string
+		mode        types.String
+		private     fakePrivateState
+		current     *testWLAN
+		wantError   bool
+		wantWarning bool
+	}{
+		{name: "unchanged", mode: types.StringValue(conflictDetectionError), private: saved, current: applied},
+		{name: "changed with error", mode: types.StringValue(conflictDetectionError), private: saved, current: edited, wantError: true},
+		{name: "changed with warn", mode: types.StringValue(conflictDetectionWarn), private: saved, current: edited, wantWarning: true},
+		{name: "changed with null mode warns", mode: types.StringNull(), private: saved, current: edited, wantWarning: true},
+		{name: "changed with off", mode: types.StringValue(conflictDetectionOff), private: saved, current: edited},
+		{name: "no snapshot", mode: types.StringValue(conflictDetectionError), private: fakePrivateState{}, current: edited},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			checkOutOfBandEdits(ctx, tc.mode, tc.private, tc.current, "WLAN", &diags)
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("error = %v, want %v (%v)", diags.HasError(), tc.wantError, diags)
+			}
+			if gotWarning := diags.WarningsCount() > 0; gotWarning != tc.wantWarning {
+				t.Fatalf("warning = %v, want %v (%v)", gotWarning, tc.wantWarning, diags)
+			}
+			for _, d := range diags {
+				if strings.Contains(d.Detail(), "changed\"") || strings.Contains(d.Detail(), "secret") {
+					t.Fatalf("diagnostic leaks a sensitive value: %s", d.Detail())
+				}
+				if !strings.Contains(d.Detail(), "hide_ssid: false -> true") {
+					t.Fatalf("diagnostic does not list the changed field: %s", d.Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestDiffSnapshotFields(t *testing.T) {
+	before, err := snapshotObject(map[string]any{"name": "a", "vlan": 10, "x_key": "k1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := snapshotObject(map[string]any{"name": "a", "vlan": 20, "x_key": "k2", "enabled": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := diffSnapshotFields(before.Object, after.Object)
+	want := []string{
+		"  enabled: (unset) -> true",
+		"  vlan: 10 -> 20",
+		"  x_key: (sensitive value changed)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("diffSnapshotFields =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if before.Hash == after.Hash {
+		t.Fatal("different objects produced the same hash")
+	}
+}
@@ -29,6 +29,7 @@ type ContentFilteringResourceModel struct {
 	BlockedCategories types.Set      `tfsdk:"blocked_categories"`
 	AllowedDomains    types.Set      `tfsdk:"allowed_domains"`
 	BlockedDomains    types.Set      `tfsdk:"blocked_domains"`
+	OnDestroy         types.String   `tfsdk:"on_destroy"`
 	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -43,7 +44,7 @@ func (r *ContentFilteringResource) Metadata(ctx context.Context, req resource.Me
 func (r *ContentFilteringResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages UniFi content filtering configuration. " +
-			"This is a singleton resource — one per site. Delete resets to defaults (disabled).",
+			"This is a singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -75,6 +76,7 @@ func (r *ContentFilteringResource) Schema(ctx context.Context, req resource.Sche
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"on_destroy": onDestroyAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -117,6 +119,16 @@ func (r *ContentFilteringResource) Create(ctx context.Context, req resource.Crea
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	original, err := r.client.GetContentFiltering(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "content filtering")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	config := r.planToSDK(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -221,9 +233,19 @@ func (r *ContentFilteringResource) Delete(ctx context.Context, req resource.Dele
 		BlockedDomains:    []string{},
 	}
 
-	_, err := r.client.UpdateContentFiltering(ctx, defaults)
+	config := defaults
+	var original unifi.ContentFiltering
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "content filtering", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		config = &original
+	}
+
+	_, err := r.client.UpdateContentFiltering(ctx, config)
 	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "reset", "content filtering")
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "content filtering")
 		return
 	}
 }
@@ -257,6 +279,7 @@ func (r *ContentFilteringResource) sdkToState(ctx context.Context, filtering *un
 
 	state.ID = types.StringValue("content_filtering")
 	state.Enabled = types.BoolValue(derefBool(filtering.Enabled))
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
 
 	if len(filtering.BlockedCategories) > 0 {
 		s, d := types.SetValueFrom(ctx, types.StringType, filtering.BlockedCategories)
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionDefault resolves a deletion_protection value that is not
+// yet known (unset in config, or missing from state written by an older
+// provider version or by import) to the resource's default.
+func deletionProtectionDefault(current types.Bool, defaultValue bool) types.Bool {
+	if current.IsNull() || current.IsUnknown() {
+		return types.BoolValue(defaultValue)
+	}
+	return current
+}
+
+// isManagementNetwork reports whether a network is the site's untagged
+// management LAN: the corporate network on the LAN group without a VLAN,
+// which carries device adoption and controller traffic. Removing it takes
+// every device on the site offline.
+func isManagementNetwork(purpose, networkGroup string, tagged bool) bool {
+	if networkGroup == "" {
+		networkGroup = "LAN"
+	}
+	return purpose == "corporate" && networkGroup == "LAN" && !tagged
+}
+
+// checkDeletionProtection adds an error and returns true when a resource's
+// deletion_protection is enabled. It is called at the top of Delete, before
+// any controller request is made. The value in state is what counts, so
+// protection must be disabled in a separate apply before the destroy.
+func checkDeletionProtection(diags *diag.Diagnostics, protected types.Bool, resourceType, name string) bool {
+	if !protected.ValueBool() {
+		return false
+	}
+	diags.AddError(
+		"Deletion protection enabled",
+		fmt.Sprintf("Cannot delete %s %q because deletion_protection is enabled. "+
+			"Set deletion_protection = false and apply that change first, then destroy or replace the resource.",
+			resourceType, name),
+	)
+	return true
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIsManagementNetwork(t *testing.T) {
+	cases := []struct {
+		name         string
+		purpose      string
+		networkGroup string
+		tagged       bool
+		want         bool
+	}{
+		{name: "untagged corporate LAN", purpose: "corporate", networkGroup: "LAN", want: true},
+		{name: "missing network group", purpose: "corporate", want: true},
+		{name: "tagged corporate", purpose: "corporate", networkGroup: "LAN", tagged: true},
+		{name: "guest", purpose: "guest", networkGroup: "LAN"},
+		{name: "vlan-only", purpose: "vlan-only", networkGroup: "LAN"},
+		{name: "wan", purpose: "wan", networkGroup: "WAN"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isManagementNetwork(tc.purpose, tc.networkGroup, tc.tagged); got != tc.want {
+				t.Fatalf("isManagementNetwork(%q, %q, %v) = %v, want %v", tc.purpose, tc.networkGroup, tc.tagged, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeletionProtectionDefault(t *testing.T) {
+	cases := []struct {
+		name         string
+		current      types.Bool
+		defaultValue bool
+		want         bool
+	}{
+		{name: "null uses default", current: types.BoolNull(), defaultValue: true, want: true},
+		{name: "unknown uses default", current: types.BoolUnknown(), defaultValue: false, want: false},
+		{name: "explicit false kept", current: types.BoolValue(false), defaultValue: true, want: false},
+		{name: "explicit true kept", current: types.BoolValue(true), defaultValue: false, want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := deletionProtectionDefault(tc.current, tc.defaultValue)
+			if got.IsNull() || got.IsUnknown() || got.ValueBool() != tc.want {
+				t.Fatalf("deletionProtectionDefault(%s, %v) = %s, want %v", tc.current, tc.defaultValue, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	var diags diag.Diagnostics
+	if checkDeletionProtection(&diags, types.BoolValue(false), "site", "Branch") {
+		t.Fatal("checkDeletionProtection blocked an unprotected resource")
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !checkDeletionProtection(&diags, types.BoolValue(true), "site", "Branch") {
+		t.Fatal("checkDeletionProtection allowed a protected resource")
+	}
+	if !diags.HasError() {
+		t.Fatal("expected an error diagnostic")
+	}
+}
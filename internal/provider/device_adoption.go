@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// Device states reported by the controller in the stat/device "state" field.
+const (
+	deviceStateConnected      = 1
+	deviceStatePending        = 2
+	deviceStateAdoptionError  = 9
+	deviceStateAdoptionFailed = 10
+)
+
+// deviceAdoptionPollInterval is how often Create re-reads a device while
+// waiting for adoption to finish.
+const deviceAdoptionPollInterval = 5 * time.Second
+
+// deviceNeedsAdoption reports whether a device is waiting to be adopted by
+// this controller. Devices that failed a previous adoption attempt can be
+// retried as well.
+func deviceNeedsAdoption(adopted bool, state *int) bool {
+	if adopted {
+		return false
+	}
+	if state == nil {
+		return true
+	}
+	switch *state {
+	case deviceStatePending, deviceStateAdoptionError, deviceStateAdoptionFailed:
+		return true
+	}
+	return false
+}
+
+// deviceAdoptionDone classifies a device state seen while waiting for
+// adoption. It returns done once the device is connected, and an error when
+// the controller reports that adoption failed.
+func deviceAdoptionDone(adopted bool, state *int) (bool, error) {
+	if state == nil {
+		return false, nil
+	}
+	switch *state {
+	case deviceStateConnected:
+		return adopted, nil
+	case deviceStateAdoptionError, deviceStateAdoptionFailed:
+		return false, fmt.Errorf("the controller reported adoption failure (state %d)", *state)
+	}
+	return false, nil
+}
+
+// adoptDevice starts adoption of a pending device. When adopt_credentials is
+// set it uses the controller's advanced adoption, which logs into the device
+// over SSH and points it at this controller's inform URL.
+func (r *DeviceResource) adoptDevice(ctx context.Context, device *unifi.DeviceConfig, creds types.Object) error {
+	if creds.IsNull() || creds.IsUnknown() {
+		return r.client.AdoptDevice(ctx, device.MAC)
+	}
+
+	adopt := &unifi.DeviceAdvancedAdopt{
+		MAC:     device.MAC,
+		IP:      device.IP,
+		SSHPort: 22,
+	}
+	attrs := creds.Attributes()
+	if v, ok := attrs["username"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		adopt.Username = v.ValueString()
+	}
+	if v, ok := attrs["password"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		adopt.Password = v.ValueString()
+	}
+	if v, ok := attrs["ip"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		adopt.IP = v.ValueString()
+	}
+	if v, ok := attrs["ssh_port"].(types.Int64); ok && !v.IsNull() && !v.IsUnknown() {
+		adopt.SSHPort = int(v.ValueInt64())
+	}
+	return r.client.AdvancedAdoptDevice(ctx, adopt)
+}
+
+// waitForDeviceAdoption polls the device until it is adopted and connected,
+// the controller reports a failure, or ctx expires.
+func (r *DeviceResource) waitForDeviceAdoption(ctx context.Context, mac string, diags *diag.Diagnostics) *unifi.DeviceConfig {
+	ticker := time.NewTicker(deviceAdoptionPollInterval)
+	defer ticker.Stop()
+
+	var lastState *int
+	for {
+		device, err := r.client.GetDeviceByMAC(ctx, mac)
+		if err != nil && !isNotFoundError(err) && ctx.Err() == nil {
+			handleSDKError(diags, err, "read", "device")
+			return nil
+		}
+		if device != nil {
+			lastState = device.State
+			done, err := deviceAdoptionDone(derefBool(device.Adopted), device.State)
+			if err != nil {
+				diags.AddError("Device adoption failed", fmt.Sprintf("Adopting device %s failed: %s.", mac, err))
+				return nil
+			}
+			if done {
+				return device
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			state := "unknown"
+			if lastState != nil {
+				state = fmt.Sprintf("%d", *lastState)
+			}
+			diags.AddError(
+				"Timed out waiting for device adoption",
+				fmt.Sprintf("Device %s did not reach the connected state before the create timeout (last state: %s). "+
+					"Check that the device can reach the controller's inform URL, or raise timeouts.create.", mac, state),
+			)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package provider
+
+import "testing"
+
+func TestDeviceNeedsAdoption(t *testing.T) {
+	state := func(v int) *int { return &v }
+
+	cases := []struct {
+		name    string
+		adopted bool
+		state   *int
+		want    bool
+	}{
+		{name: "pending", state: state(deviceStatePending), want: true},
+		{name: "adoption error", state: state(deviceStateAdoptionError), want: true},
+		{name: "adoption failed", state: state(deviceStateAdoptionFailed), want: true},
+		{name: "state not reported", want: true},
+		{name: "adopted and connected", adopted: true, state: state(deviceStateConnected)},
+		{name: "adopted but offline", adopted: true, state: state(0)},
+		{name: "adopting", state: state(7)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := deviceNeedsAdoption(tc.adopted, tc.state); got != tc.want {
+				t.Fatalf("deviceNeedsAdoption(%v, %v) = %v, want %v", tc.adopted, tc.state, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeviceAdoptionDone(t *testing.T) {
+	state := func(v int) *int { return &v }
+
+	cases := []struct {
+		name    string
+		adopted bool
+		state   *int
+		want    bool
+		wantErr bool
+	}{
+		{name: "connected", adopted: true, state: state(deviceStateConnected), want: true},
+		{name: "connected before adopted flag", state: state(deviceStateConnected)},
+		{name: "still pending", state: state(deviceStatePending)},
+		{name: "provisioning", adopted: true, state: state(5)},
+		{name: "state not reported"},
+		{name: "adoption error", state: state(deviceStateAdoptionError), wantErr: true},
+		{name: "adoption failed", state: state(deviceStateAdoptionFailed), wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := deviceAdoptionDone(tc.adopted, tc.state)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("deviceAdoptionDone() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Fatalf("deviceAdoptionDone() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
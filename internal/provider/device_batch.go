@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// deviceWriteBatchTimeout bounds the reads and writes of a batch. It matches
+// the default update timeout of the resources that queue writes.
+const deviceWriteBatchTimeout = 5 * time.Minute
+
+// deviceWrite is one change queued in a deviceWriteBatch. result and err are
+// its outcome; err is also set when mutate rejects the change, in which case
+// it is left out of the write. started and cancelled are guarded by
+// AutoLoginClient.batchMu: a write is either cancelled by its caller before
+// the flush starts it, and then never applied, or started, and then its
+// caller waits for the batch result.
+type deviceWrite struct {
+	mutate    func(*unifi.DeviceConfig) error
+	result    *unifi.DeviceConfig
+	err       error
+	started   bool
+	cancelled bool
+}
+
+// deviceWriteBatch collects changes to one device that are applied with a
+// single UpdateDevice call.
+type deviceWriteBatch struct {
+	fetch  func(ctx context.Context) (*unifi.DeviceConfig, error)
+	writes []*deviceWrite
+	done   chan struct{}
+}
+
+// coalesceDeviceUpdate applies mutate to the device with the given ID,
+// batching it with other changes to the same device. A batch is flushed as
+// soon as the device lock is free, so a write to an idle device is sent
+// straight away, and writes queued while an earlier one is in flight are sent
+// together after it. The batch re-reads the device with fetch, applies every
+// queued mutation in order, and writes the device once under its device
+// lock, so 48 port overrides cost a handful of PUTs and re-provisions instead
+// of 48. mutate runs against the freshly read device and may reject the
+// change by returning an error, which is returned to its caller alone.
+func (c *AutoLoginClient) coalesceDeviceUpdate(ctx context.Context, deviceID string, fetch func(ctx context.Context) (*unifi.DeviceConfig, error), mutate func(*unifi.DeviceConfig) error) (*unifi.DeviceConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.batchMu.Lock()
+	if c.deviceBatches == nil {
+		c.deviceBatches = make(map[string]*deviceWriteBatch)
+	}
+	batch, ok := c.deviceBatches[deviceID]
+	if !ok {
+		batch = &deviceWriteBatch{
+			fetch: fetch,
+			done:  make(chan struct{}),
+		}
+		c.deviceBatches[deviceID] = batch
+
+		// The batch outlives the request that started it, so it must not be
+		// cancelled with it. Each caller still stops waiting on its own ctx.
+		go c.flushDeviceBatch(context.WithoutCancel(ctx), deviceID, batch)
+	}
+	write := &deviceWrite{mutate: mutate}
+	batch.writes = append(batch.writes, write)
+	c.batchMu.Unlock()
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		// A write the flush has not started yet is dropped, so the device is
+		// not changed behind a caller that already reported failure. Once
+		// started it is sent, and the caller waits for the outcome, which
+		// deviceWriteBatchTimeout bounds.
+		c.batchMu.Lock()
+		if !write.started {
+			write.cancelled = true
+			for i, w := range batch.writes {
+				if w == write {
+					batch.writes = append(batch.writes[:i:i], batch.writes[i+1:]...)
+					break
+				}
+			}
+			c.batchMu.Unlock()
+			return nil, ctx.Err()
+		}
+		c.batchMu.Unlock()
+		<-batch.done
+	}
+
+	return write.result, write.err
+}
+
+// flushDeviceBatch writes a batch. It waits for the device lock first and
+// only then removes the batch from the pending set, so writes queued while
+// an earlier write to the device is in flight join it, and writes queued
+// once it has started begin a new batch.
+//
+// When the combined UpdateDevice fails, each change is retried on its own,
+// so the error is reported by the change the controller rejects rather than
+// by every change in the batch.
+func (c *AutoLoginClient) flushDeviceBatch(ctx context.Context, deviceID string, batch *deviceWriteBatch) {
+	defer close(batch.done)
+
+	ctx, cancel := context.WithTimeout(ctx, deviceWriteBatchTimeout)
+	defer cancel()
+
+	deviceLock := c.getDeviceLock(deviceID)
+	deviceLock.Lock()
+	defer deviceLock.Unlock()
+
+	c.batchMu.Lock()
+	if c.deviceBatches[deviceID] == batch {
+		delete(c.deviceBatches, deviceID)
+	}
+	var writes []*deviceWrite
+	for _, w := range batch.writes {
+		if !w.cancelled {
+			w.started = true
+			writes = append(writes, w)
+		}
+	}
+	c.batchMu.Unlock()
+
+	if len(writes) == 0 {
+		return
+	}
+
+	device, err := batch.fetch(ctx)
+	if err != nil {
+		for _, w := range writes {
+			w.err = err
+		}
+		return
+	}
+
+	var applied []*deviceWrite
+	for _, w := range writes {
+		if w.err = w.mutate(device); w.err == nil {
+			applied = append(applied, w)
+		}
+	}
+	if len(applied) == 0 {
+		return
+	}
+
+	result, err := c.UpdateDevice(ctx, device.ID, device)
+	if err == nil || len(applied) == 1 {
+		for _, w := range applied {
+			w.result, w.err = result, err
+		}
+		return
+	}
+
+	for _, w := range applied {
+		w.result, w.err = c.applyDeviceWrite(ctx, batch.fetch, w.mutate)
+	}
+}
+
+// applyDeviceWrite re-reads the device and writes a single change to it. It
+// is used to attribute the failure of a combined write to its cause.
+func (c *AutoLoginClient) applyDeviceWrite(ctx context.Context, fetch func(ctx context.Context) (*unifi.DeviceConfig, error), mutate func(*unifi.DeviceConfig) error) (*unifi.DeviceConfig, error) {
+	device, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := mutate(device); err != nil {
+		return nil, err
+	}
+	return c.UpdateDevice(ctx, device.ID, device)
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// fakeDeviceWriter records UpdateDevice calls. reject, when set, can refuse
+// an update. Other NetworkManager methods are not implemented and panic if
+// called.
+type fakeDeviceWriter struct {
+	unifi.NetworkManager
+
+	mu      sync.Mutex
+	updates []unifi.DeviceConfig
+	reject  func(*unifi.DeviceConfig) error
+}
+
+func (f *fakeDeviceWriter) UpdateDevice(ctx context.Context, id string, device *unifi.DeviceConfig) (*unifi.DeviceConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.reject != nil {
+		if err := f.reject(device); err != nil {
+			return nil, err
+		}
+	}
+	f.updates = append(f.updates, *device)
+	return device, nil
+}
+
+// waitForQueuedWrites waits until n writes are queued for the device.
+func waitForQueuedWrites(c *AutoLoginClient, deviceID string, n int) {
+	for {
+		c.batchMu.Lock()
+		batch := c.deviceBatches[deviceID]
+		queued := batch != nil && len(batch.writes) == n
+		c.batchMu.Unlock()
+		if queued {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCoalesceDeviceUpdate_batchesConcurrentWrites(t *testing.T) {
+	writer := &fakeDeviceWriter{}
+	c := &AutoLoginClient{client: writer}
+
+	var fetches int
+	var fetchMu sync.Mutex
+	fetch := func(ctx context.Context) (*unifi.DeviceConfig, error) {
+		fetchMu.Lock()
+		fetches++
+		fetchMu.Unlock()
+		return &unifi.DeviceConfig{ID: "dev1", MAC: "aa:bb:cc:dd:ee:01"}, nil
+	}
+
+	// Holding the device lock stands in for a write already in flight;
+	// everything queued meanwhile must go out as one batch.
+	deviceLock := c.getDeviceLock("dev1")
+	deviceLock.Lock()
+
+	const ports = 8
+	var wg sync.WaitGroup
+	errs := make([]error, ports)
+	for i := 0; i < ports; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			portIdx := i + 1
+			_, errs[i] = c.coalesceDeviceUpdate(context.Background(), "dev1", fetch, func(device *unifi.DeviceConfig) error {
+				device.PortOverrides = append(device.PortOverrides, unifi.PortOverride{PortIdx: &portIdx})
+				return nil
+			})
+		}(i)
+	}
+	waitForQueuedWrites(c, "dev1", ports)
+	deviceLock.Unlock()
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("write %d failed: %v", i+1, err)
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("device fetched %d times, want 1", fetches)
+	}
+	if len(writer.updates) != 1 {
+		t.Fatalf("UpdateDevice called %d times, want 1", len(writer.updates))
+	}
+	if got := len(writer.updates[0].PortOverrides); got != ports {
+		t.Fatalf("batched update has %d port overrides, want %d", got, ports)
+	}
+}
+
+func TestCoalesceDeviceUpdate_singleWriteNotDelayed(t *testing.T) {
+	writer := &fakeDeviceWriter{}
+	c := &AutoLoginClient{client: writer}
+	fetch := func(ctx context.Context) (*unifi.DeviceConfig, error) {
+		return &unifi.DeviceConfig{ID: "dev1"}, nil
+	}
+
+	start := time.Now()
+	if _, err := c.coalesceDeviceUpdate(context.Background(), "dev1", fetch, func(*unifi.DeviceConfig) error { return nil }); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("single write took %s, want it sent without waiting for other writes", elapsed)
+	}
+	if len(writer.updates) != 1 {
+		t.Fatalf("UpdateDevice called %d times, want 1", len(writer.updates))
+	}
+}
+
+func TestCoalesceDeviceUpdate_failedBatchAttributed(t *testing.T) {
+	rejectErr := errors.New("port 2 cannot be configured")
+	writer := &fakeDeviceWriter{reject: func(device *unifi.DeviceConfig) error {
+		for _, o := range device.PortOverrides {
+			if *o.PortIdx == 2 {
+				return rejectErr
+			}
+		}
+		return nil
+	}}
+	c := &AutoLoginClient{client: writer}
+	fetch := func(ctx context.Context) (*unifi.DeviceConfig, error) {
+		return &unifi.DeviceConfig{ID: "dev1"}, nil
+	}
+
+	deviceLock := c.getDeviceLock("dev1")
+	deviceLock.Lock()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			portIdx := i + 1
+			_, errs[i] = c.coalesceDeviceUpdate(context.Background(), "dev1", fetch, func(device *unifi.DeviceConfig) error {
+				device.PortOverrides = append(device.PortOverrides, unifi.PortOverride{PortIdx: &portIdx})
+				return nil
+			})
+		}(i)
+	}
+	waitForQueuedWrites(c, "dev1", len(errs))
+	deviceLock.Unlock()
+	wg.Wait()
+
+	for i, err := range errs {
+		want := error(nil)
+		if i == 1 {
+			want = rejectErr
+		}
+		if !errors.Is(err, want) {
+			t.Fatalf("write for port %d error = %v, want %v", i+1, err, want)
+		}
+	}
+	if len(writer.updates) != 2 {
+		t.Fatalf("UpdateDevice succeeded %d times, want 2 (ports 1 and 3 on their own)", len(writer.updates))
+	}
+}
+
+func TestCoalesceDeviceUpdate_callerContextCancelled(t *testing.T) {
+	c := &AutoLoginClient{client: &fakeDeviceWriter{}}
+	fetch := func(ctx context.Context) (*unifi.DeviceConfig, error) {
+		return &unifi.DeviceConfig{ID: "dev1"}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.coalesceDeviceUpdate(ctx, "dev1", fetch, func(*unifi.DeviceConfig) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("coalesceDeviceUpdate error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCoalesceDeviceUpdate_rejectedWrite(t *testing.T) {
+	writer := &fakeDeviceWriter{}
+	c := &AutoLoginClient{client: writer}
+	fetch := func(ctx context.Context) (*unifi.DeviceConfig, error) {
+		return &unifi.DeviceConfig{ID: "dev1"}, nil
+	}
+
+	rejectErr := errors.New("port 2 is already a member of the LAG on port 1")
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			portIdx := i + 1
+			_, errs[i] = c.coalesceDeviceUpdate(context.Background(), "dev1", fetch, func(device *unifi.DeviceConfig) error {
+				if portIdx == 2 {
+					return rejectErr
+				}
+				device.PortOverrides = append(device.PortOverrides, unifi.PortOverride{PortIdx: &portIdx})
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	if errs[0] != nil {
+		t.Fatalf("accepted write failed: %v", errs[0])
+	}
+	if !errors.Is(errs[1], rejectErr) {
+		t.Fatalf("rejected write error = %v, want %v", errs[1], rejectErr)
+	}
+	if len(writer.updates) != 1 || len(writer.updates[0].PortOverrides) != 1 {
+		t.Fatalf("updates = %+v, want one update with only port 1", writer.updates)
+	}
+}
+
+func TestCoalesceDeviceUpdate_cancelledWriteNotApplied(t *testing.T) {
+	writer := &fakeDeviceWriter{}
+	c := &AutoLoginClient{client: writer}
+	fetch := func(ctx context.Context) (*unifi.DeviceConfig, error) {
+		return &unifi.DeviceConfig{ID: "dev1"}, nil
+	}
+	addPort := func(portIdx int) func(*unifi.DeviceConfig) error {
+		return func(device *unifi.DeviceConfig) error {
+			device.PortOverrides = append(device.PortOverrides, unifi.PortOverride{PortIdx: &portIdx})
+			return nil
+		}
+	}
+
+	// The first write is cancelled while its batch waits for the device
+	// lock; the second is written.
+	deviceLock := c.getDeviceLock("dev1")
+	deviceLock.Lock()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := c.coalesceDeviceUpdate(ctx, "dev1", fetch, addPort(1))
+		cancelled <- err
+	}()
+	waitForQueuedWrites(c, "dev1", 1)
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled write error = %v, want context.Canceled", err)
+	}
+	deviceLock.Unlock()
+
+	if _, err := c.coalesceDeviceUpdate(context.Background(), "dev1", fetch, addPort(2)); err != nil {
+		t.Fatalf("second write failed: %v", err)
+	}
+	if len(writer.updates) != 1 || len(writer.updates[0].PortOverrides) != 1 || *writer.updates[0].PortOverrides[0].PortIdx != 2 {
+		t.Fatalf("updates = %+v, want one update with only port 2", writer.updates)
+	}
+}
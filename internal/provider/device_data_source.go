@@ -22,16 +22,17 @@ type DeviceDataSource struct {
 }
 
 type DeviceDataSourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	SiteID  types.String `tfsdk:"site_id"`
-	MAC     types.String `tfsdk:"mac"`
-	Name    types.String `tfsdk:"name"`
-	Model   types.String `tfsdk:"model"`
-	Type    types.String `tfsdk:"type"`
-	Version types.String `tfsdk:"version"`
-	IP      types.String `tfsdk:"ip"`
-	Adopted types.Bool   `tfsdk:"adopted"`
-	State   types.Int64  `tfsdk:"state"`
+	ID        types.String `tfsdk:"id"`
+	SiteID    types.String `tfsdk:"site_id"`
+	MAC       types.String `tfsdk:"mac"`
+	Name      types.String `tfsdk:"name"`
+	Model     types.String `tfsdk:"model"`
+	Type      types.String `tfsdk:"type"`
+	Version   types.String `tfsdk:"version"`
+	IP        types.String `tfsdk:"ip"`
+	Adopted   types.Bool   `tfsdk:"adopted"`
+	State     types.Int64  `tfsdk:"state"`
+	PortTable types.List   `tfsdk:"port_table"`
 }
 
 func NewDeviceDataSource() datasource.DataSource {
@@ -91,6 +92,68 @@ func (d *DeviceDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Description: "The state of the device: 0=offline, 1=connected, 2=pending.",
 				Computed:    true,
 			},
+			"port_table": schema.ListNestedAttribute{
+				Description: "The live state of each port on the device, as reported by the controller. Empty for devices without switch ports.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"port_idx": schema.Int64Attribute{
+							Description: "The port index (1-based).",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The port name.",
+							Computed:    true,
+						},
+						"up": schema.BoolAttribute{
+							Description: "Whether the port has link.",
+							Computed:    true,
+						},
+						"speed": schema.Int64Attribute{
+							Description: "The negotiated link speed in Mbps.",
+							Computed:    true,
+						},
+						"full_duplex": schema.BoolAttribute{
+							Description: "Whether the link is full duplex.",
+							Computed:    true,
+						},
+						"poe_power": schema.Float64Attribute{
+							Description: "The PoE power drawn on the port, in watts. Null for ports without PoE.",
+							Computed:    true,
+						},
+						"port_profile_id": schema.StringAttribute{
+							Description: "The ID of the port profile applied to the port.",
+							Computed:    true,
+						},
+						"stp_state": schema.StringAttribute{
+							Description: "The spanning tree state of the port (e.g., 'forwarding', 'blocking', 'disabled').",
+							Computed:    true,
+						},
+						"is_uplink": schema.BoolAttribute{
+							Description: "Whether the port is the device's uplink.",
+							Computed:    true,
+						},
+						"lldp": schema.SingleNestedAttribute{
+							Description: "The LLDP neighbor seen on the port. Null when no neighbor is advertising.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"chassis_id": schema.StringAttribute{
+									Description: "The neighbor's chassis ID, usually its MAC address.",
+									Computed:    true,
+								},
+								"port_id": schema.StringAttribute{
+									Description: "The neighbor's port identifier.",
+									Computed:    true,
+								},
+								"system_name": schema.StringAttribute{
+									Description: "The neighbor's system name.",
+									Computed:    true,
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -202,5 +265,9 @@ func (d *DeviceDataSource) sdkToState(ctx context.Context, device *unifi.DeviceC
 		state.State = types.Int64Null()
 	}
 
+	portTable, portDiags := portTableToList(device)
+	diags.Append(portDiags...)
+	state.PortTable = portTable
+
 	return diags
 }
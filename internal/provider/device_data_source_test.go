@@ -24,6 +24,30 @@ func TestAccDeviceDataSource_byMAC(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.unifi_device.test", "id"),
 					resource.TestCheckResourceAttr("data.unifi_device.test", "mac", mac),
 					resource.TestCheckResourceAttrSet("data.unifi_device.test", "type"),
+					resource.TestCheckResourceAttrSet("data.unifi_device.test", "port_table.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeviceDataSource_portTable(t *testing.T) {
+	testAccPreCheckSwitch(t)
+	mac := testAccGetFirstSwitchMAC(t)
+	if mac == "" {
+		t.Skip("No switch MAC available")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceDataSourceConfig_byMAC(mac),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.unifi_device.test", "port_table.0.port_idx"),
+					resource.TestCheckResourceAttrSet("data.unifi_device.test", "port_table.0.up"),
+					resource.TestCheckResourceAttrSet("data.unifi_device.test", "port_table.0.stp_state"),
 				),
 			},
 		},
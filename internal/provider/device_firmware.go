@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// Values for firmware.channel and firmware.upgrade_strategy.
+const (
+	firmwareChannelLatest    = "latest"
+	firmwareStrategyParallel = "parallel"
+	firmwareStrategyRolling  = "rolling"
+)
+
+// deviceUpgradePollInterval is how often a device is re-read while it
+// installs firmware and reboots.
+const deviceUpgradePollInterval = 10 * time.Second
+
+var deviceFirmwareAttrTypes = map[string]attr.Type{
+	"target_version":       types.StringType,
+	"firmware_url":         types.StringType,
+	"channel":              types.StringType,
+	"upgrade_window_start": types.StringType,
+	"upgrade_window_end":   types.StringType,
+	"upgrade_strategy":     types.StringType,
+	"ap_group_id":          types.StringType,
+	"upgrade_available":    types.BoolType,
+}
+
+// deviceFirmwareSettings holds the firmware block as plain values.
+type deviceFirmwareSettings struct {
+	TargetVersion string
+	FirmwareURL   string
+	Channel       string
+	WindowStart   string
+	WindowEnd     string
+	Strategy      string
+	APGroupID     string
+}
+
+func firmwareSettingsFromObject(obj types.Object) deviceFirmwareSettings {
+	var s deviceFirmwareSettings
+	attrs := obj.Attributes()
+	str := func(name string) string {
+		if v, ok := attrs[name].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			return v.ValueString()
+		}
+		return ""
+	}
+	s.TargetVersion = str("target_version")
+	s.FirmwareURL = str("firmware_url")
+	s.Channel = str("channel")
+	s.WindowStart = str("upgrade_window_start")
+	s.WindowEnd = str("upgrade_window_end")
+	s.Strategy = str("upgrade_strategy")
+	s.APGroupID = str("ap_group_id")
+	return s
+}
+
+// firmwareAttrSet reports whether a string attribute of the firmware block is
+// set or not yet known, so validation does not reject values that come from
+// other resources.
+func firmwareAttrSet(attrs map[string]attr.Value, name string) bool {
+	v, ok := attrs[name].(types.String)
+	return ok && (v.IsUnknown() || !v.IsNull())
+}
+
+// validateDeviceFirmware checks the combinations the schema cannot express.
+func validateDeviceFirmware(obj types.Object, diags *diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return
+	}
+	attrs := obj.Attributes()
+	p := path.Root("firmware")
+
+	hasTarget := firmwareAttrSet(attrs, "target_version")
+	hasChannel := firmwareAttrSet(attrs, "channel")
+	if hasTarget == hasChannel {
+		diags.AddAttributeError(p, "Invalid firmware configuration",
+			"Set exactly one of firmware.target_version and firmware.channel.")
+	}
+	if firmwareAttrSet(attrs, "firmware_url") && !hasTarget {
+		diags.AddAttributeError(p.AtName("firmware_url"), "Invalid firmware configuration",
+			"firmware.firmware_url requires firmware.target_version, the version the image installs.")
+	}
+	if firmwareAttrSet(attrs, "upgrade_window_start") != firmwareAttrSet(attrs, "upgrade_window_end") {
+		diags.AddAttributeError(p, "Invalid firmware configuration",
+			"Set both firmware.upgrade_window_start and firmware.upgrade_window_end, or neither.")
+	}
+
+	s := firmwareSettingsFromObject(obj)
+	for name, value := range map[string]string{"upgrade_window_start": s.WindowStart, "upgrade_window_end": s.WindowEnd} {
+		if value == "" {
+			continue
+		}
+		if _, err := parseWindowTime(value); err != nil {
+			diags.AddAttributeError(p.AtName(name), "Invalid upgrade window", err.Error())
+		}
+	}
+	if s.Strategy == firmwareStrategyRolling && !firmwareAttrSet(attrs, "ap_group_id") {
+		diags.AddAttributeError(p.AtName("ap_group_id"), "Invalid firmware configuration",
+			"firmware.upgrade_strategy = \"rolling\" requires firmware.ap_group_id, the AP group whose devices upgrade one at a time.")
+	}
+}
+
+// versionMatches reports whether an installed firmware version satisfies a
+// target. A target may leave out trailing components, so "6.6.55" matches
+// "6.6.55.15189".
+func versionMatches(installed, target string) bool {
+	return installed == target || strings.HasPrefix(installed, target+".")
+}
+
+// parseWindowTime parses an "HH:MM" time of day into minutes after midnight.
+func parseWindowTime(s string) (int, error) {
+	h, m, ok := strings.Cut(s, ":")
+	if ok && len(h) == 2 && len(m) == 2 {
+		hours, errH := strconv.Atoi(h)
+		minutes, errM := strconv.Atoi(m)
+		if errH == nil && errM == nil && hours >= 0 && hours < 24 && minutes >= 0 && minutes < 60 {
+			return hours*60 + minutes, nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not a time of day in HH:MM format (e.g., 02:30)", s)
+}
+
+// inUpgradeWindow reports whether now falls inside the window from start to
+// end, both "HH:MM" in UTC. A window whose end is before its start runs over
+// midnight. An empty window always matches.
+func inUpgradeWindow(now time.Time, start, end string) (bool, error) {
+	if start == "" && end == "" {
+		return true, nil
+	}
+	from, err := parseWindowTime(start)
+	if err != nil {
+		return false, err
+	}
+	to, err := parseWindowTime(end)
+	if err != nil {
+		return false, err
+	}
+
+	now = now.UTC()
+	current := now.Hour()*60 + now.Minute()
+	if from <= to {
+		return current >= from && current < to, nil
+	}
+	return current >= from || current < to, nil
+}
+
+// firmwareUpgradeDone reports whether a device seen while waiting for an
+// upgrade has come back connected on new firmware.
+func firmwareUpgradeDone(state *int, installed, previous, target string) bool {
+	if state == nil || *state != deviceStateConnected || installed == previous {
+		return false
+	}
+	return target == "" || versionMatches(installed, target)
+}
+
+// firmwareToObject returns the firmware block for state. Configured values
+// are kept and upgrade_available comes from the device. With refresh set, as
+// on Read, a target_version the device is not running is replaced with the
+// installed version so the next plan shows the difference. A known planned
+// upgrade_available is kept on apply, since the plan may have asked for an
+// upgrade that was deferred to the upgrade window.
+func firmwareToObject(obj types.Object, device *unifi.DeviceConfig, refresh bool) (types.Object, diag.Diagnostics) {
+	attrs := make(map[string]attr.Value, len(deviceFirmwareAttrTypes))
+	for k, v := range obj.Attributes() {
+		attrs[k] = v
+	}
+
+	if target, ok := attrs["target_version"].(types.String); ok && refresh && !target.IsNull() &&
+		device.Version != "" && !versionMatches(device.Version, target.ValueString()) {
+		attrs["target_version"] = types.StringValue(device.Version)
+	}
+
+	if planned, ok := attrs["upgrade_available"].(types.Bool); refresh || !ok || planned.IsNull() || planned.IsUnknown() {
+		attrs["upgrade_available"] = types.BoolValue(derefBool(device.Upgradable))
+	}
+
+	return types.ObjectValue(deviceFirmwareAttrTypes, attrs)
+}
+
+// firmwareUpgradeRequest decides whether a device needs a firmware upgrade
+// and how to start it. It returns upgrade false when the device already runs
+// the wanted firmware, and an error when the target cannot be reached.
+func firmwareUpgradeRequest(s deviceFirmwareSettings, device *unifi.DeviceConfig) (upgrade bool, url string, err error) {
+	if s.Channel == firmwareChannelLatest {
+		return derefBool(device.Upgradable), "", nil
+	}
+	if s.TargetVersion == "" || versionMatches(device.Version, s.TargetVersion) {
+		return false, "", nil
+	}
+	if s.FirmwareURL != "" {
+		return true, s.FirmwareURL, nil
+	}
+	if derefBool(device.Upgradable) && versionMatches(device.UpgradeToFirmware, s.TargetVersion) {
+		return true, "", nil
+	}
+
+	offered := device.UpgradeToFirmware
+	if !derefBool(device.Upgradable) || offered == "" {
+		offered = "no upgrade"
+	}
+	return false, "", fmt.Errorf("device %s runs firmware %s and the controller offers %s. Set firmware.firmware_url to "+
+		"an image of %s to install it", device.MAC, device.Version, offered, s.TargetVersion)
+}
+
+// applyFirmware upgrades a device to the firmware block's target when it is
+// needed and the upgrade window is open, waits for the device to come back on
+// the new firmware, and returns the device as last read. It returns the
+// device unchanged when nothing was done.
+func (r *DeviceResource) applyFirmware(ctx context.Context, settings types.Object, device *unifi.DeviceConfig, diags *diag.Diagnostics) *unifi.DeviceConfig {
+	if settings.IsNull() || settings.IsUnknown() {
+		return device
+	}
+	s := firmwareSettingsFromObject(settings)
+
+	upgrade, url, err := firmwareUpgradeRequest(s, device)
+	if err != nil {
+		diags.AddAttributeError(path.Root("firmware"), "Firmware target not available", err.Error())
+		return device
+	}
+	if !upgrade {
+		return device
+	}
+
+	open, err := inUpgradeWindow(time.Now(), s.WindowStart, s.WindowEnd)
+	if err != nil {
+		diags.AddAttributeError(path.Root("firmware"), "Invalid upgrade window", err.Error())
+		return device
+	}
+	if !open {
+		diags.AddWarning(
+			"Firmware upgrade deferred",
+			fmt.Sprintf("Device %s runs firmware %s and needs an upgrade, but the upgrade window %s-%s UTC is closed. "+
+				"The upgrade is shown again in the next plan.", device.MAC, device.Version, s.WindowStart, s.WindowEnd),
+		)
+		return device
+	}
+
+	if s.Strategy == firmwareStrategyRolling {
+		groupLock := r.client.getAPGroupUpgradeLock(s.APGroupID)
+		groupLock.Lock()
+		defer groupLock.Unlock()
+
+		if !r.waitForAPGroupConnected(ctx, s.APGroupID, device.MAC, diags) {
+			return device
+		}
+	}
+
+	if url != "" {
+		err = r.client.UpgradeDeviceExternal(ctx, device.MAC, url)
+	} else {
+		err = r.client.UpgradeDevice(ctx, device.MAC)
+	}
+	if err != nil {
+		handleSDKError(diags, err, "upgrade", "device")
+		return device
+	}
+
+	if upgraded := r.waitForDeviceUpgrade(ctx, device.MAC, device.Version, s.TargetVersion, diags); upgraded != nil {
+		return upgraded
+	}
+	return device
+}
+
+// waitForDeviceUpgrade polls a device until it is connected again on firmware
+// other than previous, and matching target when one is given.
+func (r *DeviceResource) waitForDeviceUpgrade(ctx context.Context, mac, previous, target string, diags *diag.Diagnostics) *unifi.DeviceConfig {
+	ticker := time.NewTicker(deviceUpgradePollInterval)
+	defer ticker.Stop()
+
+	lastVersion := previous
+	for {
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Device firmware upgrade did not finish",
+				fmt.Sprintf("Device %s did not come back on new firmware before the timeout (last reported version: %s). "+
+					"Firmware upgrades often take several minutes; raise timeouts.create or timeouts.update, and check the "+
+					"device on the controller.", mac, lastVersion),
+			)
+			return nil
+		case <-ticker.C:
+		}
+
+		device, err := r.client.GetDeviceByMAC(ctx, mac)
+		if err != nil {
+			if isNotFoundError(err) || ctx.Err() != nil {
+				continue
+			}
+			handleSDKError(diags, err, "read", "device")
+			return nil
+		}
+		if device.Version != "" {
+			lastVersion = device.Version
+		}
+		if firmwareUpgradeDone(device.State, device.Version, previous, target) {
+			return device
+		}
+	}
+}
+
+// waitForAPGroupConnected waits until every device in an AP group other than
+// mac is connected, so a rolling upgrade never takes down two devices of the
+// group at once. Upgrades started by this provider are already serialized by
+// the group lock; the wait also covers devices upgrading for other reasons.
+func (r *DeviceResource) waitForAPGroupConnected(ctx context.Context, groupID, mac string, diags *diag.Diagnostics) bool {
+	ticker := time.NewTicker(deviceUpgradePollInterval)
+	defer ticker.Stop()
+
+	busy := "unknown"
+	for {
+		member, err := r.apGroupBusyDevice(ctx, groupID, mac)
+		if err != nil {
+			if ctx.Err() == nil {
+				handleSDKError(diags, err, "read", "AP group")
+				return false
+			}
+		} else if member == "" {
+			return true
+		} else {
+			busy = member
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Rolling firmware upgrade blocked",
+				fmt.Sprintf("Device %s in AP group %s was still not connected when the timeout ran out, so device %s "+
+					"was not upgraded.", busy, groupID, mac),
+			)
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// apGroupBusyDevice returns the MAC of a device in the AP group, other than
+// mac, that is not connected, or "" when all are.
+func (r *DeviceResource) apGroupBusyDevice(ctx context.Context, groupID, mac string) (string, error) {
+	groups, err := r.client.ListAPGroups(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var members []string
+	found := false
+	for _, g := range groups {
+		if g.ID == groupID {
+			members = g.DeviceMACs
+			found = true
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("AP group %s not found", groupID)
+	}
+
+	for _, member := range members {
+		if strings.EqualFold(member, mac) {
+			continue
+		}
+		device, err := r.client.GetDeviceByMAC(ctx, member)
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return "", err
+		}
+		if device.State == nil || *device.State != deviceStateConnected {
+			return member, nil
+		}
+	}
+	return "", nil
+}
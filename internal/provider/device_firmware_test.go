@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func testDeviceFirmwareObject(t *testing.T, fields map[string]string) types.Object {
+	t.Helper()
+
+	vals := map[string]attr.Value{
+		"target_version":       types.StringNull(),
+		"firmware_url":         types.StringNull(),
+		"channel":              types.StringNull(),
+		"upgrade_window_start": types.StringNull(),
+		"upgrade_window_end":   types.StringNull(),
+		"upgrade_strategy":     types.StringNull(),
+		"ap_group_id":          types.StringNull(),
+		"upgrade_available":    types.BoolUnknown(),
+	}
+	for k, v := range fields {
+		vals[k] = types.StringValue(v)
+	}
+
+	obj, d := types.ObjectValue(deviceFirmwareAttrTypes, vals)
+	if d.HasError() {
+		t.Fatalf("building firmware object: %v", d)
+	}
+	return obj
+}
+
+func TestValidateDeviceFirmware(t *testing.T) {
+	cases := []struct {
+		name       string
+		fields     map[string]string
+		wantErrors int
+	}{
+		{name: "target version", fields: map[string]string{"target_version": "6.6.55"}},
+		{name: "channel", fields: map[string]string{"channel": "latest"}},
+		{name: "neither", wantErrors: 1},
+		{name: "both", fields: map[string]string{"target_version": "6.6.55", "channel": "latest"}, wantErrors: 1},
+		{name: "url without target", fields: map[string]string{"channel": "latest", "firmware_url": "https://fw/x.bin"}, wantErrors: 1},
+		{name: "url with target", fields: map[string]string{"target_version": "6.5.28", "firmware_url": "https://fw/x.bin"}},
+		{name: "window", fields: map[string]string{"channel": "latest", "upgrade_window_start": "22:00", "upgrade_window_end": "04:00"}},
+		{name: "window start only", fields: map[string]string{"channel": "latest", "upgrade_window_start": "22:00"}, wantErrors: 1},
+		{name: "bad window time", fields: map[string]string{"channel": "latest", "upgrade_window_start": "2am", "upgrade_window_end": "04:00"}, wantErrors: 1},
+		{name: "rolling with group", fields: map[string]string{"channel": "latest", "upgrade_strategy": "rolling", "ap_group_id": "abc"}},
+		{name: "rolling without group", fields: map[string]string{"channel": "latest", "upgrade_strategy": "rolling"}, wantErrors: 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateDeviceFirmware(testDeviceFirmwareObject(t, tc.fields), &diags)
+			if got := diags.ErrorsCount(); got != tc.wantErrors {
+				t.Fatalf("validateDeviceFirmware() errors = %d, want %d: %v", got, tc.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestVersionMatches(t *testing.T) {
+	cases := []struct {
+		installed, target string
+		want              bool
+	}{
+		{"6.6.55.15189", "6.6.55.15189", true},
+		{"6.6.55.15189", "6.6.55", true},
+		{"6.6.55.15189", "6.6", true},
+		{"6.6.55.15189", "6.6.5", false},
+		{"6.6.65.15248", "6.6.55", false},
+		{"", "6.6.55", false},
+	}
+	for _, tc := range cases {
+		if got := versionMatches(tc.installed, tc.target); got != tc.want {
+			t.Fatalf("versionMatches(%q, %q) = %v, want %v", tc.installed, tc.target, got, tc.want)
+		}
+	}
+}
+
+func TestInUpgradeWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 5, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		name       string
+		now        time.Time
+		start, end string
+		want       bool
+	}{
+		{name: "no window", now: at(12, 0), want: true},
+		{name: "inside", now: at(3, 0), start: "02:00", end: "05:00", want: true},
+		{name: "at start", now: at(2, 0), start: "02:00", end: "05:00", want: true},
+		{name: "at end", now: at(5, 0), start: "02:00", end: "05:00"},
+		{name: "outside", now: at(12, 0), start: "02:00", end: "05:00"},
+		{name: "over midnight late", now: at(23, 30), start: "22:00", end: "04:00", want: true},
+		{name: "over midnight early", now: at(1, 0), start: "22:00", end: "04:00", want: true},
+		{name: "over midnight outside", now: at(12, 0), start: "22:00", end: "04:00"},
+		{name: "other zone", now: time.Date(2026, 5, 1, 5, 0, 0, 0, time.FixedZone("UTC+2", 2*3600)), start: "02:00", end: "04:00", want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := inUpgradeWindow(tc.now, tc.start, tc.end)
+			if err != nil {
+				t.Fatalf("inUpgradeWindow() error = %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("inUpgradeWindow(%s, %q, %q) = %v, want %v", tc.now.Format(time.RFC3339), tc.start, tc.end, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseWindowTime(t *testing.T) {
+	for _, bad := range []string{"", "2:00", "24:00", "12:60", "ab:cd", "12-00"} {
+		if _, err := parseWindowTime(bad); err == nil {
+			t.Fatalf("parseWindowTime(%q) = nil error, want error", bad)
+		}
+	}
+	if got, err := parseWindowTime("23:59"); err != nil || got != 23*60+59 {
+		t.Fatalf("parseWindowTime(\"23:59\") = %d, %v, want %d", got, err, 23*60+59)
+	}
+}
+
+func TestFirmwareUpgradeDone(t *testing.T) {
+	connected, upgrading := deviceStateConnected, 4
+
+	cases := []struct {
+		name      string
+		state     *int
+		installed string
+		target    string
+		want      bool
+	}{
+		{name: "still upgrading", state: &upgrading, installed: "6.6.65"},
+		{name: "back on old firmware", state: &connected, installed: "6.6.55"},
+		{name: "back on new firmware", state: &connected, installed: "6.6.65", want: true},
+		{name: "back on target", state: &connected, installed: "6.6.65.15248", target: "6.6.65", want: true},
+		{name: "back on other firmware", state: &connected, installed: "6.6.61", target: "6.6.65"},
+		{name: "no state", installed: "6.6.65"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := firmwareUpgradeDone(tc.state, tc.installed, "6.6.55", tc.target); got != tc.want {
+				t.Fatalf("firmwareUpgradeDone() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFirmwareUpgradeRequest(t *testing.T) {
+	device := &unifi.DeviceConfig{
+		MAC:               "aa:bb:cc:dd:ee:ff",
+		Version:           "6.6.55.15189",
+		Upgradable:        boolPtr(true),
+		UpgradeToFirmware: "6.6.65.15248",
+	}
+
+	cases := []struct {
+		name        string
+		settings    deviceFirmwareSettings
+		wantUpgrade bool
+		wantURL     string
+		wantErr     bool
+	}{
+		{name: "latest with upgrade", settings: deviceFirmwareSettings{Channel: "latest"}, wantUpgrade: true},
+		{name: "already on target", settings: deviceFirmwareSettings{TargetVersion: "6.6.55"}},
+		{name: "target offered", settings: deviceFirmwareSettings{TargetVersion: "6.6.65"}, wantUpgrade: true},
+		{name: "target not offered", settings: deviceFirmwareSettings{TargetVersion: "6.5.28"}, wantErr: true},
+		{name: "target from url", settings: deviceFirmwareSettings{TargetVersion: "6.5.28", FirmwareURL: "https://fw/x.bin"}, wantUpgrade: true, wantURL: "https://fw/x.bin"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			upgrade, url, err := firmwareUpgradeRequest(tc.settings, device)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("firmwareUpgradeRequest() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if upgrade != tc.wantUpgrade || url != tc.wantURL {
+				t.Fatalf("firmwareUpgradeRequest() = %v, %q, want %v, %q", upgrade, url, tc.wantUpgrade, tc.wantURL)
+			}
+		})
+	}
+
+	upToDate := &unifi.DeviceConfig{Version: "6.6.65.15248", Upgradable: boolPtr(false)}
+	if upgrade, _, err := firmwareUpgradeRequest(deviceFirmwareSettings{Channel: "latest"}, upToDate); upgrade || err != nil {
+		t.Fatalf("firmwareUpgradeRequest(latest, up to date) = %v, %v, want false, nil", upgrade, err)
+	}
+}
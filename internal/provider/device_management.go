@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// Values for management.type.
+const (
+	deviceManagementDHCP   = "dhcp"
+	deviceManagementStatic = "static"
+)
+
+var deviceManagementAttrTypes = map[string]attr.Type{
+	"type":            types.StringType,
+	"ip":              types.StringType,
+	"netmask":         types.StringType,
+	"gateway":         types.StringType,
+	"dns1":            types.StringType,
+	"dns2":            types.StringType,
+	"vlan_network_id": types.StringType,
+	"inform_url":      types.StringType,
+}
+
+// validateDeviceManagement checks that static addressing carries an IP,
+// netmask and gateway, and that DHCP addressing sets no static fields.
+func validateDeviceManagement(obj types.Object, diags *diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return
+	}
+	attrs := obj.Attributes()
+
+	mode, ok := attrs["type"].(types.String)
+	if !ok || mode.IsNull() || mode.IsUnknown() {
+		return
+	}
+
+	for _, name := range []string{"ip", "netmask", "gateway", "dns1", "dns2"} {
+		v, ok := attrs[name].(types.String)
+		if !ok || v.IsUnknown() {
+			continue
+		}
+		switch mode.ValueString() {
+		case deviceManagementStatic:
+			if name == "dns1" || name == "dns2" {
+				continue
+			}
+			if v.IsNull() || v.ValueString() == "" {
+				diags.AddAttributeError(
+					path.Root("management").AtName(name),
+					"Missing static management address",
+					fmt.Sprintf("management.%s is required when management.type is %q.", name, deviceManagementStatic),
+				)
+			}
+		case deviceManagementDHCP:
+			if !v.IsNull() {
+				diags.AddAttributeError(
+					path.Root("management").AtName(name),
+					"Unexpected static management setting",
+					fmt.Sprintf("management.%s can only be set when management.type is %q.", name, deviceManagementStatic),
+				)
+			}
+		}
+	}
+}
+
+// managementFromObject copies the management block into the device update.
+// The whole config_network object is sent, so DNS servers left out of the
+// configuration are cleared on the device.
+func managementFromObject(obj types.Object, device *unifi.DeviceConfig) {
+	attrs := obj.Attributes()
+
+	network := &unifi.DeviceConfigNetwork{}
+	if v, ok := attrs["type"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		network.Type = v.ValueString()
+	}
+	if network.Type == deviceManagementStatic {
+		if v, ok := attrs["ip"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			network.IP = v.ValueString()
+		}
+		if v, ok := attrs["netmask"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			network.Netmask = v.ValueString()
+		}
+		if v, ok := attrs["gateway"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			network.Gateway = v.ValueString()
+		}
+		if v, ok := attrs["dns1"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			network.DNS1 = v.ValueString()
+		}
+		if v, ok := attrs["dns2"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			network.DNS2 = v.ValueString()
+		}
+	}
+	device.ConfigNetwork = network
+
+	if v, ok := attrs["vlan_network_id"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		device.MgmtNetworkID = v.ValueString()
+	}
+	if v, ok := attrs["inform_url"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		device.MgmtInformURL = v.ValueString()
+	}
+}
+
+// managementToObject builds the management block from the device. Static
+// address fields are only reported for static addressing, since the
+// controller keeps the last static values around after switching to DHCP.
+func managementToObject(device *unifi.DeviceConfig) (types.Object, diag.Diagnostics) {
+	vals := map[string]attr.Value{
+		"type":            types.StringValue(deviceManagementDHCP),
+		"ip":              types.StringNull(),
+		"netmask":         types.StringNull(),
+		"gateway":         types.StringNull(),
+		"dns1":            types.StringNull(),
+		"dns2":            types.StringNull(),
+		"vlan_network_id": stringValueOrNull(device.MgmtNetworkID),
+		"inform_url":      stringValueOrNull(device.MgmtInformURL),
+	}
+
+	if network := device.ConfigNetwork; network != nil && network.Type == deviceManagementStatic {
+		vals["type"] = types.StringValue(deviceManagementStatic)
+		vals["ip"] = stringValueOrNull(network.IP)
+		vals["netmask"] = stringValueOrNull(network.Netmask)
+		vals["gateway"] = stringValueOrNull(network.Gateway)
+		vals["dns1"] = stringValueOrNull(network.DNS1)
+		vals["dns2"] = stringValueOrNull(network.DNS2)
+	}
+
+	return types.ObjectValue(deviceManagementAttrTypes, vals)
+}
+
+// plannedManagementIP returns the static IP the device should come back on
+// after a management change, or "" for DHCP.
+func plannedManagementIP(obj types.Object) string {
+	if obj.IsNull() || obj.IsUnknown() {
+		return ""
+	}
+	attrs := obj.Attributes()
+	mode, ok := attrs["type"].(types.String)
+	if !ok || mode.ValueString() != deviceManagementStatic {
+		return ""
+	}
+	ip, ok := attrs["ip"].(types.String)
+	if !ok || ip.IsNull() || ip.IsUnknown() {
+		return ""
+	}
+	return ip.ValueString()
+}
+
+// managementChanged reports whether the management settings in update differ
+// from the ones the device reports. Only the fields managementFromObject sets
+// are compared, so a write that repeats the device's settings does not wait
+// for a reconnect that never happens.
+func managementChanged(update, device *unifi.DeviceConfig) bool {
+	if update.MgmtNetworkID != "" && update.MgmtNetworkID != device.MgmtNetworkID {
+		return true
+	}
+	if update.MgmtInformURL != "" && update.MgmtInformURL != device.MgmtInformURL {
+		return true
+	}
+
+	sent := update.ConfigNetwork
+	if sent == nil {
+		return false
+	}
+	current := device.ConfigNetwork
+	if current == nil {
+		current = &unifi.DeviceConfigNetwork{Type: deviceManagementDHCP}
+	}
+	if sent.Type != current.Type {
+		return true
+	}
+	if sent.Type != deviceManagementStatic {
+		return false
+	}
+	return sent.IP != current.IP || sent.Netmask != current.Netmask || sent.Gateway != current.Gateway ||
+		sent.DNS1 != current.DNS1 || sent.DNS2 != current.DNS2
+}
+
+// reconnectDone reports whether a device polled after a management change
+// has reconnected with its new settings. The controller keeps reporting the
+// old connection until the device drops it, so a device connected on a new
+// static address wantIP is done straight away. Otherwise the address does not
+// show the change, and the device is done once it has been seen disconnected
+// or, since a short disconnect can fall between two polls, after
+// deviceProvisionGracePolls polls.
+func reconnectDone(sawDisconnect bool, polls int, oldIP, wantIP string, device *unifi.DeviceConfig) bool {
+	if device.State == nil || *device.State != deviceStateConnected {
+		return false
+	}
+	if wantIP != "" && device.IP != wantIP {
+		return false
+	}
+	if wantIP != "" && wantIP != oldIP {
+		return true
+	}
+	return sawDisconnect || polls >= deviceProvisionGracePolls
+}
+
+// waitForDeviceReconnect polls the device after a management change until
+// reconnectDone reports it back, or ctx expires. oldIP is the address the
+// device had before the change and wantIP the static address it should come
+// back on, or "" for DHCP.
+func (r *DeviceResource) waitForDeviceReconnect(ctx context.Context, mac, oldIP, wantIP string, diags *diag.Diagnostics) *unifi.DeviceConfig {
+	ticker := time.NewTicker(deviceProvisionPollInterval)
+	defer ticker.Stop()
+
+	sawDisconnect := false
+	var lastIP string
+	for polls := 1; ; polls++ {
+		select {
+		case <-ctx.Done():
+			detail := fmt.Sprintf("Device %s did not reconnect to the controller before the update timeout after its management "+
+				"network configuration changed (last reported IP: %q). The new settings were saved on the controller; "+
+				"check that the device can reach the controller with them, or raise timeouts.update.", mac, lastIP)
+			if !sawDisconnect {
+				detail += " The device was never seen disconnecting, so it may not have applied the new settings yet."
+			}
+			diags.AddError("Device did not reconnect", detail)
+			return nil
+		case <-ticker.C:
+		}
+
+		device, err := r.client.GetDeviceByMAC(ctx, mac)
+		if err != nil {
+			if isNotFoundError(err) || ctx.Err() != nil {
+				continue
+			}
+			handleSDKError(diags, err, "read", "device")
+			return nil
+		}
+		lastIP = device.IP
+		if device.State == nil || *device.State != deviceStateConnected {
+			sawDisconnect = true
+			continue
+		}
+		if reconnectDone(sawDisconnect, polls, oldIP, wantIP, device) {
+			return device
+		}
+	}
+}
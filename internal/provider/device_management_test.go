@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func testDeviceManagementObject(t *testing.T, mode string, fields map[string]string) types.Object {
+	t.Helper()
+
+	vals := map[string]attr.Value{
+		"type":            types.StringValue(mode),
+		"ip":              types.StringNull(),
+		"netmask":         types.StringNull(),
+		"gateway":         types.StringNull(),
+		"dns1":            types.StringNull(),
+		"dns2":            types.StringNull(),
+		"vlan_network_id": types.StringNull(),
+		"inform_url":      types.StringNull(),
+	}
+	for k, v := range fields {
+		vals[k] = types.StringValue(v)
+	}
+
+	obj, d := types.ObjectValue(deviceManagementAttrTypes, vals)
+	if d.HasError() {
+		t.Fatalf("building management object: %v", d)
+	}
+	return obj
+}
+
+func TestValidateDeviceManagement(t *testing.T) {
+	static := map[string]string{"ip": "192.168.1.10", "netmask": "255.255.255.0", "gateway": "192.168.1.1"}
+
+	cases := []struct {
+		name       string
+		mode       string
+		fields     map[string]string
+		wantErrors int
+	}{
+		{name: "dhcp", mode: deviceManagementDHCP},
+		{name: "dhcp with vlan and inform url", mode: deviceManagementDHCP, fields: map[string]string{
+			"vlan_network_id": "abc123", "inform_url": "http://unifi:8080/inform",
+		}},
+		{name: "static complete", mode: deviceManagementStatic, fields: static},
+		{name: "static with dns", mode: deviceManagementStatic, fields: map[string]string{
+			"ip": "192.168.1.10", "netmask": "255.255.255.0", "gateway": "192.168.1.1", "dns1": "1.1.1.1",
+		}},
+		{name: "static missing everything", mode: deviceManagementStatic, wantErrors: 3},
+		{name: "static missing gateway", mode: deviceManagementStatic, fields: map[string]string{
+			"ip": "192.168.1.10", "netmask": "255.255.255.0",
+		}, wantErrors: 1},
+		{name: "dhcp with static fields", mode: deviceManagementDHCP, fields: static, wantErrors: 3},
+		{name: "dhcp with dns", mode: deviceManagementDHCP, fields: map[string]string{"dns1": "1.1.1.1"}, wantErrors: 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateDeviceManagement(testDeviceManagementObject(t, tc.mode, tc.fields), &diags)
+			if got := diags.ErrorsCount(); got != tc.wantErrors {
+				t.Fatalf("validateDeviceManagement() errors = %d, want %d: %v", got, tc.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestValidateDeviceManagement_null(t *testing.T) {
+	var diags diag.Diagnostics
+	validateDeviceManagement(types.ObjectNull(deviceManagementAttrTypes), &diags)
+	if diags.HasError() {
+		t.Fatalf("validateDeviceManagement(null) = %v, want no errors", diags)
+	}
+}
+
+func TestPlannedManagementIP(t *testing.T) {
+	cases := []struct {
+		name string
+		obj  types.Object
+		want string
+	}{
+		{name: "null", obj: types.ObjectNull(deviceManagementAttrTypes)},
+		{name: "dhcp", obj: testDeviceManagementObject(t, deviceManagementDHCP, nil)},
+		{name: "static", obj: testDeviceManagementObject(t, deviceManagementStatic, map[string]string{"ip": "10.0.0.5"}), want: "10.0.0.5"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := plannedManagementIP(tc.obj); got != tc.want {
+				t.Fatalf("plannedManagementIP() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestManagementChanged(t *testing.T) {
+	static := func(ip, gateway string) *unifi.DeviceConfigNetwork {
+		return &unifi.DeviceConfigNetwork{Type: deviceManagementStatic, IP: ip, Netmask: "255.255.255.0", Gateway: gateway}
+	}
+	device := &unifi.DeviceConfig{
+		ConfigNetwork: static("10.0.0.5", "10.0.0.1"),
+		MgmtNetworkID: "net1",
+		MgmtInformURL: "http://unifi:8080/inform",
+	}
+
+	cases := []struct {
+		name   string
+		update *unifi.DeviceConfig
+		device *unifi.DeviceConfig
+		want   bool
+	}{
+		{name: "same static settings", update: &unifi.DeviceConfig{ConfigNetwork: static("10.0.0.5", "10.0.0.1")}, device: device},
+		{name: "new static address", update: &unifi.DeviceConfig{ConfigNetwork: static("10.0.0.20", "10.0.0.1")}, device: device, want: true},
+		{name: "new gateway", update: &unifi.DeviceConfig{ConfigNetwork: static("10.0.0.5", "10.0.0.254")}, device: device, want: true},
+		{name: "static to dhcp", update: &unifi.DeviceConfig{ConfigNetwork: &unifi.DeviceConfigNetwork{Type: deviceManagementDHCP}}, device: device, want: true},
+		{name: "dhcp on device without config_network", update: &unifi.DeviceConfig{ConfigNetwork: &unifi.DeviceConfigNetwork{Type: deviceManagementDHCP}}, device: &unifi.DeviceConfig{}},
+		{name: "same management network", update: &unifi.DeviceConfig{ConfigNetwork: static("10.0.0.5", "10.0.0.1"), MgmtNetworkID: "net1"}, device: device},
+		{name: "new management network", update: &unifi.DeviceConfig{ConfigNetwork: static("10.0.0.5", "10.0.0.1"), MgmtNetworkID: "net2"}, device: device, want: true},
+		{name: "new inform url", update: &unifi.DeviceConfig{ConfigNetwork: static("10.0.0.5", "10.0.0.1"), MgmtInformURL: "http://other:8080/inform"}, device: device, want: true},
+		{name: "no management block", update: &unifi.DeviceConfig{}, device: device},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := managementChanged(tc.update, tc.device); got != tc.want {
+				t.Fatalf("managementChanged() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReconnectDone(t *testing.T) {
+	device := func(state int, ip string) *unifi.DeviceConfig {
+		return &unifi.DeviceConfig{State: &state, IP: ip}
+	}
+
+	cases := []struct {
+		name          string
+		sawDisconnect bool
+		polls         int
+		oldIP, wantIP string
+		device        *unifi.DeviceConfig
+		want          bool
+	}{
+		{name: "dhcp still on old connection", polls: 1, oldIP: "10.0.0.5", device: device(deviceStateConnected, "10.0.0.5")},
+		{name: "dhcp back after disconnect", sawDisconnect: true, polls: 2, oldIP: "10.0.0.5", device: device(deviceStateConnected, "10.0.0.9"), want: true},
+		{name: "dhcp disconnect between polls", polls: deviceProvisionGracePolls, oldIP: "10.0.0.5", device: device(deviceStateConnected, "10.0.0.5"), want: true},
+		{name: "static on new address", polls: 1, oldIP: "10.0.0.5", wantIP: "10.0.0.20", device: device(deviceStateConnected, "10.0.0.20"), want: true},
+		{name: "static on old address", sawDisconnect: true, polls: deviceProvisionGracePolls, oldIP: "10.0.0.5", wantIP: "10.0.0.20", device: device(deviceStateConnected, "10.0.0.5")},
+		{name: "static unchanged address not yet dropped", polls: 1, oldIP: "10.0.0.5", wantIP: "10.0.0.5", device: device(deviceStateConnected, "10.0.0.5")},
+		{name: "static unchanged address after disconnect", sawDisconnect: true, polls: 2, oldIP: "10.0.0.5", wantIP: "10.0.0.5", device: device(deviceStateConnected, "10.0.0.5"), want: true},
+		{name: "provisioning", sawDisconnect: true, polls: deviceProvisionGracePolls, device: device(5, "10.0.0.5")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := reconnectDone(tc.sawDisconnect, tc.polls, tc.oldIP, tc.wantIP, tc.device); got != tc.want {
+				t.Fatalf("reconnectDone() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -30,27 +32,30 @@ type DevicePortOverrideResource struct {
 }
 
 type DevicePortOverrideResourceModel struct {
-	ID                       types.String `tfsdk:"id"`
-	DeviceID                 types.String `tfsdk:"device_id"`
-	MAC                      types.String `tfsdk:"mac"`
-	PortIdx                  types.Int64  `tfsdk:"port_idx"`
-	Name                     types.String `tfsdk:"name"`
-	PortProfileID            types.String `tfsdk:"port_profile_id"`
-	PoeMode                  types.String `tfsdk:"poe_mode"`
-	OpMode                   types.String `tfsdk:"op_mode"`
-	AggregateMembers         types.Set    `tfsdk:"aggregate_members"`
-	NativeNetworkID          types.String `tfsdk:"native_network_id"`
-	TaggedNetworkIDs         types.Set    `tfsdk:"tagged_network_ids"`
-	ExcludedNetworkIDs       types.Set    `tfsdk:"excluded_network_ids"`
-	VoiceNetworkID           types.String `tfsdk:"voice_network_id"`
-	Autoneg                  types.Bool   `tfsdk:"autoneg"`
-	Speed                    types.Int64  `tfsdk:"speed"`
-	FullDuplex               types.Bool   `tfsdk:"full_duplex"`
-	Isolation                types.Bool   `tfsdk:"isolation"`
-	StpPortMode              types.Bool   `tfsdk:"stp_port_mode"`
-	EgressRateLimitKbps      types.Int64  `tfsdk:"egress_rate_limit_kbps"`
-	PortSecurityEnabled      types.Bool   `tfsdk:"port_security_enabled"`
-	PortSecurityMacAddresses types.Set    `tfsdk:"port_security_mac_addresses"`
+	ID                       types.String   `tfsdk:"id"`
+	DeviceID                 types.String   `tfsdk:"device_id"`
+	MAC                      types.String   `tfsdk:"mac"`
+	PortIdx                  types.Int64    `tfsdk:"port_idx"`
+	Name                     types.String   `tfsdk:"name"`
+	PortProfileID            types.String   `tfsdk:"port_profile_id"`
+	PoeMode                  types.String   `tfsdk:"poe_mode"`
+	OpMode                   types.String   `tfsdk:"op_mode"`
+	AggregateMembers         types.Set      `tfsdk:"aggregate_members"`
+	NativeNetworkID          types.String   `tfsdk:"native_network_id"`
+	TaggedNetworkIDs         types.Set      `tfsdk:"tagged_network_ids"`
+	ExcludedNetworkIDs       types.Set      `tfsdk:"excluded_network_ids"`
+	VoiceNetworkID           types.String   `tfsdk:"voice_network_id"`
+	Autoneg                  types.Bool     `tfsdk:"autoneg"`
+	Speed                    types.Int64    `tfsdk:"speed"`
+	FullDuplex               types.Bool     `tfsdk:"full_duplex"`
+	Isolation                types.Bool     `tfsdk:"isolation"`
+	StpPortMode              types.Bool     `tfsdk:"stp_port_mode"`
+	EgressRateLimitKbps      types.Int64    `tfsdk:"egress_rate_limit_kbps"`
+	PortSecurityEnabled      types.Bool     `tfsdk:"port_security_enabled"`
+	PortSecurityMacAddresses types.Set      `tfsdk:"port_security_mac_addresses"`
+	WaitForProvision         types.Bool     `tfsdk:"wait_for_provision"`
+	OnProvisionTimeout       types.String   `tfsdk:"on_provision_timeout"`
+	Timeouts                 timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewDevicePortOverrideResource() resource.Resource {
@@ -63,7 +68,7 @@ func (r *DevicePortOverrideResource) Metadata(ctx context.Context, req resource.
 
 func (r *DevicePortOverrideResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages port-specific configuration overrides on a UniFi device (switch). Use this to assign port profiles, configure PoE, set port names, and other per-port settings.",
+		Description: "Manages port-specific configuration overrides on a UniFi device (switch). Use this to assign port profiles, configure PoE, set port names, and other per-port settings. Overrides for the same device written within a couple of seconds of each other, such as all the ports of a switch in one apply, are sent to the controller as a single device update.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier (device_id:port_idx).",
@@ -112,8 +117,9 @@ func (r *DevicePortOverrideResource) Schema(ctx context.Context, req resource.Sc
 				},
 			},
 			"op_mode": schema.StringAttribute{
-				Description: "Operation mode for this port. Valid values: 'switch', 'mirror', 'aggregate'.",
-				Optional:    true,
+				Description: "Operation mode for this port. Valid values: 'switch', 'mirror', 'aggregate'. " +
+					"The write is rejected when the ports would also belong to another LAG or mirror session on the device.",
+				Optional: true,
 				Validators: []validator.String{
 					stringvalidator.OneOf("switch", "mirror", "aggregate"),
 				},
@@ -181,6 +187,14 @@ func (r *DevicePortOverrideResource) Schema(ctx context.Context, req resource.Sc
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"wait_for_provision":   waitForProvisionAttribute(),
+			"on_provision_timeout": onProvisionTimeoutAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
 		},
 	}
 }
@@ -210,34 +224,41 @@ func (r *DevicePortOverrideResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	deviceID := plan.DeviceID.ValueString()
-
-	// Lock this device to prevent concurrent read-modify-write race conditions
-	deviceLock := r.client.getDeviceLock(deviceID)
-	deviceLock.Lock()
-	defer deviceLock.Unlock()
-
-	device, err := r.getDeviceByID(ctx, deviceID)
-	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "read", "device")
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
+	deviceID := plan.DeviceID.ValueString()
 	portIdx := int(plan.PortIdx.ValueInt64())
 	override := r.planToSDK(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	device.PortOverrides = r.mergePortOverride(device.PortOverrides, override, portIdx)
-
-	updated, err := r.client.UpdateDevice(ctx, device.ID, device)
+	// Writes to the same device are batched, so overrides for many ports of
+	// one switch are sent in a single update.
+	updated, err := r.client.coalesceDeviceUpdate(ctx, deviceID, r.deviceFetcher(deviceID), func(device *unifi.DeviceConfig) error {
+		if err := checkPortOverrideRoles(device.PortOverrides, portIdx, override.OpMode, override.AggregateMembers); err != nil {
+			return err
+		}
+		device.PortOverrides = r.mergePortOverride(device.PortOverrides, override, portIdx)
+		return nil
+	})
 	if err != nil {
 		handleSDKError(&resp.Diagnostics, err, "create", "device port override")
 		return
 	}
 
-	plan.ID = types.StringValue(fmt.Sprintf("%s:%d", device.ID, portIdx))
+	r.waitForProvision(ctx, &plan, updated.MAC, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%d", updated.ID, portIdx))
 	plan.MAC = types.StringValue(updated.MAC)
 
 	foundOverride := r.findPortOverride(updated.PortOverrides, portIdx)
@@ -275,6 +296,12 @@ func (r *DevicePortOverrideResource) Read(ctx context.Context, req resource.Read
 	}
 
 	state.MAC = types.StringValue(device.MAC)
+	if state.WaitForProvision.IsNull() {
+		state.WaitForProvision = types.BoolValue(false)
+	}
+	if state.OnProvisionTimeout.IsNull() {
+		state.OnProvisionTimeout = types.StringValue(provisionTimeoutWarn)
+	}
 	resp.Diagnostics.Append(r.sdkToState(ctx, override, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -291,33 +318,38 @@ func (r *DevicePortOverrideResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
-	deviceID := plan.DeviceID.ValueString()
-
-	// Lock this device to prevent concurrent read-modify-write race conditions
-	deviceLock := r.client.getDeviceLock(deviceID)
-	deviceLock.Lock()
-	defer deviceLock.Unlock()
-
-	device, err := r.getDeviceByID(ctx, deviceID)
-	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "read", "device")
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
+	deviceID := plan.DeviceID.ValueString()
 	portIdx := int(plan.PortIdx.ValueInt64())
 	override := r.planToSDK(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	device.PortOverrides = r.mergePortOverride(device.PortOverrides, override, portIdx)
-
-	updated, err := r.client.UpdateDevice(ctx, device.ID, device)
+	updated, err := r.client.coalesceDeviceUpdate(ctx, deviceID, r.deviceFetcher(deviceID), func(device *unifi.DeviceConfig) error {
+		if err := checkPortOverrideRoles(device.PortOverrides, portIdx, override.OpMode, override.AggregateMembers); err != nil {
+			return err
+		}
+		device.PortOverrides = r.mergePortOverride(device.PortOverrides, override, portIdx)
+		return nil
+	})
 	if err != nil {
 		handleSDKError(&resp.Diagnostics, err, "update", "device port override")
 		return
 	}
 
+	r.waitForProvision(ctx, &plan, updated.MAC, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	plan.MAC = types.StringValue(updated.MAC)
 
 	foundOverride := r.findPortOverride(updated.PortOverrides, portIdx)
@@ -337,26 +369,16 @@ func (r *DevicePortOverrideResource) Delete(ctx context.Context, req resource.De
 	}
 
 	deviceID := state.DeviceID.ValueString()
+	portIdx := int(state.PortIdx.ValueInt64())
 
-	// Lock this device to prevent concurrent read-modify-write race conditions
-	deviceLock := r.client.getDeviceLock(deviceID)
-	deviceLock.Lock()
-	defer deviceLock.Unlock()
-
-	device, err := r.getDeviceByID(ctx, deviceID)
+	_, err := r.client.coalesceDeviceUpdate(ctx, deviceID, r.deviceFetcher(deviceID), func(device *unifi.DeviceConfig) error {
+		device.PortOverrides = r.removePortOverride(device.PortOverrides, portIdx)
+		return nil
+	})
 	if err != nil {
 		if isNotFoundError(err) {
 			return
 		}
-		handleSDKError(&resp.Diagnostics, err, "read", "device")
-		return
-	}
-
-	portIdx := int(state.PortIdx.ValueInt64())
-	device.PortOverrides = r.removePortOverride(device.PortOverrides, portIdx)
-
-	_, err = r.client.UpdateDevice(ctx, device.ID, device)
-	if err != nil {
 		handleSDKError(&resp.Diagnostics, err, "delete", "device port override")
 		return
 	}
@@ -387,19 +409,25 @@ func (r *DevicePortOverrideResource) ImportState(ctx context.Context, req resour
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port_idx"), portIdx)...)
 }
 
-func (r *DevicePortOverrideResource) getDeviceByID(ctx context.Context, id string) (*unifi.DeviceConfig, error) {
-	devices, err := r.client.ListDevices(ctx)
-	if err != nil {
-		return nil, err
+// waitForProvision waits for the device to re-provision after a port
+// override write when wait_for_provision is set. Overrides written in the
+// same batch all wait for the same provisioning cycle.
+func (r *DevicePortOverrideResource) waitForProvision(ctx context.Context, plan *DevicePortOverrideResourceModel, mac string, diags *diag.Diagnostics) {
+	if !plan.WaitForProvision.ValueBool() {
+		return
 	}
+	r.client.waitForDeviceProvision(ctx, mac, plan.OnProvisionTimeout, diags)
+}
 
-	for _, d := range devices.NetworkDevices {
-		if d.ID == id {
-			return r.client.GetDeviceByMAC(ctx, d.MAC)
-		}
+// deviceFetcher returns the read half of a batched device write.
+func (r *DevicePortOverrideResource) deviceFetcher(id string) func(ctx context.Context) (*unifi.DeviceConfig, error) {
+	return func(ctx context.Context) (*unifi.DeviceConfig, error) {
+		return r.getDeviceByID(ctx, id)
 	}
+}
 
-	return nil, fmt.Errorf("device with ID '%s' not found", id)
+func (r *DevicePortOverrideResource) getDeviceByID(ctx context.Context, id string) (*unifi.DeviceConfig, error) {
+	return r.client.getDeviceByID(ctx, id)
 }
 
 func (r *DevicePortOverrideResource) findPortOverride(overrides []unifi.PortOverride, portIdx int) *unifi.PortOverride {
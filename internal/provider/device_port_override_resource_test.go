@@ -87,6 +87,36 @@ func TestAccDevicePortOverrideResource_update(t *testing.T) {
 	})
 }
 
+func TestAccDevicePortOverrideResource_waitForProvision(t *testing.T) {
+	testAccPreCheckSwitch(t)
+	mac := testAccGetFirstSwitchMAC(t)
+	if mac == "" {
+		t.Skip("No switch MAC available")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDevicePortOverrideResourceConfig_waitForProvision(mac, "tf-acc-test-port-wait"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device_port_override.test", "name", "tf-acc-test-port-wait"),
+					resource.TestCheckResourceAttr("unifi_device_port_override.test", "wait_for_provision", "true"),
+					resource.TestCheckResourceAttr("unifi_device_port_override.test", "on_provision_timeout", "error"),
+					resource.TestCheckResourceAttr("data.unifi_device.test", "state", "1"),
+				),
+			},
+			{
+				Config: testAccDevicePortOverrideResourceConfig_waitForProvision(mac, "tf-acc-test-port-wait-updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device_port_override.test", "name", "tf-acc-test-port-wait-updated"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDevicePortOverrideResourceConfig_basic(mac, name string) string {
 	return fmt.Sprintf(`
 %s
@@ -123,3 +153,26 @@ resource "unifi_device_port_override" "test" {
 }
 `, testAccProviderConfig, mac, name)
 }
+
+func testAccDevicePortOverrideResourceConfig_waitForProvision(mac, name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "unifi_device" "test" {
+  mac = %q
+}
+
+resource "unifi_device_port_override" "test" {
+  device_id            = data.unifi_device.test.id
+  port_idx             = 1
+  name                 = %q
+  wait_for_provision   = true
+  on_provision_timeout = "error"
+
+  timeouts {
+    create = "3m"
+    update = "3m"
+  }
+}
+`, testAccProviderConfig, mac, name)
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+var devicePortLLDPAttrTypes = map[string]attr.Type{
+	"chassis_id":  types.StringType,
+	"port_id":     types.StringType,
+	"system_name": types.StringType,
+}
+
+var devicePortStatusAttrTypes = map[string]attr.Type{
+	"port_idx":        types.Int64Type,
+	"name":            types.StringType,
+	"up":              types.BoolType,
+	"speed":           types.Int64Type,
+	"full_duplex":     types.BoolType,
+	"poe_power":       types.Float64Type,
+	"port_profile_id": types.StringType,
+	"stp_state":       types.StringType,
+	"is_uplink":       types.BoolType,
+	"lldp":            types.ObjectType{AttrTypes: devicePortLLDPAttrTypes},
+}
+
+// portTableToList converts the device's live port table into port_table,
+// attaching the LLDP neighbor learned on each port, if any.
+func portTableToList(device *unifi.DeviceConfig) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	neighbors := make(map[int]unifi.DeviceLLDPEntry, len(device.LLDPTable))
+	for _, n := range device.LLDPTable {
+		if n.LocalPortIdx != nil {
+			neighbors[*n.LocalPortIdx] = n
+		}
+	}
+
+	values := make([]attr.Value, 0, len(device.PortTable))
+	for _, p := range device.PortTable {
+		lldp := types.ObjectNull(devicePortLLDPAttrTypes)
+		if p.PortIdx != nil {
+			if n, ok := neighbors[*p.PortIdx]; ok {
+				obj, d := types.ObjectValue(devicePortLLDPAttrTypes, map[string]attr.Value{
+					"chassis_id":  stringValueOrNull(n.ChassisID),
+					"port_id":     stringValueOrNull(n.PortID),
+					"system_name": stringValueOrNull(n.SystemName),
+				})
+				diags.Append(d...)
+				lldp = obj
+			}
+		}
+
+		obj, d := types.ObjectValue(devicePortStatusAttrTypes, map[string]attr.Value{
+			"port_idx":        int64ValueOrNull(p.PortIdx),
+			"name":            stringValueOrNull(p.Name),
+			"up":              boolValueOrNull(p.Up),
+			"speed":           int64ValueOrNull(p.Speed),
+			"full_duplex":     boolValueOrNull(p.FullDuplex),
+			"poe_power":       poePowerValue(p.PoePower),
+			"port_profile_id": stringValueOrNull(p.PortconfID),
+			"stp_state":       stringValueOrNull(p.StpState),
+			"is_uplink":       boolValueOrNull(p.IsUplink),
+			"lldp":            lldp,
+		})
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: devicePortStatusAttrTypes}, values)
+	diags.Append(d...)
+	return list, diags
+}
+
+// poePowerValue parses the controller's PoE draw, reported in watts as a
+// string. Ports without PoE report nothing.
+func poePowerValue(s string) types.Float64 {
+	if s == "" {
+		return types.Float64Null()
+	}
+	w, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return types.Float64Null()
+	}
+	return types.Float64Value(w)
+}
+
+func int64ValueOrNull(v *int) types.Int64 {
+	if v == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*v))
+}
+
+func boolValueOrNull(v *bool) types.Bool {
+	if v == nil {
+		return types.BoolNull()
+	}
+	return types.BoolValue(*v)
+}
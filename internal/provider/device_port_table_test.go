@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func TestPortTableToList(t *testing.T) {
+	port1, port2, speed := 1, 2, 1000
+	device := &unifi.DeviceConfig{
+		PortTable: []unifi.DevicePortStatus{
+			{PortIdx: &port1, Name: "Port 1", Up: boolPtr(true), Speed: &speed, FullDuplex: boolPtr(true), PoePower: "4.52", PortconfID: "profile1", StpState: "forwarding", IsUplink: boolPtr(true)},
+			{PortIdx: &port2, Name: "Port 2", Up: boolPtr(false), StpState: "disabled", IsUplink: boolPtr(false)},
+		},
+		LLDPTable: []unifi.DeviceLLDPEntry{
+			{LocalPortIdx: &port1, ChassisID: "aa:bb:cc:dd:ee:ff", PortID: "Port 24", SystemName: "core-switch"},
+		},
+	}
+
+	list, d := portTableToList(device)
+	if d.HasError() {
+		t.Fatalf("portTableToList() = %v", d)
+	}
+	ports := list.Elements()
+	if len(ports) != 2 {
+		t.Fatalf("portTableToList() returned %d ports, want 2", len(ports))
+	}
+
+	uplink := ports[0].(types.Object).Attributes()
+	if got := uplink["poe_power"].(types.Float64); got.ValueFloat64() != 4.52 {
+		t.Fatalf("port 1 poe_power = %v, want 4.52", got)
+	}
+	lldp := uplink["lldp"].(types.Object)
+	if lldp.IsNull() || lldp.Attributes()["system_name"].(types.String).ValueString() != "core-switch" {
+		t.Fatalf("port 1 lldp = %v, want neighbor core-switch", lldp)
+	}
+
+	idle := ports[1].(types.Object).Attributes()
+	if !idle["poe_power"].IsNull() || !idle["speed"].IsNull() || !idle["lldp"].IsNull() {
+		t.Fatalf("port 2 = %v, want null poe_power, speed and lldp", idle)
+	}
+}
+
+func TestPortTableToList_empty(t *testing.T) {
+	list, d := portTableToList(&unifi.DeviceConfig{})
+	if d.HasError() {
+		t.Fatalf("portTableToList() = %v", d)
+	}
+	if list.IsNull() || len(list.Elements()) != 0 {
+		t.Fatalf("portTableToList(no ports) = %v, want an empty list", list)
+	}
+}
+
+func TestPoePowerValue(t *testing.T) {
+	if got := poePowerValue(""); !got.IsNull() {
+		t.Fatalf("poePowerValue(\"\") = %v, want null", got)
+	}
+	if got := poePowerValue("n/a"); !got.IsNull() {
+		t.Fatalf("poePowerValue(\"n/a\") = %v, want null", got)
+	}
+	if got := poePowerValue("12.5"); got.ValueFloat64() != 12.5 {
+		t.Fatalf("poePowerValue(\"12.5\") = %v, want 12.5", got)
+	}
+}
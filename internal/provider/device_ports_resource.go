@@ -0,0 +1,672 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+var (
+	_ resource.Resource                   = &DevicePortsResource{}
+	_ resource.ResourceWithImportState    = &DevicePortsResource{}
+	_ resource.ResourceWithValidateConfig = &DevicePortsResource{}
+)
+
+type DevicePortsResource struct {
+	client *AutoLoginClient
+}
+
+type DevicePortsResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	MAC                types.String   `tfsdk:"mac"`
+	Ports              types.Map      `tfsdk:"ports"`
+	WaitForProvision   types.Bool     `tfsdk:"wait_for_provision"`
+	OnProvisionTimeout types.String   `tfsdk:"on_provision_timeout"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// DevicePortsPortModel holds the settings of one entry in ports. The fields
+// match unifi_device_port_override.
+type DevicePortsPortModel struct {
+	Name                     types.String `tfsdk:"name"`
+	PortProfileID            types.String `tfsdk:"port_profile_id"`
+	PoeMode                  types.String `tfsdk:"poe_mode"`
+	OpMode                   types.String `tfsdk:"op_mode"`
+	AggregateMembers         types.Set    `tfsdk:"aggregate_members"`
+	NativeNetworkID          types.String `tfsdk:"native_network_id"`
+	TaggedNetworkIDs         types.Set    `tfsdk:"tagged_network_ids"`
+	ExcludedNetworkIDs       types.Set    `tfsdk:"excluded_network_ids"`
+	VoiceNetworkID           types.String `tfsdk:"voice_network_id"`
+	Autoneg                  types.Bool   `tfsdk:"autoneg"`
+	Speed                    types.Int64  `tfsdk:"speed"`
+	FullDuplex               types.Bool   `tfsdk:"full_duplex"`
+	Isolation                types.Bool   `tfsdk:"isolation"`
+	StpPortMode              types.Bool   `tfsdk:"stp_port_mode"`
+	EgressRateLimitKbps      types.Int64  `tfsdk:"egress_rate_limit_kbps"`
+	PortSecurityEnabled      types.Bool   `tfsdk:"port_security_enabled"`
+	PortSecurityMacAddresses types.Set    `tfsdk:"port_security_mac_addresses"`
+}
+
+var devicePortAttrTypes = map[string]attr.Type{
+	"name":                        types.StringType,
+	"port_profile_id":             types.StringType,
+	"poe_mode":                    types.StringType,
+	"op_mode":                     types.StringType,
+	"aggregate_members":           types.SetType{ElemType: types.Int64Type},
+	"native_network_id":           types.StringType,
+	"tagged_network_ids":          types.SetType{ElemType: types.StringType},
+	"excluded_network_ids":        types.SetType{ElemType: types.StringType},
+	"voice_network_id":            types.StringType,
+	"autoneg":                     types.BoolType,
+	"speed":                       types.Int64Type,
+	"full_duplex":                 types.BoolType,
+	"isolation":                   types.BoolType,
+	"stp_port_mode":               types.BoolType,
+	"egress_rate_limit_kbps":      types.Int64Type,
+	"port_security_enabled":       types.BoolType,
+	"port_security_mac_addresses": types.SetType{ElemType: types.StringType},
+}
+
+func NewDevicePortsResource() resource.Resource {
+	return &DevicePortsResource{}
+}
+
+func (r *DevicePortsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_ports"
+}
+
+func (r *DevicePortsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete port override table of a UniFi switch. Unlike unifi_device_port_override, this " +
+			"resource is authoritative: overrides on ports missing from ports, including ones added in the UI, show up " +
+			"as drift and are removed on apply, and destroy clears every override on the device. " +
+			"Do not combine it with unifi_device_port_override for the same device.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the device.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mac": schema.StringAttribute{
+				Description: "The MAC address of the switch.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ports": schema.MapNestedAttribute{
+				Description: "Port overrides keyed by port index ('5') or by an inclusive range of port indices ('1-24') " +
+					"that share the same settings. Keys must not overlap.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name/label for the port.",
+							Optional:    true,
+						},
+						"port_profile_id": schema.StringAttribute{
+							Description: "The port profile ID to apply to the port.",
+							Optional:    true,
+						},
+						"poe_mode": schema.StringAttribute{
+							Description: "PoE mode. Valid values: 'auto', 'off', 'pasv24', 'passthrough'.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("auto", "off", "pasv24", "passthrough"),
+							},
+						},
+						"op_mode": schema.StringAttribute{
+							Description: "Operation mode. Valid values: 'switch', 'mirror', 'aggregate'.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("switch", "mirror", "aggregate"),
+							},
+						},
+						"aggregate_members": schema.SetAttribute{
+							Description: "Port indices to include in link aggregation (when op_mode is 'aggregate').",
+							Optional:    true,
+							ElementType: types.Int64Type,
+						},
+						"native_network_id": schema.StringAttribute{
+							Description: "The native (untagged) network ID.",
+							Optional:    true,
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"tagged_network_ids": schema.SetAttribute{
+							Description: "Set of tagged network IDs.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"excluded_network_ids": schema.SetAttribute{
+							Description: "Set of excluded network IDs.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"voice_network_id": schema.StringAttribute{
+							Description: "The voice network ID.",
+							Optional:    true,
+						},
+						"autoneg": schema.BoolAttribute{
+							Description: "Enable auto-negotiation for speed and duplex.",
+							Optional:    true,
+						},
+						"speed": schema.Int64Attribute{
+							Description: "Port speed in Mbps (when autoneg is disabled). Valid values: 10, 100, 1000, 2500, 10000.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.OneOf(10, 100, 1000, 2500, 10000),
+							},
+						},
+						"full_duplex": schema.BoolAttribute{
+							Description: "Enable full duplex (when autoneg is disabled).",
+							Optional:    true,
+						},
+						"isolation": schema.BoolAttribute{
+							Description: "Enable port isolation.",
+							Optional:    true,
+						},
+						"stp_port_mode": schema.BoolAttribute{
+							Description: "Enable Spanning Tree Protocol on the port.",
+							Optional:    true,
+						},
+						"egress_rate_limit_kbps": schema.Int64Attribute{
+							Description: "Egress rate limit in Kbps. Set to 0 to disable.",
+							Optional:    true,
+						},
+						"port_security_enabled": schema.BoolAttribute{
+							Description: "Enable port security (MAC address limiting).",
+							Optional:    true,
+						},
+						"port_security_mac_addresses": schema.SetAttribute{
+							Description: "Set of allowed MAC addresses when port security is enabled.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"wait_for_provision":   waitForProvisionAttribute(),
+			"on_provision_timeout": onProvisionTimeoutAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *DevicePortsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DevicePortsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ports types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ports"), &ports)...)
+	if resp.Diagnostics.HasError() || ports.IsNull() || ports.IsUnknown() {
+		return
+	}
+
+	keys := make([]string, 0, len(ports.Elements()))
+	for key := range ports.Elements() {
+		keys = append(keys, key)
+	}
+	if _, err := expandPortKeys(keys); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ports"), "Invalid port keys", err.Error())
+	}
+}
+
+func (r *DevicePortsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DevicePortsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.write(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DevicePortsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DevicePortsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	device, err := r.client.GetDeviceByMAC(ctx, state.MAC.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		handleSDKError(&resp.Diagnostics, err, "read", "device")
+		return
+	}
+
+	if state.WaitForProvision.IsNull() {
+		state.WaitForProvision = types.BoolValue(false)
+	}
+	if state.OnProvisionTimeout.IsNull() {
+		state.OnProvisionTimeout = types.StringValue(provisionTimeoutWarn)
+	}
+
+	resp.Diagnostics.Append(r.sdkToState(ctx, device, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DevicePortsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DevicePortsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.write(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DevicePortsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DevicePortsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	deviceLock := r.client.getDeviceLock(state.ID.ValueString())
+	deviceLock.Lock()
+	defer deviceLock.Unlock()
+
+	device, err := r.client.GetDeviceByMAC(ctx, state.MAC.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		handleSDKError(&resp.Diagnostics, err, "read", "device")
+		return
+	}
+
+	device.PortOverrides = []unifi.PortOverride{}
+	if _, err := r.client.UpdateDevice(ctx, device.ID, device); err != nil {
+		handleSDKError(&resp.Diagnostics, err, "delete", "device ports")
+	}
+}
+
+func (r *DevicePortsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	mac := req.ID
+
+	device, err := r.client.GetDeviceByMAC(ctx, mac)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Import Error",
+			fmt.Sprintf("Could not find device with MAC %q: %s. Import requires the device MAC address (e.g., aa:bb:cc:dd:ee:ff).", mac, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), device.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mac"), device.MAC)...)
+}
+
+// write replaces the device's whole port override table with the planned
+// ports and refreshes plan from the controller's response.
+func (r *DevicePortsResource) write(ctx context.Context, plan *DevicePortsResourceModel, diags *diag.Diagnostics) {
+	overrides := r.planToSDK(ctx, plan, diags)
+	if diags.HasError() {
+		return
+	}
+
+	device, err := r.client.GetDeviceByMAC(ctx, plan.MAC.ValueString())
+	if err != nil {
+		handleSDKError(diags, err, "read", "device")
+		return
+	}
+
+	deviceLock := r.client.getDeviceLock(device.ID)
+	deviceLock.Lock()
+	defer deviceLock.Unlock()
+
+	// Re-read under the lock so fields other than the port table are not
+	// overwritten with values from before another writer's update.
+	device, err = r.client.GetDeviceByMAC(ctx, plan.MAC.ValueString())
+	if err != nil {
+		handleSDKError(diags, err, "read", "device")
+		return
+	}
+
+	device.PortOverrides = overrides
+	updated, err := r.client.UpdateDevice(ctx, device.ID, device)
+	if err != nil {
+		handleSDKError(diags, err, "update", "device ports")
+		return
+	}
+
+	if plan.WaitForProvision.ValueBool() {
+		if provisioned := r.client.waitForDeviceProvision(ctx, updated.MAC, plan.OnProvisionTimeout, diags); provisioned != nil {
+			updated = provisioned
+		}
+		if diags.HasError() {
+			return
+		}
+	}
+
+	diags.Append(r.sdkToState(ctx, updated, plan)...)
+}
+
+// planToSDK expands the ports map into one PortOverride per port, sorted by
+// port index.
+func (r *DevicePortsResource) planToSDK(ctx context.Context, plan *DevicePortsResourceModel, diags *diag.Diagnostics) []unifi.PortOverride {
+	var ports map[string]DevicePortsPortModel
+	diags.Append(plan.Ports.ElementsAs(ctx, &ports, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	keys := make([]string, 0, len(ports))
+	for key := range ports {
+		keys = append(keys, key)
+	}
+	portKeys, err := expandPortKeys(keys)
+	if err != nil {
+		diags.AddAttributeError(path.Root("ports"), "Invalid port keys", err.Error())
+		return nil
+	}
+
+	indexes := make([]int, 0, len(portKeys))
+	for idx := range portKeys {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+
+	var portOverrides DevicePortOverrideResource
+	overrides := make([]unifi.PortOverride, 0, len(indexes))
+	for _, idx := range indexes {
+		model := ports[portKeys[idx]].overrideModel(idx)
+		override := portOverrides.planToSDK(ctx, &model, diags)
+		if diags.HasError() {
+			return nil
+		}
+		overrides = append(overrides, *override)
+	}
+	return overrides
+}
+
+// sdkToState sets id and ports from the device. Overrides are grouped back
+// under the keys already in state, so a range whose ports still share one
+// configuration stays a single entry; ports that differ from their range,
+// and overrides on ports outside every key, appear as entries of their own.
+func (r *DevicePortsResource) sdkToState(ctx context.Context, device *unifi.DeviceConfig, state *DevicePortsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	state.ID = types.StringValue(device.ID)
+	state.MAC = types.StringValue(device.MAC)
+
+	var portOverrides DevicePortOverrideResource
+	values := make(map[int]attr.Value, len(device.PortOverrides))
+	for i := range device.PortOverrides {
+		override := &device.PortOverrides[i]
+		if override.PortIdx == nil {
+			continue
+		}
+
+		var model DevicePortOverrideResourceModel
+		diags.Append(portOverrides.sdkToState(ctx, override, &model)...)
+		obj, d := types.ObjectValueFrom(ctx, devicePortAttrTypes, devicePortsPortModelFrom(model))
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		values[*override.PortIdx] = obj
+	}
+
+	var keys []string
+	if !state.Ports.IsNull() && !state.Ports.IsUnknown() {
+		for key := range state.Ports.Elements() {
+			keys = append(keys, key)
+		}
+	}
+
+	ports, d := types.MapValue(types.ObjectType{AttrTypes: devicePortAttrTypes}, groupPortValues(keys, values))
+	diags.Append(d...)
+	state.Ports = ports
+
+	return diags
+}
+
+// overrideModel converts a ports entry into the unifi_device_port_override
+// model for the given port, so both resources build overrides the same way.
+func (m DevicePortsPortModel) overrideModel(portIdx int) DevicePortOverrideResourceModel {
+	return DevicePortOverrideResourceModel{
+		PortIdx:                  types.Int64Value(int64(portIdx)),
+		Name:                     m.Name,
+		PortProfileID:            m.PortProfileID,
+		PoeMode:                  m.PoeMode,
+		OpMode:                   m.OpMode,
+		AggregateMembers:         m.AggregateMembers,
+		NativeNetworkID:          m.NativeNetworkID,
+		TaggedNetworkIDs:         m.TaggedNetworkIDs,
+		ExcludedNetworkIDs:       m.ExcludedNetworkIDs,
+		VoiceNetworkID:           m.VoiceNetworkID,
+		Autoneg:                  m.Autoneg,
+		Speed:                    m.Speed,
+		FullDuplex:               m.FullDuplex,
+		Isolation:                m.Isolation,
+		StpPortMode:              m.StpPortMode,
+		EgressRateLimitKbps:      m.EgressRateLimitKbps,
+		PortSecurityEnabled:      m.PortSecurityEnabled,
+		PortSecurityMacAddresses: m.PortSecurityMacAddresses,
+	}
+}
+
+// devicePortsPortModelFrom is the inverse of overrideModel.
+func devicePortsPortModelFrom(m DevicePortOverrideResourceModel) DevicePortsPortModel {
+	return DevicePortsPortModel{
+		Name:                     m.Name,
+		PortProfileID:            m.PortProfileID,
+		PoeMode:                  m.PoeMode,
+		OpMode:                   m.OpMode,
+		AggregateMembers:         m.AggregateMembers,
+		NativeNetworkID:          m.NativeNetworkID,
+		TaggedNetworkIDs:         m.TaggedNetworkIDs,
+		ExcludedNetworkIDs:       m.ExcludedNetworkIDs,
+		VoiceNetworkID:           m.VoiceNetworkID,
+		Autoneg:                  m.Autoneg,
+		Speed:                    m.Speed,
+		FullDuplex:               m.FullDuplex,
+		Isolation:                m.Isolation,
+		StpPortMode:              m.StpPortMode,
+		EgressRateLimitKbps:      m.EgressRateLimitKbps,
+		PortSecurityEnabled:      m.PortSecurityEnabled,
+		PortSecurityMacAddresses: m.PortSecurityMacAddresses,
+	}
+}
+
+// parsePortKey parses a ports key: a port index ("5") or an inclusive range
+// ("1-24"). Keys must be written canonically so state keys match config keys.
+func parsePortKey(key string) (int, int, error) {
+	startStr, endStr, isRange := strings.Cut(key, "-")
+	if !isRange {
+		endStr = startStr
+	}
+
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("port key %q is not a port index or a range like \"1-24\"", key)
+	}
+	end, err := strconv.Atoi(endStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("port key %q is not a port index or a range like \"1-24\"", key)
+	}
+
+	if start < 1 {
+		return 0, 0, fmt.Errorf("port key %q: port indices start at 1", key)
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("port key %q: range end is before its start", key)
+	}
+
+	canonical := strconv.Itoa(start)
+	if end != start {
+		canonical += "-" + strconv.Itoa(end)
+	}
+	if key != canonical {
+		return 0, 0, fmt.Errorf("port key %q must be written as %q", key, canonical)
+	}
+	return start, end, nil
+}
+
+// expandPortKeys maps every port index covered by keys to its key, failing
+// on invalid keys and on keys that cover the same port.
+func expandPortKeys(keys []string) (map[int]string, error) {
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	ports := make(map[int]string)
+	for _, key := range sorted {
+		start, end, err := parsePortKey(key)
+		if err != nil {
+			return nil, err
+		}
+		for idx := start; idx <= end; idx++ {
+			if other, ok := ports[idx]; ok {
+				return nil, fmt.Errorf("port %d is covered by both %q and %q", idx, other, key)
+			}
+			ports[idx] = key
+		}
+	}
+	return ports, nil
+}
+
+// groupPortValues groups per-port values under keys. A key whose ports all
+// have equal values keeps a single entry; otherwise each of its ports that
+// has a value gets an entry of its own. Values on ports outside every key,
+// or keys that are invalid, are also reported per port.
+func groupPortValues(keys []string, values map[int]attr.Value) map[string]attr.Value {
+	result := make(map[string]attr.Value, len(values))
+	used := make(map[int]bool, len(values))
+
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		start, end, err := parsePortKey(key)
+		if err != nil {
+			continue
+		}
+
+		first, ok := values[start]
+		uniform := ok
+		for idx := start + 1; uniform && idx <= end; idx++ {
+			v, ok := values[idx]
+			uniform = ok && v.Equal(first)
+		}
+
+		if uniform {
+			result[key] = first
+			for idx := start; idx <= end; idx++ {
+				used[idx] = true
+			}
+			continue
+		}
+
+		for idx := start; idx <= end; idx++ {
+			if v, ok := values[idx]; ok && !used[idx] {
+				result[strconv.Itoa(idx)] = v
+				used[idx] = true
+			}
+		}
+	}
+
+	for idx, v := range values {
+		if !used[idx] {
+			result[strconv.Itoa(idx)] = v
+		}
+	}
+	return result
+}
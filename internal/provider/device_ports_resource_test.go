@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDevicePortsResource_basic(t *testing.T) {
+	testAccPreCheckSwitch(t)
+	mac := testAccGetFirstSwitchMAC(t)
+	if mac == "" {
+		t.Skip("No switch MAC available")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDevicePortsResourceConfig_basic(mac, "tf-acc-test-ports"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("unifi_device_ports.test", "id"),
+					resource.TestCheckResourceAttr("unifi_device_ports.test", "mac", mac),
+					resource.TestCheckResourceAttr("unifi_device_ports.test", "ports.%", "2"),
+					resource.TestCheckResourceAttr("unifi_device_ports.test", "ports.1-2.name", "tf-acc-test-ports"),
+					resource.TestCheckResourceAttr("unifi_device_ports.test", "ports.3.poe_mode", "off"),
+				),
+			},
+			{
+				Config: testAccDevicePortsResourceConfig_basic(mac, "tf-acc-test-ports-updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device_ports.test", "ports.1-2.name", "tf-acc-test-ports-updated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDevicePortsResourceConfig_basic(mac, name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_device_ports" "test" {
+  mac = %q
+
+  ports = {
+    "1-2" = {
+      name = %q
+    }
+    "3" = {
+      poe_mode = "off"
+    }
+  }
+}
+`, testAccProviderConfig, mac, name)
+}
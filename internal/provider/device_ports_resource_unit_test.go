@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParsePortKey(t *testing.T) {
+	cases := []struct {
+		key       string
+		wantStart int
+		wantEnd   int
+		wantErr   bool
+	}{
+		{key: "5", wantStart: 5, wantEnd: 5},
+		{key: "1-24", wantStart: 1, wantEnd: 24},
+		{key: "3-3", wantErr: true},
+		{key: "05", wantErr: true},
+		{key: "0", wantErr: true},
+		{key: "24-1", wantErr: true},
+		{key: "1-", wantErr: true},
+		{key: "a-b", wantErr: true},
+		{key: "", wantErr: true},
+		{key: " 1", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.key, func(t *testing.T) {
+			start, end, err := parsePortKey(tc.key)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parsePortKey(%q) error = %v, wantErr %v", tc.key, err, tc.wantErr)
+			}
+			if err == nil && (start != tc.wantStart || end != tc.wantEnd) {
+				t.Fatalf("parsePortKey(%q) = %d, %d, want %d, %d", tc.key, start, end, tc.wantStart, tc.wantEnd)
+			}
+		})
+	}
+}
+
+func TestExpandPortKeys(t *testing.T) {
+	ports, err := expandPortKeys([]string{"1-4", "8", "5-6"})
+	if err != nil {
+		t.Fatalf("expandPortKeys() error = %v", err)
+	}
+	want := map[int]string{1: "1-4", 2: "1-4", 3: "1-4", 4: "1-4", 5: "5-6", 6: "5-6", 8: "8"}
+	if len(ports) != len(want) {
+		t.Fatalf("expandPortKeys() = %v, want %v", ports, want)
+	}
+	for idx, key := range want {
+		if ports[idx] != key {
+			t.Fatalf("port %d mapped to %q, want %q", idx, ports[idx], key)
+		}
+	}
+
+	if _, err := expandPortKeys([]string{"1-8", "8-12"}); err == nil {
+		t.Fatal("expandPortKeys() with overlapping ranges succeeded, want error")
+	}
+	if _, err := expandPortKeys([]string{"1-8", "x"}); err == nil {
+		t.Fatal("expandPortKeys() with an invalid key succeeded, want error")
+	}
+}
+
+func TestGroupPortValues(t *testing.T) {
+	trunk := types.StringValue("trunk")
+	access := types.StringValue("access")
+
+	cases := []struct {
+		name   string
+		keys   []string
+		values map[int]attr.Value
+		want   map[string]attr.Value
+	}{
+		{
+			name:   "uniform range stays grouped",
+			keys:   []string{"1-3"},
+			values: map[int]attr.Value{1: trunk, 2: trunk, 3: trunk},
+			want:   map[string]attr.Value{"1-3": trunk},
+		},
+		{
+			name:   "changed port splits range",
+			keys:   []string{"1-3"},
+			values: map[int]attr.Value{1: trunk, 2: access, 3: trunk},
+			want:   map[string]attr.Value{"1": trunk, "2": access, "3": trunk},
+		},
+		{
+			name:   "missing port splits range",
+			keys:   []string{"1-3"},
+			values: map[int]attr.Value{1: trunk, 3: trunk},
+			want:   map[string]attr.Value{"1": trunk, "3": trunk},
+		},
+		{
+			name:   "unmanaged port reported",
+			keys:   []string{"1-2"},
+			values: map[int]attr.Value{1: trunk, 2: trunk, 7: access},
+			want:   map[string]attr.Value{"1-2": trunk, "7": access},
+		},
+		{
+			name:   "no prior keys",
+			values: map[int]attr.Value{1: trunk, 2: trunk},
+			want:   map[string]attr.Value{"1": trunk, "2": trunk},
+		},
+		{
+			name: "removed override drops key",
+			keys: []string{"4"},
+			want: map[string]attr.Value{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := groupPortValues(tc.keys, tc.values)
+			if len(got) != len(tc.want) {
+				t.Fatalf("groupPortValues() = %v, want %v", got, tc.want)
+			}
+			for key, v := range tc.want {
+				if g, ok := got[key]; !ok || !g.Equal(v) {
+					t.Fatalf("groupPortValues()[%q] = %v, want %v (all: %v)", key, g, v, got)
+				}
+			}
+		})
+	}
+}
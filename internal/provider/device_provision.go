@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// Values for the on_provision_timeout attribute.
+const (
+	provisionTimeoutError = "error"
+	provisionTimeoutWarn  = "warn"
+)
+
+// deviceProvisionPollInterval is how often a device is re-read while waiting
+// for it to re-provision.
+const deviceProvisionPollInterval = 3 * time.Second
+
+// deviceProvisionGracePolls is how many polls a device may stay connected
+// before the wait concludes it applied the change without a visible
+// provisioning state. Small changes can be provisioned between two polls.
+const deviceProvisionGracePolls = 5
+
+// waitForProvisionAttribute returns the wait_for_provision schema attribute
+// for resources that write device configuration.
+func waitForProvisionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Wait after each write until the device has re-provisioned and is connected again, bounded by the " +
+			"create or update timeout. Resources that depend on the device then see the applied configuration. " +
+			"Defaults to false.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// onProvisionTimeoutAttribute returns the on_provision_timeout schema
+// attribute that goes with wait_for_provision.
+func onProvisionTimeoutAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "What happens when wait_for_provision runs out of time before the device is connected again. " +
+			"'warn' reports a warning and keeps the write; 'error' fails the apply so a device that never comes back " +
+			"is caught. The configuration is saved on the controller either way. Defaults to 'warn'.",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(provisionTimeoutWarn),
+		Validators: []validator.String{
+			stringvalidator.OneOf(provisionTimeoutError, provisionTimeoutWarn),
+		},
+	}
+}
+
+// provisionDone reports whether a device has finished re-provisioning after a
+// write. A device that has been seen in any state other than connected is
+// done once it is connected again; one that never left connected is done
+// after deviceProvisionGracePolls polls.
+func provisionDone(sawProvisioning bool, polls int, state *int) bool {
+	if state == nil || *state != deviceStateConnected {
+		return false
+	}
+	return sawProvisioning || polls >= deviceProvisionGracePolls
+}
+
+// waitForDeviceProvision polls a device after a write until it has gone
+// through provisioning back to connected, or ctx expires. It returns the last
+// device read when the wait finished, or nil on timeout or error. A timeout
+// is reported as a warning or an error depending on onTimeout.
+func (c *AutoLoginClient) waitForDeviceProvision(ctx context.Context, mac string, onTimeout types.String, diags *diag.Diagnostics) *unifi.DeviceConfig {
+	ticker := time.NewTicker(deviceProvisionPollInterval)
+	defer ticker.Stop()
+
+	sawProvisioning := false
+	lastState := "unknown"
+	for polls := 1; ; polls++ {
+		select {
+		case <-ctx.Done():
+			summary := "Timed out waiting for device provisioning"
+			detail := fmt.Sprintf("Device %s did not finish provisioning before the timeout (last state: %s). "+
+				"The configuration was saved on the controller; check the device, or raise the resource's timeouts.", mac, lastState)
+			if onTimeout.ValueString() == provisionTimeoutError {
+				diags.AddError(summary, detail)
+			} else {
+				diags.AddWarning(summary, detail)
+			}
+			return nil
+		case <-ticker.C:
+		}
+
+		device, err := c.GetDeviceByMAC(ctx, mac)
+		if err != nil {
+			if isNotFoundError(err) || ctx.Err() != nil {
+				continue
+			}
+			handleSDKError(diags, err, "read", "device")
+			return nil
+		}
+
+		if device.State != nil {
+			lastState = fmt.Sprintf("%d", *device.State)
+			if *device.State != deviceStateConnected {
+				sawProvisioning = true
+			}
+		}
+		if provisionDone(sawProvisioning, polls, device.State) {
+			return device
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package provider
+
+import "testing"
+
+func TestProvisionDone(t *testing.T) {
+	state := func(v int) *int { return &v }
+
+	cases := []struct {
+		name            string
+		sawProvisioning bool
+		polls           int
+		state           *int
+		want            bool
+	}{
+		{name: "back to connected after provisioning", sawProvisioning: true, polls: 2, state: state(deviceStateConnected), want: true},
+		{name: "still provisioning", sawProvisioning: true, polls: 2, state: state(5)},
+		{name: "connected before grace period", polls: 1, state: state(deviceStateConnected)},
+		{name: "connected through grace period", polls: deviceProvisionGracePolls, state: state(deviceStateConnected), want: true},
+		{name: "state not reported", sawProvisioning: true, polls: 10},
+		{name: "offline after grace period", polls: 10, state: state(0)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := provisionDone(tc.sawProvisioning, tc.polls, tc.state); got != tc.want {
+				t.Fatalf("provisionDone(%v, %d, %v) = %v, want %v", tc.sawProvisioning, tc.polls, tc.state, got, tc.want)
+			}
+		})
+	}
+}
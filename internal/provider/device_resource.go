@@ -6,14 +6,18 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -22,8 +26,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &DeviceResource{}
-	_ resource.ResourceWithImportState = &DeviceResource{}
+	_ resource.Resource                   = &DeviceResource{}
+	_ resource.ResourceWithImportState    = &DeviceResource{}
+	_ resource.ResourceWithValidateConfig = &DeviceResource{}
+	_ resource.ResourceWithModifyPlan     = &DeviceResource{}
 )
 
 type DeviceResource struct {
@@ -33,6 +39,9 @@ type DeviceResource struct {
 type DeviceResourceModel struct {
 	ID                         types.String   `tfsdk:"id"`
 	MAC                        types.String   `tfsdk:"mac"`
+	Adopt                      types.Bool     `tfsdk:"adopt"`
+	AdoptCredentials           types.Object   `tfsdk:"adopt_credentials"`
+	ForgetOnDestroy            types.Bool     `tfsdk:"forget_on_destroy"`
 	Name                       types.String   `tfsdk:"name"`
 	LedOverride                types.String   `tfsdk:"led_override"`
 	LedOverrideColor           types.String   `tfsdk:"led_override_color"`
@@ -40,6 +49,12 @@ type DeviceResourceModel struct {
 	SNMPContact                types.String   `tfsdk:"snmp_contact"`
 	SNMPLocation               types.String   `tfsdk:"snmp_location"`
 	RadioOverrides             types.List     `tfsdk:"radio_overrides"`
+	WLANOverrides              types.List     `tfsdk:"wlan_overrides"`
+	Management                 types.Object   `tfsdk:"management"`
+	Switch                     types.Object   `tfsdk:"switch"`
+	Firmware                   types.Object   `tfsdk:"firmware"`
+	WaitForProvision           types.Bool     `tfsdk:"wait_for_provision"`
+	OnProvisionTimeout         types.String   `tfsdk:"on_provision_timeout"`
 	SiteID                     types.String   `tfsdk:"site_id"`
 	Model                      types.String   `tfsdk:"model"`
 	Type                       types.String   `tfsdk:"type"`
@@ -92,9 +107,10 @@ func (r *DeviceResource) Metadata(ctx context.Context, req resource.MetadataRequ
 
 func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages a UniFi device's configuration. Devices are physically adopted hardware — " +
-			"this resource manages writable settings (name, LED, SNMP) on an existing device. " +
-			"Create looks up the device by MAC; delete removes it from Terraform state without forgetting the device.",
+		Description: "Manages a UniFi device's configuration. Devices are physical hardware — " +
+			"this resource manages writable settings (name, LED, SNMP) on a device found by MAC. " +
+			"With adopt = true, Create also adopts a device pending adoption and waits for it to connect; " +
+			"with forget_on_destroy = true, delete forgets the device on the controller.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier of the device.",
@@ -110,6 +126,49 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"adopt": schema.BoolAttribute{
+				Description: "Adopt the device on create if it is pending adoption, then wait until it is connected. " +
+					"Devices that are already adopted are left as they are. Only affects Create. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt_credentials": schema.SingleNestedAttribute{
+				Description: "SSH credentials for adopting a device that was set-informed from another controller and " +
+					"no longer accepts the factory default login. The controller logs into the device and points it at " +
+					"its own inform URL. Requires adopt = true.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"username": schema.StringAttribute{
+						Description: "SSH username on the device.",
+						Required:    true,
+					},
+					"password": schema.StringAttribute{
+						Description: "SSH password on the device.",
+						Required:    true,
+						Sensitive:   true,
+					},
+					"ip": schema.StringAttribute{
+						Description: "IP address to reach the device on. Defaults to the IP the controller reports for it.",
+						Optional:    true,
+					},
+					"ssh_port": schema.Int64Attribute{
+						Description: "SSH port on the device. Defaults to 22.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					},
+				},
+			},
+			"forget_on_destroy": schema.BoolAttribute{
+				Description: "Forget the device on the controller when the resource is destroyed, returning it to the " +
+					"pending adoption state after a factory reset. When false, destroy only removes the device from " +
+					"Terraform state. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"name": schema.StringAttribute{
 				Description: "The name of the device.",
 				Optional:    true,
@@ -258,6 +317,251 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 				},
 			},
+			"wlan_overrides": schema.ListNestedAttribute{
+				Description: "Per-radio WLAN overrides for access points: stop broadcasting a WLAN on this device, or on " +
+					"one of its radios, or move its clients to another VLAN here. Each WLAN and radio pair may appear once. " +
+					"An empty list removes every override.",
+				Optional: true,
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"wlan_id": schema.StringAttribute{
+							Description: "ID of the WLAN to override.",
+							Required:    true,
+						},
+						"radio": schema.StringAttribute{
+							Description: "Radio band the override applies to. Valid values: 'ng' (2.4GHz), 'na' (5GHz), '6e' (6GHz).",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("ng", "na", "6e"),
+							},
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the WLAN is broadcast on this radio. Defaults to true.",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+						},
+						"vlan_id": schema.Int64Attribute{
+							Description: "VLAN for the WLAN's clients on this radio, overriding the WLAN's network (1-4094).",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 4094),
+							},
+						},
+					},
+				},
+			},
+			"management": schema.SingleNestedAttribute{
+				Description: "The device's own management network configuration. When a write changes the device's " +
+					"addressing, management network or inform URL, the apply waits until the device reconnects to the " +
+					"controller, on the new static IP if one is set, and fails if it does not come back within the timeout. " +
+					"Not read on import.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Description: "How the device gets its management address. Valid values: 'dhcp', 'static'.",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(deviceManagementDHCP, deviceManagementStatic),
+						},
+					},
+					"ip": schema.StringAttribute{
+						Description: "Static management IP address. Required when type is 'static'.",
+						Optional:    true,
+						Validators: []validator.String{
+							IPv4Address(),
+						},
+					},
+					"netmask": schema.StringAttribute{
+						Description: "Static management netmask (e.g., 255.255.255.0). Required when type is 'static'.",
+						Optional:    true,
+						Validators: []validator.String{
+							IPv4Address(),
+						},
+					},
+					"gateway": schema.StringAttribute{
+						Description: "Static management gateway. Required when type is 'static'.",
+						Optional:    true,
+						Validators: []validator.String{
+							IPv4Address(),
+						},
+					},
+					"dns1": schema.StringAttribute{
+						Description: "Primary DNS server for static addressing.",
+						Optional:    true,
+						Validators: []validator.String{
+							IPv4Address(),
+						},
+					},
+					"dns2": schema.StringAttribute{
+						Description: "Secondary DNS server for static addressing.",
+						Optional:    true,
+						Validators: []validator.String{
+							IPv4Address(),
+						},
+					},
+					"vlan_network_id": schema.StringAttribute{
+						Description: "ID of the network whose VLAN carries the device's management traffic. " +
+							"Defaults to the controller's current value.",
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"inform_url": schema.StringAttribute{
+						Description: "Inform URL override the device uses to reach the controller. " +
+							"Defaults to the controller's current value.",
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"switch": schema.SingleNestedAttribute{
+				Description: "Switch-wide settings. Only valid on switches (type 'usw') and Dream Machines (type 'udm'); " +
+					"the apply fails on any other device type. Attributes left unset keep the controller's current value. " +
+					"Not read on import.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"stp_version": schema.StringAttribute{
+						Description: "Spanning tree protocol version. Valid values: 'stp', 'rstp', 'disabled'.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("stp", "rstp", "disabled"),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"stp_priority": schema.Int64Attribute{
+						Description: "Spanning tree bridge priority, 0 to 61440 in steps of 4096. Lower values win root bridge election.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Int64{
+							int64validator.OneOf(stpPriorityValues...),
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+					"jumbo_frames_enabled": schema.BoolAttribute{
+						Description: "Enable jumbo frames on all ports.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"flow_control_enabled": schema.BoolAttribute{
+						Description: "Enable 802.3x flow control on all ports.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"dhcp_snooping_enabled": schema.BoolAttribute{
+						Description: "Enable DHCP snooping, which drops DHCP offers arriving on ports that do not lead to a trusted server.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"dot1x_enabled": schema.BoolAttribute{
+						Description: "Enable 802.1X port control on the switch. Ports still choose their own mode through port overrides.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"dot1x_fallback_network_id": schema.StringAttribute{
+						Description: "ID of the network that clients failing 802.1X authentication are placed on.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"igmp_querier": schema.MapAttribute{
+						Description: "IGMP snooping querier addresses keyed by network ID. The switch acts as the IGMP querier " +
+							"on each listed network's VLAN, using the given source address. Networks not listed have no querier " +
+							"on this switch.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Map{
+							mapvalidator.ValueStringsAre(IPv4Address()),
+						},
+						PlanModifiers: []planmodifier.Map{
+							mapplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"firmware": schema.SingleNestedAttribute{
+				Description: "Firmware pinning and controlled upgrades. When the device runs firmware other than the target, " +
+					"the plan shows the difference and the apply starts the upgrade and waits until the device is connected " +
+					"again on the new version. Upgrades usually take several minutes, so raise timeouts.create and " +
+					"timeouts.update accordingly.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"target_version": schema.StringAttribute{
+						Description: "Firmware version to run, e.g. '6.6.55' or '6.6.55.15189'. Trailing components may be " +
+							"left out. Conflicts with channel.",
+						Optional: true,
+					},
+					"firmware_url": schema.StringAttribute{
+						Description: "URL of a firmware image for target_version. Needed when the controller does not offer " +
+							"target_version as the device's upgrade, such as for downgrades or pinned older releases.",
+						Optional: true,
+					},
+					"channel": schema.StringAttribute{
+						Description: "Follow a firmware channel instead of a fixed version. 'latest' keeps the device on the " +
+							"newest firmware the controller offers for it. Conflicts with target_version.",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(firmwareChannelLatest),
+						},
+					},
+					"upgrade_window_start": schema.StringAttribute{
+						Description: "Start of the daily window, as HH:MM in UTC, in which upgrades may start. Outside it the " +
+							"apply only warns and the upgrade stays pending in the plan. Requires upgrade_window_end.",
+						Optional: true,
+					},
+					"upgrade_window_end": schema.StringAttribute{
+						Description: "End of the daily upgrade window, as HH:MM in UTC. May be before the start for a window " +
+							"that runs over midnight.",
+						Optional: true,
+					},
+					"upgrade_strategy": schema.StringAttribute{
+						Description: "How this device's upgrade is coordinated with others. 'parallel' (the default) upgrades " +
+							"as soon as the device is applied. 'rolling' upgrades the devices of ap_group_id one at a time, " +
+							"starting only when every other device in the group is connected.",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(firmwareStrategyParallel, firmwareStrategyRolling),
+						},
+					},
+					"ap_group_id": schema.StringAttribute{
+						Description: "ID of the AP group whose devices upgrade one at a time. Required when upgrade_strategy " +
+							"is 'rolling'.",
+						Optional: true,
+					},
+					"upgrade_available": schema.BoolAttribute{
+						Description: "Whether the controller offers a firmware upgrade for the device.",
+						Computed:    true,
+					},
+				},
+			},
+			"wait_for_provision":   waitForProvisionAttribute(),
+			"on_provision_timeout": onProvisionTimeoutAttribute(),
 			"site_id": schema.StringAttribute{
 				Description: "The site ID where the device is adopted.",
 				Computed:    true,
@@ -338,18 +642,60 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	checkSwitchSettingsSupported(plan.Switch, device.Type, mac, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Adopt.ValueBool() && deviceNeedsAdoption(derefBool(device.Adopted), device.State) {
+		if err := r.adoptDevice(ctx, device, plan.AdoptCredentials); err != nil {
+			handleSDKError(&resp.Diagnostics, err, "adopt", "device")
+			return
+		}
+
+		device = r.waitForDeviceAdoption(ctx, mac, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	updateDevice := r.planToSDK(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	updateDevice.MAC = mac
+	reconnect := managementChanged(updateDevice, device)
 	updated, err := r.client.UpdateDevice(ctx, device.ID, updateDevice)
 	if err != nil {
 		handleSDKError(&resp.Diagnostics, err, "update", "device")
 		return
 	}
 
+	if reconnect {
+		updated = r.waitForDeviceReconnect(ctx, mac, device.IP, plannedManagementIP(plan.Management), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if plan.WaitForProvision.ValueBool() {
+		if provisioned := r.client.waitForDeviceProvision(ctx, mac, plan.OnProvisionTimeout, &resp.Diagnostics); provisioned != nil {
+			updated = provisioned
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	updated = r.applyFirmware(ctx, plan.Firmware, updated, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(r.sdkToState(ctx, updated, &plan)...)
+	if !plan.Firmware.IsNull() {
+		firmware, d := firmwareToObject(plan.Firmware, updated, false)
+		resp.Diagnostics.Append(d...)
+		plan.Firmware = firmware
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -383,7 +729,27 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	// Fill in the lifecycle flags for imported resources and state written
+	// before they existed.
+	if state.Adopt.IsNull() {
+		state.Adopt = types.BoolValue(false)
+	}
+	if state.ForgetOnDestroy.IsNull() {
+		state.ForgetOnDestroy = types.BoolValue(false)
+	}
+	if state.WaitForProvision.IsNull() {
+		state.WaitForProvision = types.BoolValue(false)
+	}
+	if state.OnProvisionTimeout.IsNull() {
+		state.OnProvisionTimeout = types.StringValue(provisionTimeoutWarn)
+	}
+
 	resp.Diagnostics.Append(r.sdkToState(ctx, device, &state)...)
+	if !state.Firmware.IsNull() {
+		firmware, d := firmwareToObject(state.Firmware, device, true)
+		resp.Diagnostics.Append(d...)
+		state.Firmware = firmware
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -413,19 +779,65 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	checkSwitchSettingsSupported(plan.Switch, state.Type.ValueString(), state.MAC.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	updateDevice := r.planToSDK(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	updateDevice.MAC = plan.MAC.ValueString()
 
+	// A management network change makes the device drop its connection and
+	// come back with the new settings. Wait for that so a device that cannot
+	// reach the controller any more fails the apply instead of going quiet.
+	// The settings are compared with the device rather than with state,
+	// which has no management block after an import.
+	reconnect := false
+	var oldIP string
+	if !plan.Management.IsNull() {
+		current, err := r.client.GetDeviceByMAC(ctx, updateDevice.MAC)
+		if err != nil {
+			handleSDKError(&resp.Diagnostics, err, "read", "device")
+			return
+		}
+		reconnect = managementChanged(updateDevice, current)
+		oldIP = current.IP
+	}
+
 	updated, err := r.client.UpdateDevice(ctx, state.ID.ValueString(), updateDevice)
 	if err != nil {
 		handleSDKError(&resp.Diagnostics, err, "update", "device")
 		return
 	}
 
+	if reconnect {
+		updated = r.waitForDeviceReconnect(ctx, updateDevice.MAC, oldIP, plannedManagementIP(plan.Management), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if plan.WaitForProvision.ValueBool() {
+		if provisioned := r.client.waitForDeviceProvision(ctx, updateDevice.MAC, plan.OnProvisionTimeout, &resp.Diagnostics); provisioned != nil {
+			updated = provisioned
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	updated = r.applyFirmware(ctx, plan.Firmware, updated, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(r.sdkToState(ctx, updated, &plan)...)
+	if !plan.Firmware.IsNull() {
+		firmware, d := firmwareToObject(plan.Firmware, updated, false)
+		resp.Diagnostics.Append(d...)
+		plan.Firmware = firmware
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -434,8 +846,85 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Devices are physical hardware — delete just removes from Terraform state.
-	// The device remains adopted on the controller.
+	var state DeviceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Devices are physical hardware — unless forget_on_destroy is set, delete
+	// just removes from Terraform state and the device remains adopted on the
+	// controller.
+	if !state.ForgetOnDestroy.ValueBool() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	deviceLock := r.client.getDeviceLock(state.ID.ValueString())
+	deviceLock.Lock()
+	defer deviceLock.Unlock()
+
+	err := r.client.ForgetDevice(ctx, state.MAC.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		handleSDKError(&resp.Diagnostics, err, "forget", "device")
+	}
+}
+
+func (r *DeviceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DeviceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.AdoptCredentials.IsNull() && !config.Adopt.IsUnknown() && !config.Adopt.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("adopt_credentials"),
+			"adopt_credentials requires adopt",
+			"adopt_credentials is only used when adopting a device. Set adopt = true or remove adopt_credentials.",
+		)
+	}
+
+	validateDeviceManagement(config.Management, &resp.Diagnostics)
+	validateDeviceFirmware(config.Firmware, &resp.Diagnostics)
+	validateWLANOverrides(ctx, config.WLANOverrides, &resp.Diagnostics)
+}
+
+// ModifyPlan plans the upgrade of a device following a firmware channel. Its
+// state only says whether the controller offers an upgrade, so the plan asks
+// for upgrade_available = false to make a pending upgrade show as a change.
+func (r *DeviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state DeviceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Firmware.IsNull() || plan.Firmware.IsUnknown() || state.Firmware.IsNull() {
+		return
+	}
+	if firmwareSettingsFromObject(plan.Firmware).Channel != firmwareChannelLatest {
+		return
+	}
+	if available, ok := state.Firmware.Attributes()["upgrade_available"].(types.Bool); !ok || !available.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("firmware").AtName("upgrade_available"), false)...)
 }
 
 func (r *DeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -524,6 +1013,18 @@ func (r *DeviceResource) planToSDK(ctx context.Context, plan *DeviceResourceMode
 		}
 	}
 
+	if !plan.WLANOverrides.IsNull() && !plan.WLANOverrides.IsUnknown() {
+		device.WLANOverrides = wlanOverridesFromList(ctx, plan.WLANOverrides, diags)
+	}
+
+	if !plan.Management.IsNull() && !plan.Management.IsUnknown() {
+		managementFromObject(plan.Management, device)
+	}
+
+	if !plan.Switch.IsNull() && !plan.Switch.IsUnknown() {
+		switchFromObject(ctx, plan.Switch, device, diags)
+	}
+
 	return device
 }
 
@@ -557,6 +1058,21 @@ func (r *DeviceResource) sdkToState(ctx context.Context, device *unifi.DeviceCon
 
 	state.Adopted = types.BoolValue(derefBool(device.Adopted))
 
+	// management is only tracked once it is in the configuration, so devices
+	// managed without it never show a diff for their addressing.
+	if !state.Management.IsNull() {
+		management, d := managementToObject(device)
+		diags.Append(d...)
+		state.Management = management
+	}
+
+	// switch follows the same rule as management.
+	if !state.Switch.IsNull() {
+		settings, d := switchToObject(ctx, device)
+		diags.Append(d...)
+		state.Switch = settings
+	}
+
 	if len(device.RadioOverrides) > 0 {
 		overrideValues := make([]attr.Value, len(device.RadioOverrides))
 		for i, ro := range device.RadioOverrides {
@@ -607,5 +1123,9 @@ func (r *DeviceResource) sdkToState(ctx context.Context, device *unifi.DeviceCon
 		state.RadioOverrides = types.ListValueMust(types.ObjectType{AttrTypes: radioOverrideAttrTypes}, []attr.Value{})
 	}
 
+	wlanOverrides, d := wlanOverridesToList(device)
+	diags.Append(d...)
+	state.WLANOverrides = wlanOverrides
+
 	return diags
 }
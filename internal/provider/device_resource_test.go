@@ -63,6 +63,131 @@ func TestAccDeviceResource_updateName(t *testing.T) {
 	})
 }
 
+// TestAccDeviceResource_adoptAlreadyAdopted checks that adopt = true leaves an
+// adopted device alone. Adopting a pending device needs hardware in the
+// pending state and is not covered by the acceptance tests.
+func TestAccDeviceResource_adoptAlreadyAdopted(t *testing.T) {
+	mac := testAccGetFirstDeviceMAC(t)
+	if mac == "" {
+		t.Skip("No device available for testing")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckDevice(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceResourceConfig_adopt(mac),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device.test", "adopt", "true"),
+					resource.TestCheckResourceAttr("unifi_device.test", "forget_on_destroy", "false"),
+					resource.TestCheckResourceAttr("unifi_device.test", "adopted", "true"),
+					resource.TestCheckResourceAttr("unifi_device.test", "state", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccDeviceResource_switchSettings sets controller defaults for the
+// spanning tree settings so the test switch keeps behaving as before.
+func TestAccDeviceResource_switchSettings(t *testing.T) {
+	mac := testAccGetFirstSwitchMAC(t)
+	if mac == "" {
+		t.Skip("No switch available for testing")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckSwitch(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceResourceConfig_switch(mac, "rstp", 32768),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device.test", "switch.stp_version", "rstp"),
+					resource.TestCheckResourceAttr("unifi_device.test", "switch.stp_priority", "32768"),
+					resource.TestCheckResourceAttrSet("unifi_device.test", "switch.jumbo_frames_enabled"),
+					resource.TestCheckResourceAttrSet("unifi_device.test", "switch.dhcp_snooping_enabled"),
+				),
+			},
+			{
+				Config: testAccDeviceResourceConfig_switch(mac, "rstp", 28672),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device.test", "switch.stp_priority", "28672"),
+				),
+			},
+			{
+				Config: testAccDeviceResourceConfig_switch(mac, "rstp", 32768),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device.test", "switch.stp_priority", "32768"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccDeviceResource_firmwarePinned pins the firmware the device already
+// runs, so no upgrade is started. Upgrades themselves are not covered by the
+// acceptance tests.
+func TestAccDeviceResource_firmwarePinned(t *testing.T) {
+	mac := testAccGetFirstDeviceMAC(t)
+	if mac == "" {
+		t.Skip("No device available for testing")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckDevice(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceResourceConfig_firmwarePinned(mac),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("unifi_device.test", "firmware.target_version", "data.unifi_device.test", "version"),
+					resource.TestCheckResourceAttrPair("unifi_device.test", "version", "data.unifi_device.test", "version"),
+					resource.TestCheckResourceAttrSet("unifi_device.test", "firmware.upgrade_available"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeviceResource_wlanOverrides(t *testing.T) {
+	mac := testAccGetFirstAPMAC(t)
+	if mac == "" {
+		t.Skip("No access point available for testing")
+	}
+	apGroupID := getDefaultAPGroupID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckDevice(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceResourceConfig_wlanOverrides(mac, apGroupID, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device.test", "wlan_overrides.#", "1"),
+					resource.TestCheckResourceAttrPair("unifi_device.test", "wlan_overrides.0.wlan_id", "unifi_wlan.test", "id"),
+					resource.TestCheckResourceAttr("unifi_device.test", "wlan_overrides.0.radio", "ng"),
+					resource.TestCheckResourceAttr("unifi_device.test", "wlan_overrides.0.enabled", "false"),
+				),
+			},
+			{
+				Config: testAccDeviceResourceConfig_wlanOverrides(mac, apGroupID, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device.test", "wlan_overrides.0.enabled", "true"),
+					resource.TestCheckResourceAttr("unifi_device.test", "wlan_overrides.0.vlan_id", "30"),
+				),
+			},
+			{
+				Config: testAccDeviceResourceConfig_noWLANOverrides(mac, apGroupID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_device.test", "wlan_overrides.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDeviceResourceConfig_basic(mac string) string {
 	return fmt.Sprintf(`
 %s
@@ -83,3 +208,92 @@ resource "unifi_device" "test" {
 }
 `, testAccProviderConfig, mac, name)
 }
+
+func testAccDeviceResourceConfig_adopt(mac string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_device" "test" {
+  mac   = %q
+  adopt = true
+}
+`, testAccProviderConfig, mac)
+}
+
+func testAccDeviceResourceConfig_switch(mac, stpVersion string, stpPriority int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_device" "test" {
+  mac = %q
+
+  switch = {
+    stp_version  = %q
+    stp_priority = %d
+  }
+}
+`, testAccProviderConfig, mac, stpVersion, stpPriority)
+}
+
+func testAccDeviceResourceConfig_firmwarePinned(mac string) string {
+	return fmt.Sprintf(`
+%s
+
+data "unifi_device" "test" {
+  mac = %q
+}
+
+resource "unifi_device" "test" {
+  mac = data.unifi_device.test.mac
+
+  firmware = {
+    target_version = data.unifi_device.test.version
+  }
+}
+`, testAccProviderConfig, mac)
+}
+
+func testAccDeviceResourceConfig_wlan(apGroupID string) string {
+	return fmt.Sprintf(`
+resource "unifi_wlan" "test" {
+  name         = "tf-acc-wlan-override"
+  security     = "wpapsk"
+  passphrase   = "tf-acc-passphrase"
+  ap_group_ids = [%q]
+}
+`, apGroupID)
+}
+
+func testAccDeviceResourceConfig_wlanOverrides(mac, apGroupID string, enabled bool) string {
+	vlan := ""
+	if enabled {
+		vlan = "vlan_id = 30"
+	}
+	return fmt.Sprintf(`
+%s
+%s
+
+resource "unifi_device" "test" {
+  mac = %q
+
+  wlan_overrides = [{
+    wlan_id = unifi_wlan.test.id
+    radio   = "ng"
+    enabled = %t
+    %s
+  }]
+}
+`, testAccProviderConfig, testAccDeviceResourceConfig_wlan(apGroupID), mac, enabled, vlan)
+}
+
+func testAccDeviceResourceConfig_noWLANOverrides(mac, apGroupID string) string {
+	return fmt.Sprintf(`
+%s
+%s
+
+resource "unifi_device" "test" {
+  mac            = %q
+  wlan_overrides = []
+}
+`, testAccProviderConfig, testAccDeviceResourceConfig_wlan(apGroupID), mac)
+}
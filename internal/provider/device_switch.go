@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+var deviceSwitchAttrTypes = map[string]attr.Type{
+	"stp_version":               types.StringType,
+	"stp_priority":              types.Int64Type,
+	"jumbo_frames_enabled":      types.BoolType,
+	"flow_control_enabled":      types.BoolType,
+	"dhcp_snooping_enabled":     types.BoolType,
+	"dot1x_enabled":             types.BoolType,
+	"dot1x_fallback_network_id": types.StringType,
+	"igmp_querier":              types.MapType{ElemType: types.StringType},
+}
+
+// stpPriorityValues are the bridge priorities the controller accepts: 0 to
+// 61440 in steps of 4096.
+var stpPriorityValues = func() []int64 {
+	values := make([]int64, 0, 16)
+	for p := int64(0); p <= 61440; p += 4096 {
+		values = append(values, p)
+	}
+	return values
+}()
+
+// isSwitchingDevice reports whether switch-level settings apply to a device
+// type: standalone switches and UniFi Dream Machines with built-in switch
+// ports.
+func isSwitchingDevice(deviceType string) bool {
+	return deviceType == "usw" || deviceType == "udm"
+}
+
+// checkSwitchSettingsSupported adds an error when the switch block is set on
+// a device that is not a switch.
+func checkSwitchSettingsSupported(settings types.Object, deviceType, mac string, diags *diag.Diagnostics) {
+	if settings.IsNull() || settings.IsUnknown() || isSwitchingDevice(deviceType) {
+		return
+	}
+	diags.AddAttributeError(
+		path.Root("switch"),
+		"Switch settings not supported",
+		fmt.Sprintf("Device %s is of type %q. The switch block can only be used on switches (usw) and Dream Machines (udm).", mac, deviceType),
+	)
+}
+
+// switchFromObject copies the switch block into the device update.
+func switchFromObject(ctx context.Context, obj types.Object, device *unifi.DeviceConfig, diags *diag.Diagnostics) {
+	attrs := obj.Attributes()
+
+	if v, ok := attrs["stp_version"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		device.StpVersion = v.ValueString()
+	}
+	if v, ok := attrs["stp_priority"].(types.Int64); ok && !v.IsNull() && !v.IsUnknown() {
+		device.StpPriority = strconv.FormatInt(v.ValueInt64(), 10)
+	}
+	if v, ok := attrs["jumbo_frames_enabled"].(types.Bool); ok && !v.IsNull() && !v.IsUnknown() {
+		device.JumboframeEnabled = boolPtr(v.ValueBool())
+	}
+	if v, ok := attrs["flow_control_enabled"].(types.Bool); ok && !v.IsNull() && !v.IsUnknown() {
+		device.FlowctrlEnabled = boolPtr(v.ValueBool())
+	}
+	if v, ok := attrs["dhcp_snooping_enabled"].(types.Bool); ok && !v.IsNull() && !v.IsUnknown() {
+		device.DHCPSnoop = boolPtr(v.ValueBool())
+	}
+	if v, ok := attrs["dot1x_enabled"].(types.Bool); ok && !v.IsNull() && !v.IsUnknown() {
+		device.Dot1xPortctrlEnabled = boolPtr(v.ValueBool())
+	}
+	if v, ok := attrs["dot1x_fallback_network_id"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		device.Dot1xFallbackNetworkconfID = v.ValueString()
+	}
+
+	if v, ok := attrs["igmp_querier"].(types.Map); ok && !v.IsUnknown() {
+		queriers := map[string]string{}
+		if !v.IsNull() {
+			diags.Append(v.ElementsAs(ctx, &queriers, false)...)
+		}
+
+		networkIDs := make([]string, 0, len(queriers))
+		for id := range queriers {
+			networkIDs = append(networkIDs, id)
+		}
+		sort.Strings(networkIDs)
+
+		device.IGMPQueriers = make([]unifi.DeviceIGMPQuerier, 0, len(networkIDs))
+		for _, id := range networkIDs {
+			device.IGMPQueriers = append(device.IGMPQueriers, unifi.DeviceIGMPQuerier{
+				NetworkconfID:  id,
+				QuerierAddress: queriers[id],
+			})
+		}
+	}
+}
+
+// switchToObject builds the switch block from the device.
+func switchToObject(ctx context.Context, device *unifi.DeviceConfig) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	priority := types.Int64Null()
+	if device.StpPriority != "" {
+		if p, err := strconv.ParseInt(device.StpPriority, 10, 64); err == nil {
+			priority = types.Int64Value(p)
+		}
+	}
+
+	queriers := make(map[string]string, len(device.IGMPQueriers))
+	for _, q := range device.IGMPQueriers {
+		queriers[q.NetworkconfID] = q.QuerierAddress
+	}
+	igmpQuerier, d := types.MapValueFrom(ctx, types.StringType, queriers)
+	diags.Append(d...)
+
+	obj, d := types.ObjectValue(deviceSwitchAttrTypes, map[string]attr.Value{
+		"stp_version":               stringValueOrNull(device.StpVersion),
+		"stp_priority":              priority,
+		"jumbo_frames_enabled":      types.BoolValue(derefBool(device.JumboframeEnabled)),
+		"flow_control_enabled":      types.BoolValue(derefBool(device.FlowctrlEnabled)),
+		"dhcp_snooping_enabled":     types.BoolValue(derefBool(device.DHCPSnoop)),
+		"dot1x_enabled":             types.BoolValue(derefBool(device.Dot1xPortctrlEnabled)),
+		"dot1x_fallback_network_id": stringValueOrNull(device.Dot1xFallbackNetworkconfID),
+		"igmp_querier":              igmpQuerier,
+	})
+	diags.Append(d...)
+	return obj, diags
+}
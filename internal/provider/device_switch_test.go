@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testDeviceSwitchObject(t *testing.T) types.Object {
+	t.Helper()
+
+	obj, d := types.ObjectValue(deviceSwitchAttrTypes, map[string]attr.Value{
+		"stp_version":               types.StringValue("rstp"),
+		"stp_priority":              types.Int64Value(4096),
+		"jumbo_frames_enabled":      types.BoolUnknown(),
+		"flow_control_enabled":      types.BoolUnknown(),
+		"dhcp_snooping_enabled":     types.BoolValue(true),
+		"dot1x_enabled":             types.BoolUnknown(),
+		"dot1x_fallback_network_id": types.StringUnknown(),
+		"igmp_querier":              types.MapUnknown(types.StringType),
+	})
+	if d.HasError() {
+		t.Fatalf("building switch object: %v", d)
+	}
+	return obj
+}
+
+func TestCheckSwitchSettingsSupported(t *testing.T) {
+	cases := []struct {
+		name       string
+		settings   types.Object
+		deviceType string
+		wantError  bool
+	}{
+		{name: "switch", settings: testDeviceSwitchObject(t), deviceType: "usw"},
+		{name: "dream machine", settings: testDeviceSwitchObject(t), deviceType: "udm"},
+		{name: "access point", settings: testDeviceSwitchObject(t), deviceType: "uap", wantError: true},
+		{name: "gateway", settings: testDeviceSwitchObject(t), deviceType: "uxg", wantError: true},
+		{name: "unknown type", settings: testDeviceSwitchObject(t), deviceType: "", wantError: true},
+		{name: "no switch block on access point", settings: types.ObjectNull(deviceSwitchAttrTypes), deviceType: "uap"},
+		{name: "unknown switch block", settings: types.ObjectUnknown(deviceSwitchAttrTypes), deviceType: "uap"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			checkSwitchSettingsSupported(tc.settings, tc.deviceType, "aa:bb:cc:dd:ee:ff", &diags)
+			if got := diags.HasError(); got != tc.wantError {
+				t.Fatalf("checkSwitchSettingsSupported(%q) error = %v, want %v: %v", tc.deviceType, got, tc.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestStpPriorityValues(t *testing.T) {
+	if got := len(stpPriorityValues); got != 16 {
+		t.Fatalf("len(stpPriorityValues) = %d, want 16", got)
+	}
+	if first, last := stpPriorityValues[0], stpPriorityValues[len(stpPriorityValues)-1]; first != 0 || last != 61440 {
+		t.Fatalf("stpPriorityValues range = %d..%d, want 0..61440", first, last)
+	}
+	for _, p := range stpPriorityValues {
+		if p%4096 != 0 {
+			t.Fatalf("stpPriorityValues contains %d, which is not a multiple of 4096", p)
+		}
+	}
+}
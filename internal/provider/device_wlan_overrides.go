@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+type WLANOverrideModel struct {
+	WLANID  types.String `tfsdk:"wlan_id"`
+	Radio   types.String `tfsdk:"radio"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	VLANID  types.Int64  `tfsdk:"vlan_id"`
+}
+
+var wlanOverrideAttrTypes = map[string]attr.Type{
+	"wlan_id": types.StringType,
+	"radio":   types.StringType,
+	"enabled": types.BoolType,
+	"vlan_id": types.Int64Type,
+}
+
+// validateWLANOverrides rejects two entries for the same WLAN and radio, which
+// the controller would silently collapse into one.
+func validateWLANOverrides(ctx context.Context, list types.List, diags *diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return
+	}
+
+	var overrides []WLANOverrideModel
+	diags.Append(list.ElementsAs(ctx, &overrides, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	seen := make(map[string]int, len(overrides))
+	for i, o := range overrides {
+		if o.WLANID.IsUnknown() || o.Radio.IsUnknown() {
+			continue
+		}
+		key := o.WLANID.ValueString() + "/" + o.Radio.ValueString()
+		if first, ok := seen[key]; ok {
+			diags.AddAttributeError(
+				path.Root("wlan_overrides").AtListIndex(i),
+				"Duplicate WLAN override",
+				fmt.Sprintf("wlan_overrides[%d] and wlan_overrides[%d] both override WLAN %s on radio %s. Merge them into one entry.",
+					first, i, o.WLANID.ValueString(), o.Radio.ValueString()),
+			)
+			continue
+		}
+		seen[key] = i
+	}
+}
+
+// wlanOverridesFromList converts planned wlan_overrides into the device's
+// override list. An empty list clears every override on the device.
+func wlanOverridesFromList(ctx context.Context, list types.List, diags *diag.Diagnostics) []unifi.WLANOverride {
+	var overrides []WLANOverrideModel
+	diags.Append(list.ElementsAs(ctx, &overrides, false)...)
+
+	result := make([]unifi.WLANOverride, 0, len(overrides))
+	for _, o := range overrides {
+		wo := unifi.WLANOverride{
+			WLANID:      o.WLANID.ValueString(),
+			Radio:       o.Radio.ValueString(),
+			Enabled:     boolPtr(true),
+			VLANEnabled: boolPtr(false),
+		}
+		if !o.Enabled.IsNull() && !o.Enabled.IsUnknown() {
+			wo.Enabled = boolPtr(o.Enabled.ValueBool())
+		}
+		if !o.VLANID.IsNull() && !o.VLANID.IsUnknown() {
+			wo.VLANEnabled = boolPtr(true)
+			wo.VLAN = intPtr(o.VLANID.ValueInt64())
+		}
+		result = append(result, wo)
+	}
+	return result
+}
+
+// wlanOverridesToList converts the device's overrides into wlan_overrides.
+func wlanOverridesToList(device *unifi.DeviceConfig) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, 0, len(device.WLANOverrides))
+	for _, wo := range device.WLANOverrides {
+		vlanID := types.Int64Null()
+		if derefBool(wo.VLANEnabled) && wo.VLAN != nil {
+			vlanID = types.Int64Value(int64(*wo.VLAN))
+		}
+
+		// A missing enabled flag means the WLAN broadcasts as usual.
+		enabled := true
+		if wo.Enabled != nil {
+			enabled = *wo.Enabled
+		}
+
+		obj, d := types.ObjectValue(wlanOverrideAttrTypes, map[string]attr.Value{
+			"wlan_id": types.StringValue(wo.WLANID),
+			"radio":   types.StringValue(wo.Radio),
+			"enabled": types.BoolValue(enabled),
+			"vlan_id": vlanID,
+		})
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: wlanOverrideAttrTypes}, values)
+	diags.Append(d...)
+	return list, diags
+}
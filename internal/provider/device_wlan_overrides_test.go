@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func testWLANOverrideList(t *testing.T, entries ...map[string]attr.Value) types.List {
+	t.Helper()
+
+	values := make([]attr.Value, len(entries))
+	for i, e := range entries {
+		vals := map[string]attr.Value{
+			"wlan_id": types.StringNull(),
+			"radio":   types.StringNull(),
+			"enabled": types.BoolValue(true),
+			"vlan_id": types.Int64Null(),
+		}
+		for k, v := range e {
+			vals[k] = v
+		}
+		obj, d := types.ObjectValue(wlanOverrideAttrTypes, vals)
+		if d.HasError() {
+			t.Fatalf("building WLAN override: %v", d)
+		}
+		values[i] = obj
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: wlanOverrideAttrTypes}, values)
+	if d.HasError() {
+		t.Fatalf("building WLAN override list: %v", d)
+	}
+	return list
+}
+
+func TestValidateWLANOverrides(t *testing.T) {
+	guest2g := map[string]attr.Value{"wlan_id": types.StringValue("guest"), "radio": types.StringValue("ng")}
+	guest5g := map[string]attr.Value{"wlan_id": types.StringValue("guest"), "radio": types.StringValue("na")}
+	unknownID := map[string]attr.Value{"wlan_id": types.StringUnknown(), "radio": types.StringValue("ng")}
+
+	cases := []struct {
+		name       string
+		list       types.List
+		wantErrors int
+	}{
+		{name: "null", list: types.ListNull(types.ObjectType{AttrTypes: wlanOverrideAttrTypes})},
+		{name: "distinct radios", list: testWLANOverrideList(t, guest2g, guest5g)},
+		{name: "duplicate", list: testWLANOverrideList(t, guest2g, guest5g, guest2g), wantErrors: 1},
+		{name: "unknown wlan id", list: testWLANOverrideList(t, unknownID, unknownID)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateWLANOverrides(context.Background(), tc.list, &diags)
+			if got := diags.ErrorsCount(); got != tc.wantErrors {
+				t.Fatalf("validateWLANOverrides() errors = %d, want %d: %v", got, tc.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestWLANOverridesRoundTrip(t *testing.T) {
+	planned := testWLANOverrideList(t,
+		map[string]attr.Value{"wlan_id": types.StringValue("guest"), "radio": types.StringValue("ng"), "enabled": types.BoolValue(false)},
+		map[string]attr.Value{"wlan_id": types.StringValue("corp"), "radio": types.StringValue("na"), "vlan_id": types.Int64Value(30)},
+	)
+
+	var diags diag.Diagnostics
+	overrides := wlanOverridesFromList(context.Background(), planned, &diags)
+	if diags.HasError() {
+		t.Fatalf("wlanOverridesFromList() = %v", diags)
+	}
+	if len(overrides) != 2 || derefBool(overrides[0].Enabled) || !derefBool(overrides[1].VLANEnabled) || *overrides[1].VLAN != 30 {
+		t.Fatalf("wlanOverridesFromList() = %+v, want guest disabled and corp on VLAN 30", overrides)
+	}
+
+	state, d := wlanOverridesToList(&unifi.DeviceConfig{WLANOverrides: overrides})
+	if d.HasError() {
+		t.Fatalf("wlanOverridesToList() = %v", d)
+	}
+	if !state.Equal(planned) {
+		t.Fatalf("wlanOverridesToList() = %v, want %v", state, planned)
+	}
+}
+
+func TestWLANOverridesToList_empty(t *testing.T) {
+	state, d := wlanOverridesToList(&unifi.DeviceConfig{})
+	if d.HasError() {
+		t.Fatalf("wlanOverridesToList() = %v", d)
+	}
+	if state.IsNull() || len(state.Elements()) != 0 {
+		t.Fatalf("wlanOverridesToList(no overrides) = %v, want an empty list", state)
+	}
+}
@@ -0,0 +1,675 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+const dhcpReservationsID = "dhcp_reservations"
+
+var (
+	_ resource.Resource                   = &DHCPReservationsResource{}
+	_ resource.ResourceWithImportState    = &DHCPReservationsResource{}
+	_ resource.ResourceWithModifyPlan     = &DHCPReservationsResource{}
+	_ resource.ResourceWithValidateConfig = &DHCPReservationsResource{}
+)
+
+type DHCPReservationsResource struct {
+	client *AutoLoginClient
+}
+
+type DHCPReservationsResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Reservations types.Map      `tfsdk:"reservations"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// DHCPReservationModel holds one entry in reservations. The fields match
+// unifi_user.
+type DHCPReservationModel struct {
+	Name           types.String `tfsdk:"name"`
+	FixedIP        types.String `tfsdk:"fixed_ip"`
+	NetworkID      types.String `tfsdk:"network_id"`
+	LocalDnsRecord types.String `tfsdk:"local_dns_record"`
+}
+
+var dhcpReservationAttrTypes = map[string]attr.Type{
+	"name":             types.StringType,
+	"fixed_ip":         types.StringType,
+	"network_id":       types.StringType,
+	"local_dns_record": types.StringType,
+}
+
+func NewDHCPReservationsResource() resource.Resource {
+	return &DHCPReservationsResource{}
+}
+
+func (r *DHCPReservationsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dhcp_reservations"
+}
+
+func (r *DHCPReservationsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages many DHCP reservations (fixed IPs) in one resource, keyed by client MAC address. " +
+			"All reservations are refreshed with a single client list call and only the clients whose reservation " +
+			"differs are written. Clients missing from reservations are left alone. " +
+			"Do not combine it with unifi_user for the same MAC address.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the resource. Always 'dhcp_reservations'.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"reservations": schema.MapNestedAttribute{
+				Description: "DHCP reservations keyed by client MAC address in lowercase, colon-separated form (aa:bb:cc:dd:ee:ff).",
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "A friendly name for the client. Left unchanged on the controller when unset.",
+							Optional:    true,
+						},
+						"fixed_ip": schema.StringAttribute{
+							Description: "The IP address reserved for the client. Must be inside the subnet of network_id.",
+							Required:    true,
+							Validators: []validator.String{
+								IPv4Address(),
+							},
+						},
+						"network_id": schema.StringAttribute{
+							Description: "The ID of the network the reservation is made on.",
+							Required:    true,
+						},
+						"local_dns_record": schema.StringAttribute{
+							Description: "A hostname the gateway resolves to fixed_ip. Unset disables the local DNS record.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DHCPReservationsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that every key is a MAC address in the form the
+// controller reports, so keys do not show as drift, and that no IP is
+// reserved twice.
+func (r *DHCPReservationsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var reservations types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reservations"), &reservations)...)
+	if resp.Diagnostics.HasError() || reservations.IsNull() || reservations.IsUnknown() {
+		return
+	}
+
+	var entries map[string]DHCPReservationModel
+	resp.Diagnostics.Append(reservations.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, c := range validateDHCPReservations(entries) {
+		resp.Diagnostics.AddAttributeError(path.Root("reservations").AtMapKey(c.Attribute), c.Summary, c.Detail)
+	}
+}
+
+// validateDHCPReservations returns a conflict, keyed by the MAC address of
+// the entry at fault, for each malformed key and each fixed IP that is
+// reserved more than once.
+func validateDHCPReservations(entries map[string]DHCPReservationModel) []networkConflict {
+	var conflicts []networkConflict
+
+	macs := sortedReservationMACs(entries)
+	owners := make(map[string]string, len(entries))
+	for _, mac := range macs {
+		if hw, err := net.ParseMAC(mac); err != nil || len(hw) != 6 {
+			conflicts = append(conflicts, networkConflict{
+				Attribute: mac,
+				Summary:   "Invalid MAC address",
+				Detail:    fmt.Sprintf("%q is not a MAC address. Use the form aa:bb:cc:dd:ee:ff.", mac),
+			})
+			continue
+		} else if hw.String() != mac {
+			conflicts = append(conflicts, networkConflict{
+				Attribute: mac,
+				Summary:   "Non-canonical MAC address",
+				Detail:    fmt.Sprintf("Write %q as %q, the form the controller reports, so it does not show as drift.", mac, hw.String()),
+			})
+			continue
+		}
+
+		ip := entries[mac].FixedIP
+		if ip.IsNull() || ip.IsUnknown() {
+			continue
+		}
+		if owner, ok := owners[ip.ValueString()]; ok {
+			conflicts = append(conflicts, networkConflict{
+				Attribute: mac,
+				Summary:   "Duplicate fixed IP",
+				Detail:    fmt.Sprintf("%s is reserved for both %s and %s.", ip.ValueString(), owner, mac),
+			})
+			continue
+		}
+		owners[ip.ValueString()] = mac
+	}
+
+	return conflicts
+}
+
+// ModifyPlan checks each fixed_ip against the network it is reserved on. An
+// address outside the subnet is an error; one inside the dynamic DHCP range
+// is a warning, since the server may already have leased it. Networks planned
+// in the same run are taken from the plan, the rest from a single controller
+// list call. Unknown values and lookup failures skip the check.
+func (r *DHCPReservationsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan DHCPReservationsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Reservations.IsUnknown() {
+		return
+	}
+
+	var entries map[string]DHCPReservationModel
+	resp.Diagnostics.Append(plan.Reservations.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networks := r.client.plannedNetworkSnapshot()
+	listed := false
+	for _, mac := range sortedReservationMACs(entries) {
+		entry := entries[mac]
+		if entry.FixedIP.IsUnknown() || entry.NetworkID.IsUnknown() {
+			continue
+		}
+		fixedIP := net.ParseIP(entry.FixedIP.ValueString()).To4()
+		if fixedIP == nil {
+			continue
+		}
+
+		networkID := entry.NetworkID.ValueString()
+		if _, ok := networks[networkID]; !ok && !listed {
+			listed = true
+			lookupCtx, cancel := context.WithTimeout(ctx, modifyPlanLookupTimeout)
+			existing, err := r.client.ListNetworks(lookupCtx)
+			cancel()
+			if err != nil {
+				resp.Diagnostics.AddWarning(
+					"Unable to check reservations against networks",
+					fmt.Sprintf("Listing networks on the controller failed, so fixed_ip values were not checked against their networks: %s", err),
+				)
+				return
+			}
+			for i := range existing {
+				if _, planned := networks[existing[i].ID]; !planned {
+					networks[existing[i].ID] = networkAddressingFromSDK(&existing[i])
+				}
+			}
+		}
+
+		network, ok := networks[networkID]
+		if !ok || network.Subnet == nil {
+			continue
+		}
+
+		attrPath := path.Root("reservations").AtMapKey(mac).AtName("fixed_ip")
+		switch {
+		case !network.Subnet.Contains(fixedIP):
+			resp.Diagnostics.AddAttributeError(
+				attrPath,
+				"Fixed IP outside network",
+				fmt.Sprintf("fixed_ip %s for %s is not inside subnet %s of network %q. "+
+					"The controller rejects DHCP reservations outside the network's subnet.",
+					fixedIP, mac, network.Subnet.String(), network.Name),
+			)
+		case inDHCPRange(fixedIP, network):
+			resp.Diagnostics.AddAttributeWarning(
+				attrPath,
+				"Fixed IP inside DHCP range",
+				fmt.Sprintf("fixed_ip %s for %s is inside the DHCP range %s-%s of network %q, so it may already be leased to another client. "+
+					"Reserve addresses outside the range to avoid conflicts.",
+					fixedIP, mac, network.DHCPStart, network.DHCPStop, network.Name),
+			)
+		}
+	}
+}
+
+func (r *DHCPReservationsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DHCPReservationsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.write(ctx, &plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DHCPReservationsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DHCPReservationsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	users, err := r.client.ListUsers(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "list", "clients")
+		return
+	}
+
+	resp.Diagnostics.Append(r.sdkToState(ctx, users, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DHCPReservationsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state DHCPReservationsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.write(ctx, &plan, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete clears the reservation and local DNS record of every client in
+// state. The client records themselves are kept, since the controller
+// recreates them as soon as the device reconnects.
+func (r *DHCPReservationsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DHCPReservationsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	empty := DHCPReservationsResourceModel{
+		Reservations: types.MapValueMust(types.ObjectType{AttrTypes: dhcpReservationAttrTypes}, map[string]attr.Value{}),
+	}
+	r.write(ctx, &empty, &state, &resp.Diagnostics)
+}
+
+// ImportState takes a comma-separated list of MAC addresses. Read then fills
+// in the reservations those clients have on the controller.
+func (r *DHCPReservationsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	nullEntry := types.ObjectNull(dhcpReservationAttrTypes)
+	entries := make(map[string]attr.Value)
+	for _, mac := range strings.Split(req.ID, ",") {
+		mac = strings.ToLower(strings.TrimSpace(mac))
+		if mac == "" {
+			continue
+		}
+		entries[mac] = nullEntry
+	}
+	if len(entries) == 0 {
+		resp.Diagnostics.AddError(
+			"Import Error",
+			fmt.Sprintf("Invalid import ID %q. Import requires a comma-separated list of client MAC addresses (e.g., aa:bb:cc:dd:ee:01,aa:bb:cc:dd:ee:02).", req.ID),
+		)
+		return
+	}
+
+	reservations, diags := types.MapValue(types.ObjectType{AttrTypes: dhcpReservationAttrTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dhcpReservationsID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("reservations"), reservations)...)
+}
+
+// write lists the controller's clients once, then clears the reservation of
+// clients that were in prior but are no longer planned and creates or
+// updates only the clients whose reservation differs from plan, in the order
+// orderReservationWrites gives. plan is refreshed from the controller's
+// responses.
+func (r *DHCPReservationsResource) write(ctx context.Context, plan, prior *DHCPReservationsResourceModel, diags *diag.Diagnostics) {
+	var planned map[string]DHCPReservationModel
+	diags.Append(plan.Reservations.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	users, err := r.client.ListUsers(ctx)
+	if err != nil {
+		handleSDKError(diags, err, "list", "clients")
+		return
+	}
+
+	var removed []string
+	if prior != nil && !prior.Reservations.IsNull() {
+		for mac := range prior.Reservations.Elements() {
+			if _, ok := planned[mac]; !ok {
+				removed = append(removed, mac)
+			}
+		}
+		sort.Strings(removed)
+	}
+
+	for _, step := range orderReservationWrites(users, planned, removed) {
+		existing := findUserByMAC(users, step.MAC)
+
+		if step.Clear {
+			user := *existing
+			clearReservation(&user)
+			updated, err := r.client.UpdateUser(ctx, user.ID, &user)
+			if err != nil {
+				if isNotFoundError(err) {
+					continue
+				}
+				handleSDKError(diags, err, "delete", fmt.Sprintf("DHCP reservation for %s", step.MAC))
+				return
+			}
+			*existing = *updated
+			continue
+		}
+
+		if existing == nil {
+			user := &unifi.User{MAC: step.MAC}
+			applyReservation(user, planned[step.MAC])
+			created, err := r.client.CreateUser(ctx, user)
+			if err != nil {
+				handleSDKError(diags, err, "create", fmt.Sprintf("DHCP reservation for %s", step.MAC))
+				return
+			}
+			users = append(users, *created)
+			continue
+		}
+
+		user := *existing
+		applyReservation(&user, planned[step.MAC])
+		updated, err := r.client.UpdateUser(ctx, user.ID, &user)
+		if err != nil {
+			handleSDKError(diags, err, "update", fmt.Sprintf("DHCP reservation for %s", step.MAC))
+			return
+		}
+		*existing = *updated
+	}
+
+	diags.Append(r.sdkToState(ctx, users, plan)...)
+}
+
+// sdkToState rebuilds reservations from the client list for the MAC
+// addresses already in state. A client that is gone, or no longer has a
+// fixed IP, is dropped so the next plan recreates its reservation. Names are
+// only tracked for entries that set one, except right after import.
+func (r *DHCPReservationsResource) sdkToState(ctx context.Context, users []unifi.User, state *DHCPReservationsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	state.ID = types.StringValue(dhcpReservationsID)
+
+	var prior map[string]types.Object
+	diags.Append(state.Reservations.ElementsAs(ctx, &prior, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	values := make(map[string]attr.Value, len(prior))
+	for mac, priorObj := range prior {
+		user := findUserByMAC(users, mac)
+		if user == nil || !derefBool(user.UseFixedIP) {
+			continue
+		}
+
+		imported := priorObj.IsNull()
+		trackName := imported
+		if !imported {
+			if name, ok := priorObj.Attributes()["name"].(types.String); ok && !name.IsNull() {
+				trackName = true
+			}
+		}
+
+		entry := reservationFromUser(user, trackName)
+		obj, d := types.ObjectValueFrom(ctx, dhcpReservationAttrTypes, entry)
+		diags.Append(d...)
+		values[mac] = obj
+	}
+
+	reservations, d := types.MapValue(types.ObjectType{AttrTypes: dhcpReservationAttrTypes}, values)
+	diags.Append(d...)
+	state.Reservations = reservations
+
+	return diags
+}
+
+// reservationFromUser converts a client's reservation into an entry. The
+// name is only filled in when trackName is set.
+func reservationFromUser(user *unifi.User, trackName bool) DHCPReservationModel {
+	entry := DHCPReservationModel{
+		Name:           types.StringNull(),
+		FixedIP:        stringValueOrNull(user.FixedIP),
+		NetworkID:      stringValueOrNull(user.NetworkID),
+		LocalDnsRecord: types.StringNull(),
+	}
+	if trackName {
+		entry.Name = stringValueOrNull(user.Name)
+	}
+	if derefBool(user.LocalDnsRecordEnabled) {
+		entry.LocalDnsRecord = stringValueOrNull(user.LocalDnsRecord)
+	}
+	return entry
+}
+
+// reservationMatches reports whether the client already has the planned
+// reservation, so it can be skipped.
+func reservationMatches(user *unifi.User, entry DHCPReservationModel) bool {
+	if !derefBool(user.UseFixedIP) ||
+		user.FixedIP != entry.FixedIP.ValueString() ||
+		user.NetworkID != entry.NetworkID.ValueString() {
+		return false
+	}
+	if !entry.Name.IsNull() && user.Name != entry.Name.ValueString() {
+		return false
+	}
+	if entry.LocalDnsRecord.IsNull() {
+		return !derefBool(user.LocalDnsRecordEnabled)
+	}
+	return derefBool(user.LocalDnsRecordEnabled) && user.LocalDnsRecord == entry.LocalDnsRecord.ValueString()
+}
+
+// applyReservation sets the planned reservation on a client, leaving its
+// other fields as they are.
+func applyReservation(user *unifi.User, entry DHCPReservationModel) {
+	user.UseFixedIP = boolPtr(true)
+	user.FixedIP = entry.FixedIP.ValueString()
+	user.NetworkID = entry.NetworkID.ValueString()
+	if !entry.Name.IsNull() {
+		user.Name = entry.Name.ValueString()
+	}
+	if entry.LocalDnsRecord.IsNull() {
+		user.LocalDnsRecordEnabled = boolPtr(false)
+		user.LocalDnsRecord = ""
+	} else {
+		user.LocalDnsRecordEnabled = boolPtr(true)
+		user.LocalDnsRecord = entry.LocalDnsRecord.ValueString()
+	}
+}
+
+// clearReservation removes a client's fixed IP and local DNS record.
+func clearReservation(user *unifi.User) {
+	user.UseFixedIP = boolPtr(false)
+	user.FixedIP = ""
+	user.LocalDnsRecordEnabled = boolPtr(false)
+	user.LocalDnsRecord = ""
+}
+
+// reservationStep is one client write made by unifi_dhcp_reservations.
+// Clear removes the client's fixed IP; otherwise the client's planned
+// reservation is applied.
+type reservationStep struct {
+	MAC   string
+	Clear bool
+}
+
+// orderReservationWrites orders the writes that bring users to the planned
+// reservations. Removed reservations are cleared first, and a client moving
+// off a fixed IP is written before the client that takes the address, so
+// the controller never sees two clients reserving it. Clients swapping
+// addresses wait on each other; the cycle is broken by clearing one of them
+// first. Clients that already match their reservation are not written.
+func orderReservationWrites(users []unifi.User, planned map[string]DHCPReservationModel, removed []string) []reservationStep {
+	address := func(networkID, ip string) string {
+		return networkID + "/" + ip
+	}
+	holder := make(map[string]string) // address -> MAC
+	held := make(map[string]string)   // MAC -> address
+	for i := range users {
+		if u := &users[i]; derefBool(u.UseFixedIP) && u.FixedIP != "" {
+			mac := strings.ToLower(u.MAC)
+			holder[address(u.NetworkID, u.FixedIP)] = mac
+			held[mac] = address(u.NetworkID, u.FixedIP)
+		}
+	}
+	release := func(mac string) {
+		if a, ok := held[mac]; ok {
+			if holder[a] == mac {
+				delete(holder, a)
+			}
+			delete(held, mac)
+		}
+	}
+
+	var steps []reservationStep
+	for _, mac := range removed {
+		if u := findUserByMAC(users, mac); u != nil && derefBool(u.UseFixedIP) {
+			steps = append(steps, reservationStep{MAC: mac, Clear: true})
+			release(mac)
+		}
+	}
+
+	pending := make(map[string]bool)
+	var queue []string
+	for _, mac := range sortedReservationMACs(planned) {
+		if u := findUserByMAC(users, mac); u == nil || !reservationMatches(u, planned[mac]) {
+			pending[mac] = true
+			queue = append(queue, mac)
+		}
+	}
+
+	for len(queue) > 0 {
+		var blocked []string
+		for _, mac := range queue {
+			want := address(planned[mac].NetworkID.ValueString(), planned[mac].FixedIP.ValueString())
+			if h := holder[want]; h != "" && h != mac && pending[h] {
+				blocked = append(blocked, mac)
+				continue
+			}
+			steps = append(steps, reservationStep{MAC: mac})
+			release(mac)
+			holder[want] = mac
+			held[mac] = want
+			delete(pending, mac)
+		}
+		if len(blocked) == len(queue) {
+			steps = append(steps, reservationStep{MAC: blocked[0], Clear: true})
+			release(blocked[0])
+		}
+		queue = blocked
+	}
+
+	return steps
+}
+
+func sortedReservationMACs(entries map[string]DHCPReservationModel) []string {
+	macs := make([]string, 0, len(entries))
+	for mac := range entries {
+		macs = append(macs, mac)
+	}
+	sort.Strings(macs)
+	return macs
+}
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func testDHCPReservation(fixedIP string) DHCPReservationModel {
+	return DHCPReservationModel{
+		Name:           types.StringNull(),
+		FixedIP:        types.StringValue(fixedIP),
+		NetworkID:      types.StringValue("net1"),
+		LocalDnsRecord: types.StringNull(),
+	}
+}
+
+func TestValidateDHCPReservations(t *testing.T) {
+	cases := []struct {
+		name          string
+		entries       map[string]DHCPReservationModel
+		wantConflicts int
+	}{
+		{name: "valid", entries: map[string]DHCPReservationModel{
+			"aa:bb:cc:dd:ee:01": testDHCPReservation("10.0.0.5"),
+			"aa:bb:cc:dd:ee:02": testDHCPReservation("10.0.0.6"),
+		}},
+		{name: "not a mac", entries: map[string]DHCPReservationModel{"printer": testDHCPReservation("10.0.0.5")}, wantConflicts: 1},
+		{name: "upper case", entries: map[string]DHCPReservationModel{"AA:BB:CC:DD:EE:01": testDHCPReservation("10.0.0.5")}, wantConflicts: 1},
+		{name: "dashes", entries: map[string]DHCPReservationModel{"aa-bb-cc-dd-ee-01": testDHCPReservation("10.0.0.5")}, wantConflicts: 1},
+		{name: "duplicate ip", entries: map[string]DHCPReservationModel{
+			"aa:bb:cc:dd:ee:01": testDHCPReservation("10.0.0.5"),
+			"aa:bb:cc:dd:ee:02": testDHCPReservation("10.0.0.5"),
+		}, wantConflicts: 1},
+		{name: "unknown ip", entries: map[string]DHCPReservationModel{
+			"aa:bb:cc:dd:ee:01": {FixedIP: types.StringUnknown()},
+			"aa:bb:cc:dd:ee:02": {FixedIP: types.StringUnknown()},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := validateDHCPReservations(tc.entries); len(got) != tc.wantConflicts {
+				t.Fatalf("validateDHCPReservations() = %+v, want %d conflicts", got, tc.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestReservationMatches(t *testing.T) {
+	entry := testDHCPReservation("10.0.0.5")
+	named := entry
+	named.Name = types.StringValue("printer-1")
+	withDNS := entry
+	withDNS.LocalDnsRecord = types.StringValue("printer-1.lan")
+
+	reserved := unifi.User{MAC: "aa:bb:cc:dd:ee:01", Name: "HP LaserJet", UseFixedIP: boolPtr(true), FixedIP: "10.0.0.5", NetworkID: "net1"}
+
+	cases := []struct {
+		name  string
+		user  func(u *unifi.User)
+		entry DHCPReservationModel
+		want  bool
+	}{
+		{name: "same", entry: entry, want: true},
+		{name: "unmanaged name ignored", entry: entry, user: func(u *unifi.User) { u.Name = "renamed" }, want: true},
+		{name: "managed name differs", entry: named},
+		{name: "other ip", entry: entry, user: func(u *unifi.User) { u.FixedIP = "10.0.0.9" }},
+		{name: "other network", entry: entry, user: func(u *unifi.User) { u.NetworkID = "net2" }},
+		{name: "no fixed ip", entry: entry, user: func(u *unifi.User) { u.UseFixedIP = boolPtr(false) }},
+		{name: "dns record missing", entry: withDNS},
+		{name: "dns record not wanted", entry: entry, user: func(u *unifi.User) { u.LocalDnsRecordEnabled = boolPtr(true) }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			user := reserved
+			if tc.user != nil {
+				tc.user(&user)
+			}
+			if got := reservationMatches(&user, tc.entry); got != tc.want {
+				t.Fatalf("reservationMatches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplyAndClearReservation(t *testing.T) {
+	entry := testDHCPReservation("10.0.0.5")
+	entry.LocalDnsRecord = types.StringValue("printer-1.lan")
+
+	user := unifi.User{MAC: "aa:bb:cc:dd:ee:01", Name: "HP LaserJet", Note: "2nd floor"}
+	applyReservation(&user, entry)
+	if !reservationMatches(&user, entry) {
+		t.Fatalf("applyReservation() = %+v, does not match %+v", user, entry)
+	}
+	if user.Name != "HP LaserJet" || user.Note != "2nd floor" {
+		t.Fatalf("applyReservation() changed unmanaged fields: %+v", user)
+	}
+
+	clearReservation(&user)
+	if derefBool(user.UseFixedIP) || user.FixedIP != "" || derefBool(user.LocalDnsRecordEnabled) {
+		t.Fatalf("clearReservation() = %+v, want no fixed IP or DNS record", user)
+	}
+}
+
+func TestReservationFromUser(t *testing.T) {
+	user := &unifi.User{Name: "HP LaserJet", UseFixedIP: boolPtr(true), FixedIP: "10.0.0.5", NetworkID: "net1", LocalDnsRecord: "old.lan"}
+
+	if got := reservationFromUser(user, false); !got.Name.IsNull() || !got.LocalDnsRecord.IsNull() {
+		t.Fatalf("reservationFromUser(untracked name, dns disabled) = %+v, want null name and record", got)
+	}
+	if got := reservationFromUser(user, true); got.Name.ValueString() != "HP LaserJet" {
+		t.Fatalf("reservationFromUser(tracked name).Name = %v, want HP LaserJet", got.Name)
+	}
+}
+
+func TestOrderReservationWrites(t *testing.T) {
+	fixed := func(mac, ip string) unifi.User {
+		return unifi.User{MAC: mac, UseFixedIP: boolPtr(true), FixedIP: ip, NetworkID: "net1"}
+	}
+	format := func(steps []reservationStep) string {
+		var parts []string
+		for _, s := range steps {
+			if s.Clear {
+				parts = append(parts, "clear "+s.MAC[len(s.MAC)-2:])
+			} else {
+				parts = append(parts, "set "+s.MAC[len(s.MAC)-2:])
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	cases := []struct {
+		name    string
+		users   []unifi.User
+		planned map[string]DHCPReservationModel
+		removed []string
+		want    string
+	}{
+		{
+			name:  "removed reservation cleared before its address is reused",
+			users: []unifi.User{fixed("aa:bb:cc:dd:ee:01", "10.0.0.5"), {MAC: "aa:bb:cc:dd:ee:02"}},
+			planned: map[string]DHCPReservationModel{
+				"aa:bb:cc:dd:ee:02": testDHCPReservation("10.0.0.5"),
+			},
+			removed: []string{"aa:bb:cc:dd:ee:01"},
+			want:    "clear 01, set 02",
+		},
+		{
+			name:  "move frees an address before it is taken",
+			users: []unifi.User{fixed("aa:bb:cc:dd:ee:01", "10.0.0.6"), fixed("aa:bb:cc:dd:ee:02", "10.0.0.5")},
+			planned: map[string]DHCPReservationModel{
+				"aa:bb:cc:dd:ee:01": testDHCPReservation("10.0.0.5"),
+				"aa:bb:cc:dd:ee:02": testDHCPReservation("10.0.0.7"),
+			},
+			want: "set 02, set 01",
+		},
+		{
+			name:  "swap breaks the cycle with a clear",
+			users: []unifi.User{fixed("aa:bb:cc:dd:ee:01", "10.0.0.5"), fixed("aa:bb:cc:dd:ee:02", "10.0.0.6")},
+			planned: map[string]DHCPReservationModel{
+				"aa:bb:cc:dd:ee:01": testDHCPReservation("10.0.0.6"),
+				"aa:bb:cc:dd:ee:02": testDHCPReservation("10.0.0.5"),
+			},
+			want: "clear 01, set 02, set 01",
+		},
+		{
+			name:  "unchanged reservations are skipped",
+			users: []unifi.User{fixed("aa:bb:cc:dd:ee:01", "10.0.0.5")},
+			planned: map[string]DHCPReservationModel{
+				"aa:bb:cc:dd:ee:01": testDHCPReservation("10.0.0.5"),
+			},
+			want: "",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := format(orderReservationWrites(tc.users, tc.planned, tc.removed)); got != tc.want {
+				t.Fatalf("orderReservationWrites() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAccDHCPReservationsResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDHCPReservationsResourceConfig(`
+    "02:00:00:aa:43:01" = { name = "tf-acc-printer-1", fixed_ip = "10.214.0.201", network_id = unifi_network.test.id }
+    "02:00:00:aa:43:02" = { fixed_ip = "10.214.0.202", network_id = unifi_network.test.id, local_dns_record = "tf-acc-printer-2.lan" }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_dhcp_reservations.test", "id", "dhcp_reservations"),
+					resource.TestCheckResourceAttr("unifi_dhcp_reservations.test", "reservations.%", "2"),
+					resource.TestCheckResourceAttr("unifi_dhcp_reservations.test", "reservations.02:00:00:aa:43:01.fixed_ip", "10.214.0.201"),
+					resource.TestCheckResourceAttr("unifi_dhcp_reservations.test", "reservations.02:00:00:aa:43:02.local_dns_record", "tf-acc-printer-2.lan"),
+				),
+			},
+			{
+				Config: testAccDHCPReservationsResourceConfig(`
+    "02:00:00:aa:43:01" = { name = "tf-acc-printer-1", fixed_ip = "10.214.0.211", network_id = unifi_network.test.id }
+    "02:00:00:aa:43:03" = { fixed_ip = "10.214.0.203", network_id = unifi_network.test.id }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_dhcp_reservations.test", "reservations.%", "2"),
+					resource.TestCheckResourceAttr("unifi_dhcp_reservations.test", "reservations.02:00:00:aa:43:01.fixed_ip", "10.214.0.211"),
+					resource.TestCheckNoResourceAttr("unifi_dhcp_reservations.test", "reservations.02:00:00:aa:43:02.fixed_ip"),
+				),
+			},
+			{
+				ResourceName:      "unifi_dhcp_reservations.test",
+				ImportState:       true,
+				ImportStateId:     "02:00:00:aa:43:01,02:00:00:aa:43:03",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDHCPReservationsResource_outsideSubnet(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDHCPReservationsResourceConfig(`
+    "02:00:00:aa:43:01" = { fixed_ip = "10.99.0.5", network_id = unifi_network.test.id }
+`),
+				ExpectError: regexp.MustCompile(`Fixed IP outside network`),
+			},
+		},
+	})
+}
+
+func testAccDHCPReservationsResourceConfig(reservations string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name         = "tf-acc-test-reservations"
+  purpose      = "corporate"
+  vlan_id      = 3972
+  subnet       = "10.214.0.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "10.214.0.10"
+  dhcp_stop    = "10.214.0.100"
+}
+
+resource "unifi_dhcp_reservations" "test" {
+  reservations = {
+%s  }
+}
+`, testAccProviderConfig, reservations)
+}
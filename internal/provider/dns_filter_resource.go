@@ -0,0 +1,381 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// dnsFilterLevels are the filter levels the controller offers per network.
+var dnsFilterLevels = []string{"none", "work", "family"}
+
+var (
+	_ resource.Resource                = &DNSFilterResource{}
+	_ resource.ResourceWithImportState = &DNSFilterResource{}
+)
+
+type DNSFilterResource struct {
+	client *AutoLoginClient
+}
+
+type DNSFilterResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	NetworkID    types.String   `tfsdk:"network_id"`
+	Filter       types.String   `tfsdk:"filter"`
+	Name         types.String   `tfsdk:"name"`
+	Description  types.String   `tfsdk:"description"`
+	BlockedTLD   types.Set      `tfsdk:"blocked_tld"`
+	BlockedSites types.Set      `tfsdk:"blocked_sites"`
+	AllowedSites types.Set      `tfsdk:"allowed_sites"`
+	SafeSearch   types.Bool     `tfsdk:"safe_search"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewDNSFilterResource() resource.Resource {
+	return &DNSFilterResource{}
+}
+
+func (r *DNSFilterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_filter"
+}
+
+func (r *DNSFilterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the DNS filter of one network. Filters are stored in the site's IPS setting (setting/ips) " +
+			"next to those of other networks; this resource only changes the entry for its network_id, and " +
+			"turns on dns_filtering for the site when it creates one. Do not also set dns_filters in unifi_setting_ips.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the DNS filter. Same as network_id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				Description: "The ID of the network the filter applies to. Changing this forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filter": schema.StringAttribute{
+				Description: "The filter level. Valid values: 'none' (only the allow and block lists apply), " +
+					"'work' (blocks malicious and adult content), 'family' (also blocks content unsuitable for children).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnsFilterLevels...),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "A name for the filter.",
+				Optional:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "A description of the filter.",
+				Optional:    true,
+			},
+			"blocked_tld": schema.SetAttribute{
+				Description: "Top-level domains to block (e.g., 'xyz').",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"blocked_sites": schema.SetAttribute{
+				Description: "Domains to block regardless of the filter level.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"allowed_sites": schema.SetAttribute{
+				Description: "Domains to allow regardless of the filter level.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"safe_search": schema.BoolAttribute{
+				Description: "Whether search engines are forced to use safe search. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *DNSFilterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DNSFilterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DNSFilterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	filter := r.planToSDK(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.modifyFilters(ctx, func(setting *unifi.SettingIPS) error {
+		if findDNSFilter(setting.DNSFilters, filter.NetworkID) >= 0 {
+			return fmt.Errorf("network %s already has a DNS filter; import it with terraform import", filter.NetworkID)
+		}
+		setting.DNSFilters = mergeDNSFilter(setting.DNSFilters, *filter)
+		setting.DNSFiltering = boolPtr(true)
+		return nil
+	})
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "create", "DNS filter")
+		return
+	}
+
+	r.sdkToState(updated, filter.NetworkID, &plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DNSFilterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DNSFilterResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	setting, err := r.client.GetSettingIPS(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "DNS filter")
+		return
+	}
+
+	if !r.sdkToState(setting, state.ID.ValueString(), &state) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DNSFilterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DNSFilterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	filter := r.planToSDK(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.modifyFilters(ctx, func(setting *unifi.SettingIPS) error {
+		if findDNSFilter(setting.DNSFilters, filter.NetworkID) < 0 {
+			return unifi.ErrNotFound
+		}
+		setting.DNSFilters = mergeDNSFilter(setting.DNSFilters, *filter)
+		return nil
+	})
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "update", "DNS filter")
+		return
+	}
+
+	r.sdkToState(updated, filter.NetworkID, &plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DNSFilterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DNSFilterResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.modifyFilters(ctx, func(setting *unifi.SettingIPS) error {
+		setting.DNSFilters = removeDNSFilter(setting.DNSFilters, state.ID.ValueString())
+		return nil
+	})
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "delete", "DNS filter")
+		return
+	}
+}
+
+func (r *DNSFilterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), req.ID)...)
+}
+
+// modifyFilters applies mutate to the current IPS setting and writes it back,
+// holding the setting lock so concurrent unifi_dns_filter and
+// unifi_setting_ips operations do not overwrite each other's changes.
+func (r *DNSFilterResource) modifyFilters(ctx context.Context, mutate func(*unifi.SettingIPS) error) (*unifi.SettingIPS, error) {
+	settingLock := r.client.getSettingLock("ips")
+	settingLock.Lock()
+	defer settingLock.Unlock()
+
+	setting, err := r.client.GetSettingIPS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := mutate(setting); err != nil {
+		return nil, err
+	}
+	return r.client.UpdateSettingIPS(ctx, setting)
+}
+
+func (r *DNSFilterResource) planToSDK(ctx context.Context, plan *DNSFilterResourceModel, diags *diag.Diagnostics) *unifi.DNSFilter {
+	filter := &unifi.DNSFilter{
+		NetworkID:   plan.NetworkID.ValueString(),
+		Filter:      plan.Filter.ValueString(),
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		SafeSearch:  boolPtr(plan.SafeSearch.ValueBool()),
+	}
+
+	if !plan.BlockedTLD.IsNull() && !plan.BlockedTLD.IsUnknown() {
+		diags.Append(plan.BlockedTLD.ElementsAs(ctx, &filter.BlockedTLD, false)...)
+	}
+	if !plan.BlockedSites.IsNull() && !plan.BlockedSites.IsUnknown() {
+		diags.Append(plan.BlockedSites.ElementsAs(ctx, &filter.BlockedSites, false)...)
+	}
+	if !plan.AllowedSites.IsNull() && !plan.AllowedSites.IsUnknown() {
+		diags.Append(plan.AllowedSites.ElementsAs(ctx, &filter.AllowedSites, false)...)
+	}
+
+	return filter
+}
+
+// sdkToState copies the filter of networkID into state. It returns false if
+// the IPS setting has no filter for the network.
+func (r *DNSFilterResource) sdkToState(setting *unifi.SettingIPS, networkID string, state *DNSFilterResourceModel) bool {
+	i := findDNSFilter(setting.DNSFilters, networkID)
+	if i < 0 {
+		return false
+	}
+	f := setting.DNSFilters[i]
+
+	state.ID = types.StringValue(f.NetworkID)
+	state.NetworkID = types.StringValue(f.NetworkID)
+	state.Filter = types.StringValue(f.Filter)
+	state.Name = stringValueOrNull(f.Name)
+	state.Description = stringValueOrNull(f.Description)
+	state.BlockedTLD = stringSetOrNull(f.BlockedTLD)
+	state.BlockedSites = stringSetOrNull(f.BlockedSites)
+	state.AllowedSites = stringSetOrNull(f.AllowedSites)
+	state.SafeSearch = types.BoolValue(derefBool(f.SafeSearch))
+
+	return true
+}
+
+// findDNSFilter returns the index of the filter for networkID, or -1.
+func findDNSFilter(filters []unifi.DNSFilter, networkID string) int {
+	for i := range filters {
+		if filters[i].NetworkID == networkID {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeDNSFilter replaces the filter for the same network, keeping the
+// controller's version, or appends it. Filters of other networks are
+// returned unchanged and in order.
+func mergeDNSFilter(filters []unifi.DNSFilter, filter unifi.DNSFilter) []unifi.DNSFilter {
+	merged := make([]unifi.DNSFilter, len(filters), len(filters)+1)
+	copy(merged, filters)
+
+	if i := findDNSFilter(merged, filter.NetworkID); i >= 0 {
+		if filter.Version == "" {
+			filter.Version = merged[i].Version
+		}
+		merged[i] = filter
+		return merged
+	}
+	return append(merged, filter)
+}
+
+// removeDNSFilter returns filters without the filter for networkID.
+func removeDNSFilter(filters []unifi.DNSFilter, networkID string) []unifi.DNSFilter {
+	kept := make([]unifi.DNSFilter, 0, len(filters))
+	for _, f := range filters {
+		if f.NetworkID != networkID {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func TestMergeDNSFilter(t *testing.T) {
+	filters := []unifi.DNSFilter{
+		{NetworkID: "a", Filter: "work", Version: "v4"},
+		{NetworkID: "b", Filter: "family", Version: "v4"},
+	}
+
+	merged := mergeDNSFilter(filters, unifi.DNSFilter{NetworkID: "a", Filter: "family"})
+	if len(merged) != 2 || merged[0].Filter != "family" || merged[0].Version != "v4" || merged[1].NetworkID != "b" {
+		t.Fatalf("mergeDNSFilter(replace) = %+v", merged)
+	}
+	if filters[0].Filter != "work" {
+		t.Fatalf("mergeDNSFilter modified its input: %+v", filters)
+	}
+
+	merged = mergeDNSFilter(filters, unifi.DNSFilter{NetworkID: "c", Filter: "none"})
+	if len(merged) != 3 || merged[2].NetworkID != "c" {
+		t.Fatalf("mergeDNSFilter(append) = %+v", merged)
+	}
+
+	removed := removeDNSFilter(filters, "a")
+	if len(removed) != 1 || removed[0].NetworkID != "b" {
+		t.Fatalf("removeDNSFilter() = %+v", removed)
+	}
+	if got := findDNSFilter(removed, "a"); got != -1 {
+		t.Fatalf("findDNSFilter() = %d after removal, want -1", got)
+	}
+}
+
+func TestAccDNSFilterResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSFilterResourceConfig("work", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("unifi_dns_filter.test", "network_id", "unifi_network.test", "id"),
+					resource.TestCheckResourceAttr("unifi_dns_filter.test", "filter", "work"),
+					resource.TestCheckResourceAttr("unifi_dns_filter.test", "blocked_sites.#", "2"),
+					resource.TestCheckResourceAttr("unifi_dns_filter.test", "allowed_sites.#", "1"),
+					resource.TestCheckResourceAttr("unifi_dns_filter.test", "safe_search", "false"),
+				),
+			},
+			{
+				Config: testAccDNSFilterResourceConfig("family", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_dns_filter.test", "filter", "family"),
+					resource.TestCheckResourceAttr("unifi_dns_filter.test", "safe_search", "true"),
+				),
+			},
+			{
+				ResourceName:      "unifi_dns_filter.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccDNSFilterResourceConfig(filter string, safeSearch bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name         = "tf-acc-test-dns-filter"
+  purpose      = "corporate"
+  vlan_id      = 3991
+  subnet       = "10.191.0.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "10.191.0.10"
+  dhcp_stop    = "10.191.0.254"
+}
+
+resource "unifi_dns_filter" "test" {
+  network_id    = unifi_network.test.id
+  filter        = %q
+  name          = "tf-acc-test-dns-filter"
+  blocked_sites = ["example.net", "example.org"]
+  allowed_sites = ["example.com"]
+  safe_search   = %t
+}
+`, testAccProviderConfig, filter, safeSearch)
+}
@@ -21,14 +21,17 @@ type DynamicDNSDataSource struct {
 }
 
 type DynamicDNSDataSourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	SiteID    types.String `tfsdk:"site_id"`
-	Service   types.String `tfsdk:"service"`
-	HostName  types.String `tfsdk:"hostname"`
-	Login     types.String `tfsdk:"login"`
-	Server    types.String `tfsdk:"server"`
-	Interface types.String `tfsdk:"interface"`
-	Options   types.String `tfsdk:"options"`
+	ID         types.String `tfsdk:"id"`
+	SiteID     types.String `tfsdk:"site_id"`
+	Service    types.String `tfsdk:"service"`
+	HostName   types.String `tfsdk:"hostname"`
+	HostNames  types.List   `tfsdk:"hostnames"`
+	Login      types.String `tfsdk:"login"`
+	Server     types.String `tfsdk:"server"`
+	Interface  types.String `tfsdk:"interface"`
+	Options    types.String `tfsdk:"options"`
+	CurrentIP  types.String `tfsdk:"current_ip"`
+	LastUpdate types.String `tfsdk:"last_update"`
 }
 
 func NewDynamicDNSDataSource() datasource.DataSource {
@@ -52,9 +55,16 @@ func (d *DynamicDNSDataSource) Schema(ctx context.Context, req datasource.Schema
 				},
 			},
 			"hostname": schema.StringAttribute{
-				Description: "The hostname to update with the dynamic DNS service. Specify either id or hostname.",
-				Optional:    true,
+				Description: "The hostname to update with the dynamic DNS service. Specify either id or hostname. " +
+					"When looking up by hostname, a configuration with several hostnames matches any of them. " +
+					"When looking up by id, holds the controller's comma-separated host name field.",
+				Optional: true,
+				Computed: true,
+			},
+			"hostnames": schema.ListAttribute{
+				Description: "The hostnames updated with the dynamic DNS service.",
 				Computed:    true,
+				ElementType: types.StringType,
 			},
 			"site_id": schema.StringAttribute{
 				Description: "The site ID where the dynamic DNS is configured.",
@@ -80,6 +90,14 @@ func (d *DynamicDNSDataSource) Schema(ctx context.Context, req datasource.Schema
 				Description: "Additional options for the dynamic DNS service.",
 				Computed:    true,
 			},
+			"current_ip": schema.StringAttribute{
+				Description: "The IP address the controller last reported to the dynamic DNS service. Null until the first update.",
+				Computed:    true,
+			},
+			"last_update": schema.StringAttribute{
+				Description: "When the controller last updated the dynamic DNS service, in RFC 3339 format. Null until the first update.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -138,7 +156,7 @@ func (d *DynamicDNSDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 		searchHostname := config.HostName.ValueString()
 		for i := range records {
-			if records[i].HostName == searchHostname {
+			if dynamicDNSHasHostname(&records[i], searchHostname) {
 				dns = &records[i]
 				break
 			}
@@ -157,6 +175,7 @@ func (d *DynamicDNSDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	config.CurrentIP, config.LastUpdate = readDynamicDNSStatus(ctx, d.client, dns, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
@@ -167,7 +186,10 @@ func (d *DynamicDNSDataSource) sdkToState(ctx context.Context, dns *unifi.Dynami
 	state.ID = types.StringValue(dns.ID)
 	state.SiteID = stringValueOrNull(dns.SiteID)
 	state.Service = types.StringValue(dns.Service)
-	state.HostName = types.StringValue(dns.HostName)
+	if state.HostName.IsNull() || state.HostName.IsUnknown() {
+		state.HostName = types.StringValue(dns.HostName)
+	}
+	state.HostNames = stringListOrNull(splitDynamicDNSHostnames(dns.HostName))
 	state.Interface = stringValueOrNull(dns.Interface)
 
 	if dns.Login != "" {
@@ -190,3 +212,17 @@ func (d *DynamicDNSDataSource) sdkToState(ctx context.Context, dns *unifi.Dynami
 
 	return diags
 }
+
+// dynamicDNSHasHostname reports whether hostname is the host name field of
+// dns or one of the hostnames it lists.
+func dynamicDNSHasHostname(dns *unifi.DynamicDNS, hostname string) bool {
+	if dns.HostName == hostname {
+		return true
+	}
+	for _, h := range splitDynamicDNSHostnames(dns.HostName) {
+		if h == hostname {
+			return true
+		}
+	}
+	return false
+}
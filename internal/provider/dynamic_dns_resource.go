@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -20,8 +23,9 @@ import (
 )
 
 var (
-	_ resource.Resource                = &DynamicDNSResource{}
-	_ resource.ResourceWithImportState = &DynamicDNSResource{}
+	_ resource.Resource                   = &DynamicDNSResource{}
+	_ resource.ResourceWithImportState    = &DynamicDNSResource{}
+	_ resource.ResourceWithValidateConfig = &DynamicDNSResource{}
 )
 
 type DynamicDNSResource struct {
@@ -29,18 +33,27 @@ type DynamicDNSResource struct {
 }
 
 type DynamicDNSResourceModel struct {
-	ID        types.String   `tfsdk:"id"`
-	SiteID    types.String   `tfsdk:"site_id"`
-	Service   types.String   `tfsdk:"service"`
-	HostName  types.String   `tfsdk:"hostname"`
-	Login     types.String   `tfsdk:"login"`
-	Password  types.String   `tfsdk:"password"`
-	Server    types.String   `tfsdk:"server"`
-	Interface types.String   `tfsdk:"interface"`
-	Options   types.String   `tfsdk:"options"`
-	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	ID          types.String   `tfsdk:"id"`
+	SiteID      types.String   `tfsdk:"site_id"`
+	Service     types.String   `tfsdk:"service"`
+	HostName    types.String   `tfsdk:"hostname"`
+	HostNames   types.List     `tfsdk:"hostnames"`
+	Login       types.String   `tfsdk:"login"`
+	Password    types.String   `tfsdk:"password"`
+	Server      types.String   `tfsdk:"server"`
+	URLTemplate types.String   `tfsdk:"url_template"`
+	Interface   types.String   `tfsdk:"interface"`
+	Options     types.String   `tfsdk:"options"`
+	CurrentIP   types.String   `tfsdk:"current_ip"`
+	LastUpdate  types.String   `tfsdk:"last_update"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
+// dynamicDNSURLTemplatePattern matches the form the controller stores a
+// custom update URL in: host and path without a scheme, since updates are
+// always sent over HTTPS.
+var dynamicDNSURLTemplatePattern = regexp.MustCompile(`^[^/:\s]+(:[0-9]+)?/\S*$`)
+
 func NewDynamicDNSResource() resource.Resource {
 	return &DynamicDNSResource{}
 }
@@ -83,8 +96,23 @@ func (r *DynamicDNSResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			"hostname": schema.StringAttribute{
-				Description: "The hostname to update with the dynamic DNS service.",
-				Required:    true,
+				Description: "The hostname to update with the dynamic DNS service. Specify either hostname or hostnames.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("hostnames")),
+				},
+			},
+			"hostnames": schema.ListAttribute{
+				Description: "The hostnames to update with the dynamic DNS service, for services that accept several hostnames on one account. Specify either hostname or hostnames.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^,\s]+$`), "must be a single hostname without commas or spaces"),
+					),
+				},
 			},
 			"login": schema.StringAttribute{
 				Description: "The login/username for the dynamic DNS service.",
@@ -102,6 +130,16 @@ func (r *DynamicDNSResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Description: "The server address for the dynamic DNS service (primarily used with 'custom' service).",
 				Optional:    true,
 			},
+			"url_template": schema.StringAttribute{
+				Description: "The update URL of a self-hosted endpoint for the 'custom' service, without the scheme since updates are always sent over HTTPS, " +
+					"e.g. 'dyn.example.com/nic/update?hostname=%h&myip=%i'. %h is replaced with the hostname, %i with the current IP address, " +
+					"%u with login and %p with password. Conflicts with server.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("server")),
+					stringvalidator.RegexMatches(dynamicDNSURLTemplatePattern, "must be a host followed by a path, without a scheme"),
+				},
+			},
 			"interface": schema.StringAttribute{
 				Description: "The WAN interface to monitor for IP changes. Valid values: wan, wan2. Defaults to wan.",
 				Optional:    true,
@@ -115,6 +153,15 @@ func (r *DynamicDNSResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Description: "Additional options for the dynamic DNS service.",
 				Optional:    true,
 			},
+			"current_ip": schema.StringAttribute{
+				Description: "The IP address the controller last reported to the dynamic DNS service. Null until the first update.",
+				Computed:    true,
+			},
+			"last_update": schema.StringAttribute{
+				Description: "When the controller last updated the dynamic DNS service, in RFC 3339 format. Null until the first update. " +
+					"Alert on it to notice updates that stop without an error.",
+				Computed: true,
+			},
 		},
 	}
 }
@@ -136,6 +183,24 @@ func (r *DynamicDNSResource) Configure(ctx context.Context, req resource.Configu
 	r.client = client
 }
 
+// ValidateConfig rejects url_template for services other than 'custom'.
+func (r *DynamicDNSResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DynamicDNSResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.URLTemplate.IsNull() || config.Service.IsUnknown() || config.Service.ValueString() == "custom" {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root("url_template"),
+		"url_template requires the custom service",
+		fmt.Sprintf("url_template is only used by service 'custom', but service is %q. Remove url_template or set service to 'custom'.", config.Service.ValueString()),
+	)
+}
+
 func (r *DynamicDNSResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan DynamicDNSResourceModel
 
@@ -167,6 +232,7 @@ func (r *DynamicDNSResource) Create(ctx context.Context, req resource.CreateRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.CurrentIP, plan.LastUpdate = readDynamicDNSStatus(ctx, r.client, created, &resp.Diagnostics)
 
 	// Restore password if it was set
 	if !originalPassword.IsNull() {
@@ -209,6 +275,7 @@ func (r *DynamicDNSResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.CurrentIP, state.LastUpdate = readDynamicDNSStatus(ctx, r.client, dns, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -252,6 +319,7 @@ func (r *DynamicDNSResource) Update(ctx context.Context, req resource.UpdateRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.CurrentIP, plan.LastUpdate = readDynamicDNSStatus(ctx, r.client, updated, &resp.Diagnostics)
 
 	// Restore password if it was set
 	if !originalPassword.IsNull() {
@@ -298,6 +366,16 @@ func (r *DynamicDNSResource) planToSDK(plan *DynamicDNSResourceModel) *unifi.Dyn
 		Interface: plan.Interface.ValueString(),
 	}
 
+	if !plan.HostNames.IsNull() && !plan.HostNames.IsUnknown() {
+		var hostnames []string
+		for _, v := range plan.HostNames.Elements() {
+			if s, ok := v.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+				hostnames = append(hostnames, s.ValueString())
+			}
+		}
+		dns.HostName = joinDynamicDNSHostnames(hostnames)
+	}
+
 	if !plan.Login.IsNull() && !plan.Login.IsUnknown() {
 		dns.Login = plan.Login.ValueString()
 	}
@@ -310,6 +388,10 @@ func (r *DynamicDNSResource) planToSDK(plan *DynamicDNSResourceModel) *unifi.Dyn
 		dns.Server = plan.Server.ValueString()
 	}
 
+	if !plan.URLTemplate.IsNull() && !plan.URLTemplate.IsUnknown() {
+		dns.Server = plan.URLTemplate.ValueString()
+	}
+
 	if !plan.Options.IsNull() && !plan.Options.IsUnknown() {
 		dns.Options = plan.Options.ValueString()
 	}
@@ -323,19 +405,42 @@ func (r *DynamicDNSResource) sdkToState(ctx context.Context, dns *unifi.DynamicD
 	state.ID = types.StringValue(dns.ID)
 	state.SiteID = stringValueOrNull(dns.SiteID)
 	state.Service = types.StringValue(dns.Service)
-	state.HostName = types.StringValue(dns.HostName)
 	state.Interface = stringValueOrNull(dns.Interface)
 
+	// Hostnames are tracked the way they were configured. After import, a
+	// comma-separated host name becomes hostnames.
+	useList := !state.HostNames.IsNull()
+	if state.HostName.IsNull() && state.HostNames.IsNull() {
+		useList = strings.Contains(dns.HostName, ",")
+	}
+	if useList {
+		state.HostName = types.StringNull()
+		state.HostNames = stringListOrNull(splitDynamicDNSHostnames(dns.HostName))
+	} else {
+		state.HostName = types.StringValue(dns.HostName)
+		state.HostNames = types.ListNull(types.StringType)
+	}
+
 	if dns.Login != "" {
 		state.Login = types.StringValue(dns.Login)
 	} else {
 		state.Login = types.StringNull()
 	}
 
-	if dns.Server != "" {
+	// The server is tracked the way it was configured. After import, a
+	// custom service's server holding a path becomes url_template.
+	useTemplate := !state.URLTemplate.IsNull()
+	if state.Server.IsNull() && state.URLTemplate.IsNull() {
+		useTemplate = dns.Service == "custom" && strings.Contains(dns.Server, "/")
+	}
+	state.Server = types.StringNull()
+	state.URLTemplate = types.StringNull()
+	switch {
+	case dns.Server == "":
+	case useTemplate:
+		state.URLTemplate = types.StringValue(dns.Server)
+	default:
 		state.Server = types.StringValue(dns.Server)
-	} else {
-		state.Server = types.StringNull()
 	}
 
 	if dns.Options != "" {
@@ -351,3 +456,76 @@ func (r *DynamicDNSResource) sdkToState(ctx context.Context, dns *unifi.DynamicD
 
 	return diags
 }
+
+func joinDynamicDNSHostnames(hostnames []string) string {
+	return strings.Join(hostnames, ",")
+}
+
+// splitDynamicDNSHostnames splits the controller's comma-separated host name
+// field, dropping empty entries and surrounding spaces.
+func splitDynamicDNSHostnames(hostName string) []string {
+	var hostnames []string
+	for _, h := range strings.Split(hostName, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			hostnames = append(hostnames, h)
+		}
+	}
+	return hostnames
+}
+
+// findDynamicDNSStatus returns the status entry for dns: the entry for its
+// interface and service, preferring one that reports the same host name.
+func findDynamicDNSStatus(statuses []unifi.DynamicDNSStatus, dns *unifi.DynamicDNS) *unifi.DynamicDNSStatus {
+	iface := dns.Interface
+	if iface == "" {
+		iface = "wan"
+	}
+
+	var match *unifi.DynamicDNSStatus
+	for i := range statuses {
+		st := &statuses[i]
+		stIface := st.Interface
+		if stIface == "" {
+			stIface = "wan"
+		}
+		if stIface != iface || st.Service != dns.Service {
+			continue
+		}
+		if st.HostName == dns.HostName {
+			return st
+		}
+		if match == nil {
+			match = st
+		}
+	}
+	return match
+}
+
+// readDynamicDNSStatus returns current_ip and last_update for dns. The
+// status is informational, so a failed lookup is reported as a warning and
+// both values are left null. Controllers without the status endpoint
+// report nothing.
+func readDynamicDNSStatus(ctx context.Context, client *AutoLoginClient, dns *unifi.DynamicDNS, diags *diag.Diagnostics) (types.String, types.String) {
+	currentIP, lastUpdate := types.StringNull(), types.StringNull()
+
+	statuses, err := client.ListDynamicDNSStatus(ctx)
+	if err != nil {
+		if !isNotFoundError(err) {
+			diags.AddWarning(
+				"Unable to read dynamic DNS status",
+				fmt.Sprintf("Reading the dynamic DNS status from the controller failed, so current_ip and last_update are unknown: %s", err),
+			)
+		}
+		return currentIP, lastUpdate
+	}
+
+	st := findDynamicDNSStatus(statuses, dns)
+	if st == nil {
+		return currentIP, lastUpdate
+	}
+	currentIP = stringValueOrNull(st.CurrentIP)
+	if st.LastChanged > 0 {
+		lastUpdate = types.StringValue(time.Unix(st.LastChanged, 0).UTC().Format(time.RFC3339))
+	}
+	return currentIP, lastUpdate
+}
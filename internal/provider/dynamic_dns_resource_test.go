@@ -1,12 +1,91 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
 )
 
+func TestDynamicDNSHostnames(t *testing.T) {
+	got := splitDynamicDNSHostnames(" a.example.com, b.example.com,,c.example.com ")
+	if strings.Join(got, "|") != "a.example.com|b.example.com|c.example.com" {
+		t.Fatalf("splitDynamicDNSHostnames() = %q", got)
+	}
+	if joined := joinDynamicDNSHostnames(got); joined != "a.example.com,b.example.com,c.example.com" {
+		t.Fatalf("joinDynamicDNSHostnames() = %q", joined)
+	}
+	if got := splitDynamicDNSHostnames(""); got != nil {
+		t.Fatalf("splitDynamicDNSHostnames(\"\") = %q, want nil", got)
+	}
+}
+
+func TestDynamicDNSSDKToState_server(t *testing.T) {
+	dns := &unifi.DynamicDNS{ID: "1", Service: "custom", HostName: "home.example.com", Server: "dyn.example.com/update"}
+
+	tests := []struct {
+		name         string
+		server       types.String
+		urlTemplate  types.String
+		wantTemplate bool
+	}{
+		{"configured server", types.StringValue("dyn.example.com/update"), types.StringNull(), false},
+		{"configured url_template", types.StringNull(), types.StringValue("dyn.example.com/update"), true},
+		{"import", types.StringNull(), types.StringNull(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := DynamicDNSResourceModel{Server: tt.server, URLTemplate: tt.urlTemplate}
+			diags := (&DynamicDNSResource{}).sdkToState(context.Background(), dns, &state, nil)
+			if diags.HasError() {
+				t.Fatalf("sdkToState() diagnostics = %v", diags)
+			}
+			if got := !state.URLTemplate.IsNull(); got != tt.wantTemplate {
+				t.Fatalf("url_template set = %t, want %t (server = %s)", got, tt.wantTemplate, state.Server)
+			}
+			if state.Server.IsNull() == state.URLTemplate.IsNull() {
+				t.Fatalf("exactly one of server and url_template must be set, got %s and %s", state.Server, state.URLTemplate)
+			}
+		})
+	}
+}
+
+func TestFindDynamicDNSStatus(t *testing.T) {
+	statuses := []unifi.DynamicDNSStatus{
+		{Interface: "wan2", Service: "custom", HostName: "a.example.com", CurrentIP: "198.51.100.2"},
+		{Service: "custom", HostName: "b.example.com", CurrentIP: "198.51.100.1"},
+		{Interface: "wan", Service: "custom", HostName: "a.example.com", CurrentIP: "198.51.100.3"},
+	}
+
+	tests := []struct {
+		name string
+		dns  unifi.DynamicDNS
+		want string
+	}{
+		{"exact host name", unifi.DynamicDNS{Interface: "wan", Service: "custom", HostName: "a.example.com"}, "198.51.100.3"},
+		{"interface defaults to wan", unifi.DynamicDNS{Service: "custom", HostName: "b.example.com"}, "198.51.100.1"},
+		{"first entry for interface", unifi.DynamicDNS{Interface: "wan", Service: "custom", HostName: "c.example.com"}, "198.51.100.1"},
+		{"other interface", unifi.DynamicDNS{Interface: "wan2", Service: "custom", HostName: "x.example.com"}, "198.51.100.2"},
+		{"other service", unifi.DynamicDNS{Interface: "wan", Service: "dyndns", HostName: "a.example.com"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if st := findDynamicDNSStatus(statuses, &tt.dns); st != nil {
+				got = st.CurrentIP
+			}
+			if got != tt.want {
+				t.Fatalf("findDynamicDNSStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAccDynamicDNSResource_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -126,6 +205,63 @@ func TestAccDynamicDNSResource_update(t *testing.T) {
 	})
 }
 
+func TestAccDynamicDNSResource_hostnames(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynamicDNSResourceConfig_hostnames("tf-acc-test-ddns-a.example.com", "tf-acc-test-ddns-b.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_dynamic_dns.test", "hostnames.#", "2"),
+					resource.TestCheckResourceAttr("unifi_dynamic_dns.test", "hostnames.0", "tf-acc-test-ddns-a.example.com"),
+					resource.TestCheckResourceAttr("unifi_dynamic_dns.test", "hostnames.1", "tf-acc-test-ddns-b.example.com"),
+					resource.TestCheckNoResourceAttr("unifi_dynamic_dns.test", "hostname"),
+				),
+			},
+			{
+				Config: testAccDynamicDNSResourceConfig_hostnames("tf-acc-test-ddns-a.example.com", "tf-acc-test-ddns-c.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_dynamic_dns.test", "hostnames.#", "2"),
+					resource.TestCheckResourceAttr("unifi_dynamic_dns.test", "hostnames.1", "tf-acc-test-ddns-c.example.com"),
+				),
+			},
+			{
+				ResourceName:            "unifi_dynamic_dns.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "current_ip", "last_update"},
+			},
+		},
+	})
+}
+
+func TestAccDynamicDNSResource_urlTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDynamicDNSResourceConfig_urlTemplate("dyndns", "tf-acc-test-ddns-url.example.com"),
+				ExpectError: regexp.MustCompile(`url_template requires the custom service`),
+			},
+			{
+				Config: testAccDynamicDNSResourceConfig_urlTemplate("custom", "tf-acc-test-ddns-url.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_dynamic_dns.test", "url_template", "dyn.example.com/nic/update?hostname=%h&myip=%i"),
+					resource.TestCheckNoResourceAttr("unifi_dynamic_dns.test", "server"),
+				),
+			},
+			{
+				ResourceName:            "unifi_dynamic_dns.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "current_ip", "last_update"},
+			},
+		},
+	})
+}
+
 func testAccDynamicDNSResourceConfig_basic(hostname string) string {
 	return fmt.Sprintf(`
 %s
@@ -181,3 +317,31 @@ resource "unifi_dynamic_dns" "test" {
 }
 `, testAccProviderConfig, hostname)
 }
+
+func testAccDynamicDNSResourceConfig_hostnames(first, second string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_dynamic_dns" "test" {
+  service   = "custom"
+  hostnames = [%q, %q]
+  server    = "update.example.com"
+  login     = "testuser"
+  password  = "testpass"
+}
+`, testAccProviderConfig, first, second)
+}
+
+func testAccDynamicDNSResourceConfig_urlTemplate(service, hostname string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_dynamic_dns" "test" {
+  service      = %q
+  hostname     = %q
+  url_template = "dyn.example.com/nic/update?hostname=%%h&myip=%%i"
+  login        = "testuser"
+  password     = "testpass"
+}
+`, testAccProviderConfig, service, hostname)
+}
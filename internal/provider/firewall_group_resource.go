@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -19,8 +21,9 @@ import (
 )
 
 var (
-	_ resource.Resource                = &FirewallGroupResource{}
-	_ resource.ResourceWithImportState = &FirewallGroupResource{}
+	_ resource.Resource                   = &FirewallGroupResource{}
+	_ resource.ResourceWithImportState    = &FirewallGroupResource{}
+	_ resource.ResourceWithValidateConfig = &FirewallGroupResource{}
 )
 
 type FirewallGroupResource struct {
@@ -81,7 +84,9 @@ func (r *FirewallGroupResource) Schema(ctx context.Context, req resource.SchemaR
 			},
 			"members": schema.SetAttribute{
 				Description: "The members of the firewall group. For address groups, this is a set of " +
-					"IP addresses or CIDR ranges. For port groups, this is a set of port numbers or ranges (e.g., '80', '8080-8090').",
+					"IPv4 addresses, CIDR ranges or address ranges (e.g., '10.0.0.1-10.0.0.20'). " +
+					"For IPv6 address groups, this is a set of IPv6 addresses or CIDR ranges. " +
+					"For port groups, this is a set of port numbers or ranges (e.g., '80', '8080-8090').",
 				Required:    true,
 				ElementType: types.StringType,
 			},
@@ -97,6 +102,77 @@ func (r *FirewallGroupResource) Schema(ctx context.Context, req resource.SchemaR
 	}
 }
 
+// ValidateConfig checks that the members of address groups match the group's
+// address family. The controller accepts mismatched members but the firewall
+// never matches them.
+func (r *FirewallGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config FirewallGroupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.GroupType.IsNull() || config.GroupType.IsUnknown() || config.Members.IsNull() || config.Members.IsUnknown() {
+		return
+	}
+
+	for _, elem := range config.Members.Elements() {
+		member, ok := elem.(types.String)
+		if !ok || member.IsNull() || member.IsUnknown() {
+			continue
+		}
+		if detail := validateFirewallGroupMember(config.GroupType.ValueString(), member.ValueString()); detail != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("members"),
+				"Invalid firewall group member",
+				detail,
+			)
+		}
+	}
+}
+
+// validateFirewallGroupMember returns a message describing why member is not
+// valid for a group of groupType, or "" if it is. Port group members are left
+// to the controller.
+func validateFirewallGroupMember(groupType, member string) string {
+	switch groupType {
+	case "address-group":
+		if isIPv4Member(member) {
+			return ""
+		}
+		if isIPv6Address(member) || isIPv6CIDR(member) {
+			return fmt.Sprintf("%q is an IPv6 address. Use a firewall group with group_type 'ipv6-address-group' for IPv6 members.", member)
+		}
+		return fmt.Sprintf("%q is not a valid IPv4 address, CIDR or address range (e.g., '10.0.0.1-10.0.0.20').", member)
+	case "ipv6-address-group":
+		if isIPv6Address(member) || isIPv6CIDR(member) {
+			return ""
+		}
+		if isIPv4Member(member) {
+			return fmt.Sprintf("%q is an IPv4 address. Use a firewall group with group_type 'address-group' for IPv4 members.", member)
+		}
+		return fmt.Sprintf("%q is not a valid IPv6 address or CIDR (e.g., '2001:db8::/64').", member)
+	}
+	return ""
+}
+
+// isIPv4Member reports whether s is an IPv4 address, an IPv4 CIDR or a range
+// of two IPv4 addresses separated by a hyphen.
+func isIPv4Member(s string) bool {
+	isIPv4 := func(v string) bool {
+		ip := net.ParseIP(v)
+		return ip != nil && ip.To4() != nil
+	}
+
+	if ip, _, err := net.ParseCIDR(s); err == nil {
+		return ip.To4() != nil
+	}
+	if start, end, ok := strings.Cut(s, "-"); ok {
+		return isIPv4(start) && isIPv4(end)
+	}
+	return isIPv4(s)
+}
+
 func (r *FirewallGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
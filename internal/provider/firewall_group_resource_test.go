@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -346,6 +347,48 @@ func TestAccFirewallGroupResource_privateNetworks(t *testing.T) {
 	})
 }
 
+func TestAccFirewallGroupResource_ipv6AddressGroup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFirewallGroupResourceConfig_ipv6AddressGroup("tf-acc-test-ipv6-group", []string{"2001:db8::1", "2001:db8:10::/64"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_firewall_group.test", "group_type", "ipv6-address-group"),
+					resource.TestCheckResourceAttr("unifi_firewall_group.test", "members.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "unifi_firewall_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccFirewallGroupResource_memberValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccFirewallGroupResourceConfig_ipv6AddressGroup("tf-acc-test-ipv6-invalid", []string{"10.0.0.1"}),
+				ExpectError: regexp.MustCompile(`is an IPv4 address`),
+			},
+			{
+				Config:      testAccFirewallGroupResourceConfig_ipv6AddressGroup("tf-acc-test-ipv6-invalid", []string{"2001:db8::/129"}),
+				ExpectError: regexp.MustCompile(`is not a valid IPv6 address or CIDR`),
+			},
+			{
+				Config:      testAccFirewallGroupResourceConfig_addressGroup("tf-acc-test-addr-invalid", []string{"2001:db8::1"}),
+				ExpectError: regexp.MustCompile(`is an IPv6 address`),
+			},
+		},
+	})
+}
+
 func testAccFirewallGroupResourceConfig_addressGroup(name string, members []string) string {
 	return fmt.Sprintf(`
 %s
@@ -369,3 +412,15 @@ resource "unifi_firewall_group" "test" {
 }
 `, testAccProviderConfig, name, formatStringListForHCL(members))
 }
+
+func testAccFirewallGroupResourceConfig_ipv6AddressGroup(name string, members []string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_firewall_group" "test" {
+  name       = %q
+  group_type = "ipv6-address-group"
+  members    = [%s]
+}
+`, testAccProviderConfig, name, formatStringListForHCL(members))
+}
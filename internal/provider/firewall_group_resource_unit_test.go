@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateFirewallGroupMember(t *testing.T) {
+	cases := []struct {
+		groupType string
+		member    string
+		wantErr   string
+	}{
+		{groupType: "address-group", member: "10.0.0.1"},
+		{groupType: "address-group", member: "10.0.0.0/24"},
+		{groupType: "address-group", member: "10.0.0.1-10.0.0.20"},
+		{groupType: "address-group", member: "2001:db8::1", wantErr: "is an IPv6 address"},
+		{groupType: "address-group", member: "2001:db8::/32", wantErr: "is an IPv6 address"},
+		{groupType: "address-group", member: "10.0.0.1-2001:db8::1", wantErr: "is not a valid IPv4"},
+		{groupType: "address-group", member: "10.0.0.256", wantErr: "is not a valid IPv4"},
+		{groupType: "ipv6-address-group", member: "2001:db8::1"},
+		{groupType: "ipv6-address-group", member: "2001:db8:10::/64"},
+		{groupType: "ipv6-address-group", member: "10.0.0.1", wantErr: "is an IPv4 address"},
+		{groupType: "ipv6-address-group", member: "10.0.0.0/8", wantErr: "is an IPv4 address"},
+		{groupType: "ipv6-address-group", member: "2001:db8::/129", wantErr: "is not a valid IPv6"},
+		{groupType: "port-group", member: "8080-8090"},
+		{groupType: "port-group", member: "2001:db8::1"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.groupType+"/"+tc.member, func(t *testing.T) {
+			got := validateFirewallGroupMember(tc.groupType, tc.member)
+			if tc.wantErr == "" {
+				if got != "" {
+					t.Fatalf("validateFirewallGroupMember(%q, %q) = %q, want no error", tc.groupType, tc.member, got)
+				}
+				return
+			}
+			if !strings.Contains(got, tc.wantErr) {
+				t.Fatalf("validateFirewallGroupMember(%q, %q) = %q, want %q", tc.groupType, tc.member, got, tc.wantErr)
+			}
+		})
+	}
+}
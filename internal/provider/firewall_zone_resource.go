@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -27,11 +28,12 @@ type FirewallZoneResource struct {
 }
 
 type FirewallZoneResourceModel struct {
-	ID         types.String   `tfsdk:"id"`
-	Name       types.String   `tfsdk:"name"`
-	ZoneKey    types.String   `tfsdk:"zone_key"`
-	NetworkIDs types.Set      `tfsdk:"network_ids"`
-	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	ZoneKey            types.String   `tfsdk:"zone_key"`
+	NetworkIDs         types.Set      `tfsdk:"network_ids"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewFirewallZoneResource() resource.Resource {
@@ -82,6 +84,12 @@ func (r *FirewallZoneResource) Schema(ctx context.Context, req resource.SchemaRe
 					setplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether the firewall zone is protected from deletion. While true, destroying or replacing the zone fails; set to false and apply before destroying. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -221,6 +229,10 @@ func (r *FirewallZoneResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	if checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "firewall zone", state.Name.ValueString()) {
+		return
+	}
+
 	deleteTimeout, diags := state.Timeouts.Delete(ctx, 10*time.Minute)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -285,6 +297,7 @@ func (r *FirewallZoneResource) sdkToState(ctx context.Context, zone *unifi.Firew
 
 	state.ID = types.StringValue(zone.ID)
 	state.Name = types.StringValue(zone.Name)
+	state.DeletionProtection = deletionProtectionDefault(state.DeletionProtection, false)
 
 	if zone.ZoneKey != nil && *zone.ZoneKey != "" {
 		state.ZoneKey = types.StringValue(*zone.ZoneKey)
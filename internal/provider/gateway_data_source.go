@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &GatewayDataSource{}
+
+type GatewayDataSource struct {
+	client *AutoLoginClient
+}
+
+type GatewayDataSourceModel struct {
+	HasGateway types.Bool   `tfsdk:"has_gateway"`
+	MAC        types.String `tfsdk:"mac"`
+	Name       types.String `tfsdk:"name"`
+	Model      types.String `tfsdk:"model"`
+	Type       types.String `tfsdk:"type"`
+}
+
+// isGatewayDevice reports whether a device type routes the site: Security
+// Gateways (ugw), Dream Machines and Cloud Gateways (udm) and Next-Generation
+// Gateways (uxg).
+func isGatewayDevice(deviceType string) bool {
+	return deviceType == "ugw" || deviceType == "udm" || deviceType == "uxg"
+}
+
+func NewGatewayDataSource() datasource.DataSource {
+	return &GatewayDataSource{}
+}
+
+func (d *GatewayDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway"
+}
+
+func (d *GatewayDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports whether the site has a UniFi gateway. Sites where a third-party gateway routes the network " +
+			"have none, and should use unifi_network with purpose 'vlan-only'.",
+		Attributes: map[string]schema.Attribute{
+			"has_gateway": schema.BoolAttribute{
+				Description: "Whether a UniFi gateway (Security Gateway, Dream Machine, Cloud Gateway or Next-Generation Gateway) is adopted on the site.",
+				Computed:    true,
+			},
+			"mac": schema.StringAttribute{
+				Description: "The MAC address of the gateway. Null when the site has no UniFi gateway.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the gateway. Null when the site has no UniFi gateway.",
+				Computed:    true,
+			},
+			"model": schema.StringAttribute{
+				Description: "The model of the gateway. Null when the site has no UniFi gateway.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The device type of the gateway: 'ugw', 'udm' or 'uxg'. Null when the site has no UniFi gateway.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *GatewayDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *GatewayDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	devices, err := d.client.ListDevices(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "list", "devices")
+		return
+	}
+
+	state := GatewayDataSourceModel{
+		HasGateway: types.BoolValue(false),
+		MAC:        types.StringNull(),
+		Name:       types.StringNull(),
+		Model:      types.StringNull(),
+		Type:       types.StringNull(),
+	}
+	for i := range devices.NetworkDevices {
+		device := &devices.NetworkDevices[i]
+		if !isGatewayDevice(device.Type) {
+			continue
+		}
+		state.HasGateway = types.BoolValue(true)
+		state.MAC = types.StringValue(device.MAC)
+		state.Name = stringValueOrNull(device.Name)
+		state.Model = stringValueOrNull(device.Model)
+		state.Type = types.StringValue(device.Type)
+		break
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
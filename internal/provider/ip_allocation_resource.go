@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+var (
+	_ resource.Resource                = &IPAllocationResource{}
+	_ resource.ResourceWithImportState = &IPAllocationResource{}
+)
+
+type IPAllocationResource struct {
+	client *AutoLoginClient
+}
+
+type IPAllocationResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	NetworkID  types.String   `tfsdk:"network_id"`
+	ExcludeIPs types.Set      `tfsdk:"exclude_ips"`
+	IPAddress  types.String   `tfsdk:"ip_address"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewIPAllocationResource() resource.Resource {
+	return &IPAllocationResource{}
+}
+
+func (r *IPAllocationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_allocation"
+}
+
+func (r *IPAllocationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Allocates the next free IP address in a network's subnet, for use as a fixed IP. " +
+			"The gateway, the DHCP range, fixed IPs already reserved for clients and addresses held by other " +
+			"unifi_ip_allocation resources are skipped. The address is picked once on create and kept until the resource " +
+			"is replaced. It is recorded on the controller as a firewall address group named " +
+			"'tf-allocation:ip:<network_id>:<ip_address>', which is deleted with the resource.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+			}),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the allocation, in the form 'network_id:ip_address'.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				Description: "The ID of the network to allocate an address in. Changing this allocates a new address.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"exclude_ips": schema.SetAttribute{
+				Description: "Additional addresses that must not be allocated, such as addresses used by devices " +
+					"the controller does not know about. Changing this allocates a new address.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(IPv4Address()),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Description: "The allocated IP address.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IPAllocationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IPAllocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan IPAllocationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var excluded []string
+	if !plan.ExcludeIPs.IsNull() {
+		resp.Diagnostics.Append(plan.ExcludeIPs.ElementsAs(ctx, &excluded, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	networkID := plan.NetworkID.ValueString()
+	network, err := r.client.GetNetwork(ctx, networkID)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "network")
+		return
+	}
+
+	users, err := r.client.ListUsers(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "list", "clients")
+		return
+	}
+
+	recorded, err := r.client.listAllocations(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "list", "allocations")
+		return
+	}
+
+	ip, err := r.allocate(network, users, excluded, recorded)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("network_id"), "Unable to allocate IP address", err.Error())
+		return
+	}
+
+	key := ipAllocationKey(networkID, ip.String())
+	if err := r.client.recordAllocation(ctx, key, "address-group", ip.String()); err != nil {
+		r.client.releaseAllocation(key)
+		handleSDKError(&resp.Diagnostics, err, "record", "IP allocation")
+		return
+	}
+
+	plan.ID = types.StringValue(networkID + ":" + ip.String())
+	plan.IPAddress = types.StringValue(ip.String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// allocate picks the lowest free host address in the network's subnet that
+// is not recorded on the controller, and claims it for this process.
+func (r *IPAllocationResource) allocate(network *unifi.Network, users []unifi.User, excluded []string, recorded map[string]string) (net.IP, error) {
+	gateway, subnet, err := parseNetworkSubnet(network.IPSubnet)
+	if err != nil {
+		return nil, fmt.Errorf("network %q has no usable IPv4 subnet (%q): %s", network.Name, network.IPSubnet, err)
+	}
+	addressing := networkAddressingFromSDK(network)
+
+	taken := make(map[string]bool, len(excluded)+len(users)+1)
+	taken[gateway.String()] = true
+	for _, ip := range excluded {
+		taken[ip] = true
+	}
+	for _, u := range users {
+		if derefBool(u.UseFixedIP) && u.FixedIP != "" {
+			taken[u.FixedIP] = true
+		}
+	}
+
+	ip := nextFreeIP(subnet, func(ip net.IP) bool {
+		key := ipAllocationKey(network.ID, ip.String())
+		if _, ok := recorded[key]; ok {
+			return true
+		}
+		return taken[ip.String()] ||
+			inDHCPRange(ip, addressing) ||
+			!r.client.claimAllocation(key)
+	})
+	if ip == nil {
+		return nil, fmt.Errorf("every address in subnet %s of network %q outside the DHCP range is already in use. "+
+			"Shrink the DHCP range or release a fixed IP.", subnet, network.Name)
+	}
+	return ip, nil
+}
+
+// Read keeps the allocated address as long as the network exists.
+func (r *IPAllocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IPAllocationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	if _, err := r.client.GetNetwork(ctx, state.NetworkID.ValueString()); err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		handleSDKError(&resp.Diagnostics, err, "read", "network")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only changes timeouts, since every other attribute forces
+// replacement.
+func (r *IPAllocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan IPAllocationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *IPAllocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IPAllocationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := ipAllocationKey(state.NetworkID.ValueString(), state.IPAddress.ValueString())
+	if err := r.client.deleteAllocation(ctx, key); err != nil {
+		handleSDKError(&resp.Diagnostics, err, "delete", "IP allocation")
+		return
+	}
+	r.client.releaseAllocation(key)
+}
+
+func (r *IPAllocationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 || parts[0] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected format 'network_id:ip_address', got '%s'", req.ID),
+		)
+		return
+	}
+
+	if ip := net.ParseIP(parts[1]); ip == nil || ip.To4() == nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("ip_address must be an IPv4 address, got '%s'", parts[1]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip_address"), parts[1])...)
+}
+
+func ipAllocationKey(networkID, ip string) string {
+	return "ip:" + networkID + ":" + ip
+}
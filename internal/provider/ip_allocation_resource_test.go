@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIPAllocationResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAllocationResourceConfig(`["10.215.0.51"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_ip_allocation.first", "ip_address", "10.215.0.52"),
+					resource.TestCheckResourceAttr("unifi_ip_allocation.second", "ip_address", "10.215.0.53"),
+					resource.TestCheckResourceAttrPair("unifi_ip_allocation.first", "network_id", "unifi_network.test", "id"),
+				),
+			},
+			{
+				// A refresh must keep the allocated addresses.
+				Config:   testAccIPAllocationResourceConfig(`["10.215.0.51"]`),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "unifi_ip_allocation.first",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"exclude_ips"},
+			},
+		},
+	})
+}
+
+func TestAccIPAllocationResource_exhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIPAllocationResourceConfig(`[for i in range(51, 63) : "10.215.0.${i}"]`),
+				ExpectError: regexp.MustCompile(`Unable to allocate IP address`),
+			},
+		},
+	})
+}
+
+func TestAccIPAllocationResource_earlierApply(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAllocationResourceConfig_earlierApply(false),
+				Check:  resource.TestCheckResourceAttr("unifi_ip_allocation.first", "ip_address", "10.220.0.51"),
+			},
+			// The first address is still unused, but it was recorded on the
+			// controller, so an allocation made by a later apply skips it.
+			{
+				Config: testAccIPAllocationResourceConfig_earlierApply(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_ip_allocation.first", "ip_address", "10.220.0.51"),
+					resource.TestCheckResourceAttr("unifi_ip_allocation.second[0]", "ip_address", "10.220.0.52"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIPAllocationResourceConfig(excludeIPs string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name         = "tf-acc-test-ip-allocation"
+  purpose      = "corporate"
+  vlan_id      = 3973
+  subnet       = "10.215.0.1/26"
+  dhcp_enabled = true
+  dhcp_start   = "10.215.0.2"
+  dhcp_stop    = "10.215.0.50"
+}
+
+resource "unifi_ip_allocation" "first" {
+  network_id  = unifi_network.test.id
+  exclude_ips = %s
+}
+
+resource "unifi_ip_allocation" "second" {
+  network_id  = unifi_network.test.id
+  exclude_ips = %s
+
+  depends_on = [unifi_ip_allocation.first]
+}
+`, testAccProviderConfig, excludeIPs, excludeIPs)
+}
+
+func testAccIPAllocationResourceConfig_earlierApply(second bool) string {
+	count := 0
+	if second {
+		count = 1
+	}
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name         = "tf-acc-test-ip-allocation-later"
+  purpose      = "corporate"
+  vlan_id      = 3980
+  subnet       = "10.220.0.1/26"
+  dhcp_enabled = true
+  dhcp_start   = "10.220.0.2"
+  dhcp_stop    = "10.220.0.50"
+}
+
+resource "unifi_ip_allocation" "first" {
+  network_id = unifi_network.test.id
+}
+
+resource "unifi_ip_allocation" "second" {
+  count      = %d
+  network_id = unifi_network.test.id
+}
+`, testAccProviderConfig, count)
+}
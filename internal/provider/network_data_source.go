@@ -57,10 +57,12 @@ type NetworkDataSourceModel struct {
 
 	// DHCP Additional Options
 	DHCPRelayEnabled      types.Bool   `tfsdk:"dhcp_relay_enabled"`
+	DHCPRelayServers      types.List   `tfsdk:"dhcp_relay_servers"`
 	DHCPTimeOffsetEnabled types.Bool   `tfsdk:"dhcp_time_offset_enabled"`
 	DHCPUnifiController   types.String `tfsdk:"dhcp_unifi_controller"`
 	DHCPWPADUrl           types.String `tfsdk:"dhcp_wpad_url"`
 	DHCPGuardingEnabled   types.Bool   `tfsdk:"dhcp_guarding_enabled"`
+	DHCPOptions           types.List   `tfsdk:"dhcp_options"`
 
 	// Multicast
 	DomainName        types.String `tfsdk:"domain_name"`
@@ -79,7 +81,8 @@ type NetworkDataSourceModel struct {
 	FirewallZoneID types.String `tfsdk:"firewall_zone_id"`
 
 	// IPv6
-	IPv6 types.Object `tfsdk:"ipv6"`
+	IPv6    types.Object `tfsdk:"ipv6"`
+	WANIPv6 types.Object `tfsdk:"wan_ipv6"`
 }
 
 func NewNetworkDataSource() datasource.DataSource {
@@ -203,6 +206,11 @@ func (d *NetworkDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "Whether DHCP relay is enabled.",
 				Computed:    true,
 			},
+			"dhcp_relay_servers": schema.ListAttribute{
+				Description: "DHCP servers that requests are relayed to.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"dhcp_time_offset_enabled": schema.BoolAttribute{
 				Description: "Whether time offset is provided via DHCP (Option 2).",
 				Computed:    true,
@@ -219,6 +227,26 @@ func (d *NetworkDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "Whether DHCP guarding is enabled.",
 				Computed:    true,
 			},
+			"dhcp_options": schema.ListNestedAttribute{
+				Description: "Custom DHCP options handed out on the network.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.Int64Attribute{
+							Description: "The DHCP option code.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "How value is encoded.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "The option value.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 
 			// Multicast
 			"domain_name": schema.StringAttribute{
@@ -356,6 +384,47 @@ func (d *NetworkDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 						Description: "Whether SLAAC is allowed alongside DHCPv6.",
 						Computed:    true,
 					},
+					"ra_dns_servers": schema.ListAttribute{
+						Description: "DNS servers announced in Router Advertisements (RDNSS).",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"ra_dns_search_domains": schema.ListAttribute{
+						Description: "DNS search domains announced in Router Advertisements (DNSSL).",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			"wan_ipv6": schema.SingleNestedAttribute{
+				Description: "IPv6 on a WAN network, as managed by unifi_wan. Null for other networks and when IPv6 is disabled on the WAN.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"connection_type": schema.StringAttribute{
+						Description: "How the WAN obtains IPv6 (dhcpv6, static).",
+						Computed:    true,
+					},
+					"pd_size": schema.Int64Attribute{
+						Description: "The prefix length requested through DHCPv6 prefix delegation.",
+						Computed:    true,
+					},
+					"ip_address": schema.StringAttribute{
+						Description: "The static IPv6 address of the WAN.",
+						Computed:    true,
+					},
+					"prefix_length": schema.Int64Attribute{
+						Description: "The prefix length of the static IPv6 address.",
+						Computed:    true,
+					},
+					"gateway": schema.StringAttribute{
+						Description: "The static IPv6 gateway.",
+						Computed:    true,
+					},
+					"dns_servers": schema.ListAttribute{
+						Description: "IPv6 DNS servers used instead of those provided by the ISP.",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
 				},
 			},
 		},
@@ -544,6 +613,13 @@ func (d *NetworkDataSource) sdkToState(ctx context.Context, network *unifi.Netwo
 	} else {
 		state.DHCPRelayEnabled = types.BoolNull()
 	}
+	if len(network.DHCPRelayServers) > 0 {
+		relayList, relayDiags := types.ListValueFrom(ctx, types.StringType, network.DHCPRelayServers)
+		diags.Append(relayDiags...)
+		state.DHCPRelayServers = relayList
+	} else {
+		state.DHCPRelayServers = types.ListNull(types.StringType)
+	}
 	if network.DHCPDTimeOffsetEnabled != nil {
 		state.DHCPTimeOffsetEnabled = types.BoolValue(*network.DHCPDTimeOffsetEnabled)
 	} else {
@@ -556,6 +632,9 @@ func (d *NetworkDataSource) sdkToState(ctx context.Context, network *unifi.Netwo
 	} else {
 		state.DHCPGuardingEnabled = types.BoolNull()
 	}
+	dhcpOptions, optionDiags := dhcpOptionsToList(network)
+	diags.Append(optionDiags...)
+	state.DHCPOptions = dhcpOptions
 
 	// Multicast
 	state.DomainName = stringValueOrNull(network.DomainName)
@@ -593,5 +672,12 @@ func (d *NetworkDataSource) sdkToState(ctx context.Context, network *unifi.Netwo
 	}
 	state.IPv6 = ipv6Obj
 
+	state.WANIPv6 = types.ObjectNull(wanIPv6AttrTypes)
+	if network.Purpose == wanPurpose {
+		wanIPv6Obj, wanIPv6Diags := wanIPv6ToObject(network)
+		diags.Append(wanIPv6Diags...)
+		state.WANIPv6 = wanIPv6Obj
+	}
+
 	return diags
 }
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// dhcpOptionTypes are the value encodings the controller accepts for custom
+// DHCP options.
+var dhcpOptionTypes = []string{"text", "ipv4", "uint8", "uint16", "uint32", "boolean", "hex"}
+
+// dhcpOptionReservedCodes maps option codes that unifi_network already sets
+// through a dedicated attribute, or that the DHCP server manages itself, to
+// what owns them. A custom option with one of these codes would fight the
+// dedicated attribute on every apply.
+var dhcpOptionReservedCodes = map[int64]string{
+	1:   "subnet",
+	2:   "dhcp_time_offset_enabled",
+	3:   "dhcp_gateway",
+	6:   "dhcp_dns",
+	15:  "domain_name",
+	42:  "dhcp_ntp",
+	43:  "dhcp_unifi_controller",
+	50:  "the DHCP server (requested address)",
+	51:  "dhcp_lease",
+	53:  "the DHCP server (message type)",
+	54:  "the DHCP server (server identifier)",
+	55:  "the DHCP server (parameter request list)",
+	66:  "dhcp_boot_server",
+	67:  "dhcp_boot_filename",
+	82:  "the DHCP relay agent",
+	252: "dhcp_wpad_url",
+}
+
+type DHCPOptionModel struct {
+	Code  types.Int64  `tfsdk:"code"`
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+var dhcpOptionAttrTypes = map[string]attr.Type{
+	"code":  types.Int64Type,
+	"type":  types.StringType,
+	"value": types.StringType,
+}
+
+// validateDHCPOptions rejects custom options whose code is reserved or
+// repeated, and values that do not match their declared type. Entries with
+// unknown fields are skipped.
+func validateDHCPOptions(options []DHCPOptionModel) []networkConflict {
+	var conflicts []networkConflict
+
+	seen := make(map[int64]bool, len(options))
+	for _, o := range options {
+		if o.Code.IsUnknown() || o.Code.IsNull() {
+			continue
+		}
+		code := o.Code.ValueInt64()
+
+		if owner, ok := dhcpOptionReservedCodes[code]; ok {
+			conflicts = append(conflicts, networkConflict{
+				Attribute: "dhcp_options",
+				Summary:   "Reserved DHCP option code",
+				Detail:    fmt.Sprintf("DHCP option %d is managed by %s and cannot be set in dhcp_options.", code, owner),
+			})
+			continue
+		}
+		if seen[code] {
+			conflicts = append(conflicts, networkConflict{
+				Attribute: "dhcp_options",
+				Summary:   "Duplicate DHCP option code",
+				Detail:    fmt.Sprintf("DHCP option %d is set more than once in dhcp_options. Merge the entries into one.", code),
+			})
+			continue
+		}
+		seen[code] = true
+
+		if o.Type.IsUnknown() || o.Value.IsUnknown() {
+			continue
+		}
+		if err := validateDHCPOptionValue(o.Type.ValueString(), o.Value.ValueString()); err != nil {
+			conflicts = append(conflicts, networkConflict{
+				Attribute: "dhcp_options",
+				Summary:   "Invalid DHCP option value",
+				Detail:    fmt.Sprintf("DHCP option %d: %s.", code, err),
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// validateDHCPOptionValue checks that value is a valid encoding for the
+// option type. Unsupported types are reported by the schema validator.
+func validateDHCPOptionValue(optionType, value string) error {
+	switch optionType {
+	case "text":
+		if value == "" {
+			return fmt.Errorf("a text value must not be empty")
+		}
+	case "ipv4":
+		for _, ip := range strings.Split(value, ",") {
+			if parsed := net.ParseIP(strings.TrimSpace(ip)); parsed == nil || parsed.To4() == nil {
+				return fmt.Errorf("%q is not an IPv4 address or a comma-separated list of them", value)
+			}
+		}
+	case "uint8", "uint16", "uint32":
+		bits, _ := strconv.Atoi(strings.TrimPrefix(optionType, "uint"))
+		if _, err := strconv.ParseUint(value, 10, bits); err != nil {
+			return fmt.Errorf("%q is not an unsigned %d-bit integer", value, bits)
+		}
+	case "boolean":
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%q is not 'true' or 'false'", value)
+		}
+	case "hex":
+		if _, err := hex.DecodeString(strings.ReplaceAll(value, ":", "")); err != nil || value == "" {
+			return fmt.Errorf("%q is not a hex string (e.g. '0a1b2c' or '0a:1b:2c')", value)
+		}
+	}
+	return nil
+}
+
+// dhcpOptionsFromList converts planned dhcp_options into the network's custom
+// option list. A null list clears every custom option.
+func dhcpOptionsFromList(ctx context.Context, list types.List, diags *diag.Diagnostics) []unifi.NetworkDHCPOption {
+	result := []unifi.NetworkDHCPOption{}
+	if list.IsNull() || list.IsUnknown() {
+		return result
+	}
+
+	var options []DHCPOptionModel
+	diags.Append(list.ElementsAs(ctx, &options, false)...)
+
+	for _, o := range options {
+		result = append(result, unifi.NetworkDHCPOption{
+			Code:  intPtr(o.Code.ValueInt64()),
+			Type:  o.Type.ValueString(),
+			Value: o.Value.ValueString(),
+		})
+	}
+	return result
+}
+
+// dhcpOptionsToList converts the network's custom options into dhcp_options,
+// returning null when there are none.
+func dhcpOptionsToList(network *unifi.Network) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elemType := types.ObjectType{AttrTypes: dhcpOptionAttrTypes}
+	if len(network.DHCPDOptions) == 0 {
+		return types.ListNull(elemType), diags
+	}
+
+	values := make([]attr.Value, 0, len(network.DHCPDOptions))
+	for _, o := range network.DHCPDOptions {
+		code := types.Int64Null()
+		if o.Code != nil {
+			code = types.Int64Value(int64(*o.Code))
+		}
+		obj, d := types.ObjectValue(dhcpOptionAttrTypes, map[string]attr.Value{
+			"code":  code,
+			"type":  types.StringValue(o.Type),
+			"value": types.StringValue(o.Value),
+		})
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+
+	list, d := types.ListValue(elemType, values)
+	diags.Append(d...)
+	return list, diags
+}
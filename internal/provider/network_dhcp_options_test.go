@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func testDHCPOption(code int64, optionType, value string) DHCPOptionModel {
+	return DHCPOptionModel{
+		Code:  types.Int64Value(code),
+		Type:  types.StringValue(optionType),
+		Value: types.StringValue(value),
+	}
+}
+
+func TestValidateDHCPOptions(t *testing.T) {
+	cases := []struct {
+		name          string
+		options       []DHCPOptionModel
+		wantConflicts int
+	}{
+		{name: "none"},
+		{name: "valid", options: []DHCPOptionModel{
+			testDHCPOption(150, "ipv4", "10.0.0.5, 10.0.0.6"),
+			testDHCPOption(119, "text", "corp.example.com"),
+			testDHCPOption(26, "uint16", "9000"),
+		}},
+		{name: "reserved dns", options: []DHCPOptionModel{testDHCPOption(6, "ipv4", "1.1.1.1")}, wantConflicts: 1},
+		{name: "reserved protocol", options: []DHCPOptionModel{testDHCPOption(53, "uint8", "1")}, wantConflicts: 1},
+		{name: "duplicate", options: []DHCPOptionModel{
+			testDHCPOption(150, "ipv4", "10.0.0.5"),
+			testDHCPOption(150, "ipv4", "10.0.0.6"),
+		}, wantConflicts: 1},
+		{name: "bad value", options: []DHCPOptionModel{testDHCPOption(26, "uint16", "70000")}, wantConflicts: 1},
+		{name: "unknown code", options: []DHCPOptionModel{
+			{Code: types.Int64Unknown(), Type: types.StringValue("text"), Value: types.StringValue("x")},
+		}},
+		{name: "unknown value", options: []DHCPOptionModel{
+			{Code: types.Int64Value(150), Type: types.StringValue("ipv4"), Value: types.StringUnknown()},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := validateDHCPOptions(tc.options); len(got) != tc.wantConflicts {
+				t.Fatalf("validateDHCPOptions() = %+v, want %d conflicts", got, tc.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestValidateDHCPOptionValue(t *testing.T) {
+	cases := []struct {
+		optionType string
+		value      string
+		wantErr    bool
+	}{
+		{optionType: "text", value: "hello"},
+		{optionType: "text", value: "", wantErr: true},
+		{optionType: "ipv4", value: "10.0.0.1"},
+		{optionType: "ipv4", value: "10.0.0.1,10.0.0.2"},
+		{optionType: "ipv4", value: "fd00::1", wantErr: true},
+		{optionType: "uint8", value: "255"},
+		{optionType: "uint8", value: "256", wantErr: true},
+		{optionType: "uint32", value: "-1", wantErr: true},
+		{optionType: "boolean", value: "true"},
+		{optionType: "boolean", value: "yes", wantErr: true},
+		{optionType: "hex", value: "0a:1b:2c"},
+		{optionType: "hex", value: "0a1b2", wantErr: true},
+		{optionType: "hex", value: "zz", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.optionType+"/"+tc.value, func(t *testing.T) {
+			err := validateDHCPOptionValue(tc.optionType, tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("validateDHCPOptionValue(%q, %q) error = %v, wantErr %v", tc.optionType, tc.value, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestDHCPOptionsRoundTrip(t *testing.T) {
+	obj, d := types.ObjectValue(dhcpOptionAttrTypes, map[string]attr.Value{
+		"code":  types.Int64Value(150),
+		"type":  types.StringValue("ipv4"),
+		"value": types.StringValue("10.0.0.5"),
+	})
+	if d.HasError() {
+		t.Fatalf("building DHCP option: %v", d)
+	}
+	planned, d := types.ListValue(types.ObjectType{AttrTypes: dhcpOptionAttrTypes}, []attr.Value{obj})
+	if d.HasError() {
+		t.Fatalf("building DHCP option list: %v", d)
+	}
+
+	var diags diag.Diagnostics
+	options := dhcpOptionsFromList(context.Background(), planned, &diags)
+	if diags.HasError() {
+		t.Fatalf("dhcpOptionsFromList() = %v", diags)
+	}
+
+	state, d := dhcpOptionsToList(&unifi.Network{DHCPDOptions: options})
+	if d.HasError() {
+		t.Fatalf("dhcpOptionsToList() = %v", d)
+	}
+	if !state.Equal(planned) {
+		t.Fatalf("dhcpOptionsToList() = %v, want %v", state, planned)
+	}
+
+	// A removed block must clear the options on the controller.
+	if cleared := dhcpOptionsFromList(context.Background(), types.ListNull(types.ObjectType{AttrTypes: dhcpOptionAttrTypes}), &diags); cleared == nil || len(cleared) != 0 {
+		t.Fatalf("dhcpOptionsFromList(null) = %#v, want an empty non-nil slice", cleared)
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ipv6ModeAttributes maps each interface_type to the ipv6 attributes that
+// only apply in that mode. The controller keeps them when the mode changes
+// but ignores them, so setting one for another mode is always a mistake.
+var ipv6ModeAttributes = map[string][]string{
+	"static": {"subnet"},
+	"pd":     {"pd_interface", "pd_prefixid", "pd_start", "pd_stop", "pd_auto_prefixid_enabled"},
+}
+
+// validateNetworkIPv6 checks the configured ipv6 attributes of a network
+// against each other: mode-specific attributes must match interface_type and
+// RA DNS options need Router Advertisements. Conflicts are keyed by the
+// nested attribute name. Unknown values are skipped.
+func validateNetworkIPv6(attrs map[string]attr.Value) []networkConflict {
+	var conflicts []networkConflict
+
+	if mode, ok := attrs["interface_type"].(types.String); ok && !mode.IsNull() && !mode.IsUnknown() {
+		modes := make([]string, 0, len(ipv6ModeAttributes))
+		for m := range ipv6ModeAttributes {
+			modes = append(modes, m)
+		}
+		sort.Strings(modes)
+
+		for _, m := range modes {
+			if m == mode.ValueString() {
+				continue
+			}
+			for _, name := range ipv6ModeAttributes[m] {
+				if v, ok := attrs[name]; ok && !v.IsNull() && !v.IsUnknown() {
+					conflicts = append(conflicts, networkConflict{
+						Attribute: name,
+						Summary:   "Invalid IPv6 attribute",
+						Detail:    fmt.Sprintf("ipv6.%s can only be set when ipv6.interface_type is '%s', got '%s'.", name, m, mode.ValueString()),
+					})
+				}
+			}
+		}
+	}
+
+	if ra, ok := attrs["ra_enabled"].(types.Bool); ok && !ra.IsNull() && !ra.IsUnknown() && !ra.ValueBool() {
+		for _, name := range []string{"ra_dns_servers", "ra_dns_search_domains"} {
+			if v, ok := attrs[name]; ok && !v.IsNull() && !v.IsUnknown() {
+				conflicts = append(conflicts, networkConflict{
+					Attribute: name,
+					Summary:   "Router Advertisements not enabled",
+					Detail:    fmt.Sprintf("ipv6.%s is announced in Router Advertisements and requires ipv6.ra_enabled to be true.", name),
+				})
+			}
+		}
+	}
+
+	return conflicts
+}
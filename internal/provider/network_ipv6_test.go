@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIsIPv6(t *testing.T) {
+	cases := []struct {
+		value       string
+		wantAddress bool
+		wantCIDR    bool
+	}{
+		{value: "2001:db8::1", wantAddress: true},
+		{value: "::2", wantAddress: true},
+		{value: "fd00:3922::1/64", wantCIDR: true},
+		{value: "2001:db8::/32", wantCIDR: true},
+		{value: "2001:db8::/129"},
+		{value: "10.0.0.1"},
+		{value: "10.0.0.0/24"},
+		{value: "::ffff:10.0.0.1"},
+		{value: "not-an-address"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			if got := isIPv6Address(tc.value); got != tc.wantAddress {
+				t.Fatalf("isIPv6Address(%q) = %v, want %v", tc.value, got, tc.wantAddress)
+			}
+			if got := isIPv6CIDR(tc.value); got != tc.wantCIDR {
+				t.Fatalf("isIPv6CIDR(%q) = %v, want %v", tc.value, got, tc.wantCIDR)
+			}
+		})
+	}
+}
+
+func TestValidateNetworkIPv6(t *testing.T) {
+	dns := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("2001:4860:4860::8888")})
+
+	cases := []struct {
+		name  string
+		attrs map[string]attr.Value
+		want  []string
+	}{
+		{name: "empty", attrs: map[string]attr.Value{}},
+		{name: "static with subnet", attrs: map[string]attr.Value{
+			"interface_type": types.StringValue("static"),
+			"subnet":         types.StringValue("fd00:10::1/64"),
+		}},
+		{name: "pd with subnet", attrs: map[string]attr.Value{
+			"interface_type": types.StringValue("pd"),
+			"subnet":         types.StringValue("fd00:10::1/64"),
+			"pd_interface":   types.StringValue("wan"),
+		}, want: []string{"subnet"}},
+		{name: "static with pd attributes", attrs: map[string]attr.Value{
+			"interface_type": types.StringValue("static"),
+			"pd_interface":   types.StringValue("wan2"),
+			"pd_start":       types.StringValue("::2"),
+		}, want: []string{"pd_interface", "pd_start"}},
+		{name: "none with subnet", attrs: map[string]attr.Value{
+			"interface_type": types.StringValue("none"),
+			"subnet":         types.StringValue("fd00:10::1/64"),
+		}, want: []string{"subnet"}},
+		{name: "unknown mode", attrs: map[string]attr.Value{
+			"interface_type": types.StringUnknown(),
+			"subnet":         types.StringValue("fd00:10::1/64"),
+		}},
+		{name: "ra dns with ra", attrs: map[string]attr.Value{
+			"ra_enabled":     types.BoolValue(true),
+			"ra_dns_servers": dns,
+		}},
+		{name: "ra dns without ra", attrs: map[string]attr.Value{
+			"ra_enabled":            types.BoolValue(false),
+			"ra_dns_servers":        dns,
+			"ra_dns_search_domains": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("corp.example.com")}),
+		}, want: []string{"ra_dns_servers", "ra_dns_search_domains"}},
+		{name: "ra dns with unknown ra", attrs: map[string]attr.Value{
+			"ra_enabled":     types.BoolUnknown(),
+			"ra_dns_servers": dns,
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := validateNetworkIPv6(tc.attrs)
+			if len(got) != len(tc.want) {
+				t.Fatalf("validateNetworkIPv6() = %+v, want conflicts on %v", got, tc.want)
+			}
+			for i, c := range got {
+				if c.Attribute != tc.want[i] {
+					t.Fatalf("conflict %d on %q, want %q", i, c.Attribute, tc.want[i])
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -32,8 +33,10 @@ const (
 )
 
 var (
-	_ resource.Resource                = &NetworkResource{}
-	_ resource.ResourceWithImportState = &NetworkResource{}
+	_ resource.Resource                   = &NetworkResource{}
+	_ resource.ResourceWithImportState    = &NetworkResource{}
+	_ resource.ResourceWithModifyPlan     = &NetworkResource{}
+	_ resource.ResourceWithValidateConfig = &NetworkResource{}
 )
 
 var ipv6AttrTypes = map[string]attr.Type{
@@ -58,6 +61,8 @@ var ipv6AttrTypes = map[string]attr.Type{
 	"dhcpv6_dns_auto":           types.BoolType,
 	"dhcpv6_dns":                types.ListType{ElemType: types.StringType},
 	"dhcpv6_allow_slaac":        types.BoolType,
+	"ra_dns_servers":            types.ListType{ElemType: types.StringType},
+	"ra_dns_search_domains":     types.ListType{ElemType: types.StringType},
 }
 
 type NetworkResource struct {
@@ -72,6 +77,8 @@ type NetworkResourceModel struct {
 	Enabled  types.Bool     `tfsdk:"enabled"`
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
 	// VLAN
 	VlanID types.Int64  `tfsdk:"vlan_id"`
 	Subnet types.String `tfsdk:"subnet"`
@@ -102,10 +109,12 @@ type NetworkResourceModel struct {
 
 	// DHCP Additional Options
 	DHCPRelayEnabled      types.Bool   `tfsdk:"dhcp_relay_enabled"`
+	DHCPRelayServers      types.List   `tfsdk:"dhcp_relay_servers"`
 	DHCPTimeOffsetEnabled types.Bool   `tfsdk:"dhcp_time_offset_enabled"`
 	DHCPUnifiController   types.String `tfsdk:"dhcp_unifi_controller"`
 	DHCPWPADUrl           types.String `tfsdk:"dhcp_wpad_url"`
 	DHCPGuardingEnabled   types.Bool   `tfsdk:"dhcp_guarding_enabled"`
+	DHCPOptions           types.List   `tfsdk:"dhcp_options"`
 
 	// Multicast
 	DomainName        types.String `tfsdk:"domain_name"`
@@ -158,8 +167,10 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Required:    true,
 			},
 			"purpose": schema.StringAttribute{
-				Description: "The purpose of the network. Valid values: 'corporate', 'guest', 'wan', 'vlan-only'.",
-				Required:    true,
+				Description: "The purpose of the network. Valid values: 'corporate', 'guest', 'wan', 'vlan-only'. " +
+					"Use 'vlan-only' for a VLAN routed by a third-party gateway: gateway-only attributes such as subnet, DHCP, NAT and firewall_zone_id are then rejected, " +
+					"and dhcp_enabled, nat_enabled, internet_access_enabled, mdns_enabled and upnp_lan_enabled are reported as false.",
+				Required: true,
 				Validators: []validator.String{
 					stringvalidator.OneOf("corporate", "guest", "wan", "vlan-only"),
 				},
@@ -173,10 +184,18 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether the network is protected from deletion. While true, destroying or replacing the network fails; set to false and apply before destroying. Defaults to true for the site's management network (the untagged corporate LAN) and false otherwise.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 
 			// VLAN
 			"vlan_id": schema.Int64Attribute{
-				Description: "The VLAN ID for this network. Must be between 1 and 4095.",
+				Description: "The VLAN ID for this network. Must be between 1 and 4095 and not used by any other network on the site.",
 				Optional:    true,
 				Computed:    true,
 				Validators: []validator.Int64{
@@ -187,7 +206,7 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"subnet": schema.StringAttribute{
-				Description: "The subnet in CIDR notation (e.g., '10.0.100.0/24').",
+				Description: "The subnet in CIDR notation (e.g., '10.0.100.0/24'). Must not overlap the subnet of any other network on the site.",
 				Optional:    true,
 			},
 
@@ -199,17 +218,23 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Default:     booldefault.StaticBool(true),
 			},
 			"dhcp_start": schema.StringAttribute{
-				Description: "The start of the DHCP IP range.",
+				Description: "The start of the DHCP IP range. Must be inside subnet.",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					IPv4Address(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"dhcp_stop": schema.StringAttribute{
-				Description: "The end of the DHCP IP range.",
+				Description: "The end of the DHCP IP range. Must be inside subnet and not before dhcp_start.",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					IPv4Address(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -298,6 +323,17 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Description: "Whether DHCP relay is enabled. When enabled, DHCP requests are forwarded to another DHCP server instead of using the built-in server.",
 				Optional:    true,
 			},
+			"dhcp_relay_servers": schema.ListAttribute{
+				Description: "DHCP servers to relay requests to (maximum 5). Requires dhcp_relay_enabled to be true.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 5),
+					listvalidator.ValueStringsAre(
+						IPv4Address(),
+					),
+				},
+			},
 			"dhcp_time_offset_enabled": schema.BoolAttribute{
 				Description: "Whether to provide time offset via DHCP (Option 2).",
 				Optional:    true,
@@ -317,6 +353,32 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Description: "Whether DHCP guarding is enabled. Protects against rogue DHCP servers on the network.",
 				Optional:    true,
 			},
+			"dhcp_options": schema.ListNestedAttribute{
+				Description: "Custom DHCP options to hand out. Codes already covered by a dedicated attribute (2, 3, 6, 15, 42, 43, 51, 66, 67, 252) or managed by the DHCP server itself are rejected.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.Int64Attribute{
+							Description: "The DHCP option code (1-254).",
+							Required:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 254),
+							},
+						},
+						"type": schema.StringAttribute{
+							Description: "How value is encoded. Valid values: 'text', 'ipv4', 'uint8', 'uint16', 'uint32', 'boolean', 'hex'. 'ipv4' accepts a comma-separated list of addresses.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(dhcpOptionTypes...),
+							},
+						},
+						"value": schema.StringAttribute{
+							Description: "The option value.",
+							Required:    true,
+						},
+					},
+				},
+			},
 
 			// Multicast
 			"domain_name": schema.StringAttribute{
@@ -356,9 +418,10 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Default:     booldefault.StaticBool(true),
 			},
 			"mdns_enabled": schema.BoolAttribute{
-				Description: "Whether mDNS (Bonjour/Avahi) is enabled for this network. Computed by the controller when not set.",
-				Optional:    true,
-				Computed:    true,
+				Description: "Whether mDNS (Bonjour/Avahi) is enabled for this network. Computed by the controller when not set. " +
+					"Only kept by the controller when the network is listed in unifi_setting_mdns.enabled_for_network_ids.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.UseStateForUnknown(),
 				},
@@ -422,6 +485,9 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 						Description: "IPv6 subnet in CIDR notation.",
 						Optional:    true,
 						Computed:    true,
+						Validators: []validator.String{
+							IPv6CIDR(),
+						},
 					},
 					"client_address_assignment": schema.StringAttribute{
 						Description: "IPv6 client address assignment method.",
@@ -429,9 +495,13 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 						Computed:    true,
 					},
 					"pd_interface": schema.StringAttribute{
-						Description: "IPv6 prefix delegation interface.",
-						Optional:    true,
-						Computed:    true,
+						Description: "The WAN whose DHCPv6 delegated prefix this network takes its subnet from. Valid values: 'wan', 'wan2'. " +
+							"Networks can take prefixes from different WANs, each WAN needing a unifi_wan ipv6 block with connection_type 'dhcpv6'.",
+						Optional: true,
+						Computed: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("wan", "wan2"),
+						},
 					},
 					"pd_prefixid": schema.StringAttribute{
 						Description: "IPv6 prefix delegation prefix ID.",
@@ -442,11 +512,17 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 						Description: "IPv6 prefix delegation range start.",
 						Optional:    true,
 						Computed:    true,
+						Validators: []validator.String{
+							IPv6Address(),
+						},
 					},
 					"pd_stop": schema.StringAttribute{
 						Description: "IPv6 prefix delegation range stop.",
 						Optional:    true,
 						Computed:    true,
+						Validators: []validator.String{
+							IPv6Address(),
+						},
 					},
 					"pd_auto_prefixid_enabled": schema.BoolAttribute{
 						Description: "Whether automatic prefix ID assignment is enabled for prefix delegation.",
@@ -485,11 +561,17 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 						Description: "DHCPv6 range start address.",
 						Optional:    true,
 						Computed:    true,
+						Validators: []validator.String{
+							IPv6Address(),
+						},
 					},
 					"dhcpv6_stop": schema.StringAttribute{
 						Description: "DHCPv6 range stop address.",
 						Optional:    true,
 						Computed:    true,
+						Validators: []validator.String{
+							IPv6Address(),
+						},
 					},
 					"dhcpv6_lease_time": schema.Int64Attribute{
 						Description: "DHCPv6 lease time in seconds.",
@@ -508,6 +590,7 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 						ElementType: types.StringType,
 						Validators: []validator.List{
 							listvalidator.SizeAtMost(4),
+							listvalidator.ValueStringsAre(IPv6Address()),
 						},
 					},
 					"dhcpv6_allow_slaac": schema.BoolAttribute{
@@ -515,6 +598,26 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 						Optional:    true,
 						Computed:    true,
 					},
+					"ra_dns_servers": schema.ListAttribute{
+						Description: "DNS servers announced in Router Advertisements (RDNSS, RFC 8106), for clients using SLAAC (maximum 3). Requires ra_enabled.",
+						Optional:    true,
+						Computed:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeBetween(1, 3),
+							listvalidator.ValueStringsAre(IPv6Address()),
+						},
+					},
+					"ra_dns_search_domains": schema.ListAttribute{
+						Description: "DNS search domains announced in Router Advertisements (DNSSL, RFC 8106). Requires ra_enabled.",
+						Optional:    true,
+						Computed:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 253)),
+						},
+					},
 				},
 			},
 		},
@@ -667,6 +770,10 @@ func (r *NetworkResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "network", state.Name.ValueString()) {
+		return
+	}
+
 	deleteTimeout, diags := state.Timeouts.Delete(ctx, 10*time.Minute)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -689,6 +796,255 @@ func (r *NetworkResource) ImportState(ctx context.Context, req resource.ImportSt
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// ValidateConfig checks the network's own addressing: subnet must be an IPv4
+// CIDR and the DHCP range must sit inside it. A vlan-only network must not
+// set gateway-only attributes. Conflicts with other networks need the
+// controller and are checked in ModifyPlan.
+func (r *NetworkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config NetworkResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.DHCPRelayServers.IsNull() && !config.DHCPRelayEnabled.IsUnknown() && !config.DHCPRelayEnabled.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dhcp_relay_servers"),
+			"DHCP relay not enabled",
+			"dhcp_relay_servers requires dhcp_relay_enabled to be true.",
+		)
+	}
+
+	if !config.DHCPOptions.IsNull() && !config.DHCPOptions.IsUnknown() {
+		var options []DHCPOptionModel
+		resp.Diagnostics.Append(config.DHCPOptions.ElementsAs(ctx, &options, false)...)
+		for _, c := range validateDHCPOptions(options) {
+			resp.Diagnostics.AddAttributeError(path.Root(c.Attribute), c.Summary, c.Detail)
+		}
+	}
+
+	if !config.IPv6.IsNull() && !config.IPv6.IsUnknown() {
+		for _, c := range validateNetworkIPv6(config.IPv6.Attributes()) {
+			resp.Diagnostics.AddAttributeError(path.Root("ipv6").AtName(c.Attribute), c.Summary, c.Detail)
+		}
+	}
+
+	for _, c := range validateVLANOnlyNetwork(&config) {
+		resp.Diagnostics.AddAttributeError(path.Root(c.Attribute), c.Summary, c.Detail)
+	}
+
+	if config.Subnet.IsNull() || config.Subnet.IsUnknown() {
+		return
+	}
+
+	_, subnet, err := parseNetworkSubnet(config.Subnet.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("subnet"),
+			"Invalid subnet",
+			fmt.Sprintf("The value %q is not a valid IPv4 subnet in CIDR notation (e.g. '10.0.100.1/24').", config.Subnet.ValueString()),
+		)
+		return
+	}
+
+	var start, stop string
+	if !config.DHCPStart.IsNull() && !config.DHCPStart.IsUnknown() {
+		start = config.DHCPStart.ValueString()
+	}
+	if !config.DHCPStop.IsNull() && !config.DHCPStop.IsUnknown() {
+		stop = config.DHCPStop.ValueString()
+	}
+
+	for _, c := range validateDHCPRange(subnet, start, stop) {
+		resp.Diagnostics.AddAttributeError(path.Root(c.Attribute), c.Summary, c.Detail)
+	}
+}
+
+// ModifyPlan clears the gateway-only attributes of a vlan-only network,
+// resolves the default for deletion_protection, which depends on
+// whether the network is the management network, and then rejects a planned
+// network whose VLAN ID or subnet collides with
+// another network on the controller. Without this check the collision only
+// surfaces as a controller error part-way through apply.
+//
+// A controller network already planned in the same run is judged by its
+// planned values, so one moving off the VLAN or subnet, or being destroyed,
+// does not conflict. A failure to list networks only downgrades the check to
+// a warning; it never blocks the plan on its own.
+func (r *NetworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// A network destroyed in this run no longer conflicts with the
+		// networks planned after it.
+		if r.client != nil && !req.State.Raw.IsNull() {
+			var id types.String
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if !resp.Diagnostics.HasError() && !id.IsNull() {
+				r.client.recordDestroyedNetwork(id.ValueString())
+			}
+		}
+		return
+	}
+
+	var plan, config NetworkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Purpose.ValueString() == networkPurposeVLANOnly {
+		suppressVLANOnlyGateway(&plan)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if plan.DeletionProtection.IsUnknown() && !plan.Purpose.IsUnknown() && !plan.NetworkGroup.IsUnknown() {
+		management := isManagementNetwork(plan.Purpose.ValueString(), plan.NetworkGroup.ValueString(), !config.VlanID.IsNull())
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deletion_protection"), types.BoolValue(management))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if r.client == nil {
+		return
+	}
+
+	candidate := networkAddressingFromModel(&plan)
+	key := plannedNetworkKey(candidate)
+
+	if key != "" {
+		r.client.recordPlannedNetwork(key, candidate)
+	}
+
+	// Only a new or changed vlan_id or subnet is checked, so a conflict that
+	// already exists on the controller never blocks unrelated changes.
+	check := candidate
+	if !req.State.Raw.IsNull() {
+		var state NetworkResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.VlanID.Equal(state.VlanID) {
+			check.VLAN = 0
+		}
+		if plan.Subnet.Equal(state.Subnet) {
+			check.Subnet = nil
+		}
+	}
+	if check.VLAN != 0 || check.Subnet != nil {
+		r.checkNetworkConflicts(ctx, check, resp)
+	}
+
+	r.checkMDNSReflector(ctx, req, &plan, resp)
+}
+
+// networkAddressingFromModel extracts the validated fields from a planned
+// or prior unifi_network. Unknown values are left unset.
+func networkAddressingFromModel(m *NetworkResourceModel) networkAddressing {
+	addr := networkAddressing{
+		Purpose: m.Purpose.ValueString(),
+	}
+	if !m.ID.IsNull() && !m.ID.IsUnknown() {
+		addr.ID = m.ID.ValueString()
+	}
+	if !m.Name.IsNull() && !m.Name.IsUnknown() {
+		addr.Name = m.Name.ValueString()
+	}
+	if !m.VlanID.IsNull() && !m.VlanID.IsUnknown() {
+		addr.VLAN = int(m.VlanID.ValueInt64())
+	}
+	if !m.Subnet.IsNull() && !m.Subnet.IsUnknown() {
+		if _, subnet, err := parseNetworkSubnet(m.Subnet.ValueString()); err == nil {
+			addr.Subnet = subnet
+		}
+	}
+	if m.DHCPEnabled.ValueBool() && !m.DHCPStart.IsUnknown() && !m.DHCPStop.IsUnknown() {
+		addr.DHCPStart = net.ParseIP(m.DHCPStart.ValueString()).To4()
+		addr.DHCPStop = net.ParseIP(m.DHCPStop.ValueString()).To4()
+	}
+	return addr
+}
+
+// checkNetworkConflicts reports VLAN ID and subnet conflicts between a
+// planned network and the other networks on the controller. A controller
+// network whose plan was already recorded in this run is compared by its
+// planned values instead, and one being destroyed is skipped.
+func (r *NetworkResource) checkNetworkConflicts(ctx context.Context, candidate networkAddressing, resp *resource.ModifyPlanResponse) {
+	listCtx, cancel := context.WithTimeout(ctx, modifyPlanLookupTimeout)
+	defer cancel()
+	existing, err := r.client.ListNetworks(listCtx)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to check network for conflicts",
+			fmt.Sprintf("Listing networks on the controller failed, so VLAN ID and subnet conflicts with existing networks were not checked: %s", err),
+		)
+		return
+	}
+
+	others := make([]networkAddressing, 0, len(existing))
+	for i := range existing {
+		other := networkAddressingFromSDK(&existing[i])
+		planned, destroyed, ok := r.client.plannedNetworkByID(other.ID)
+		switch {
+		case destroyed:
+			continue
+		case ok:
+			planned.Name = other.Name
+			others = append(others, planned)
+		default:
+			others = append(others, other)
+		}
+	}
+
+	for _, c := range findNetworkConflicts(candidate, others) {
+		resp.Diagnostics.AddAttributeError(path.Root(c.Attribute), c.Summary, c.Detail)
+	}
+}
+
+// checkMDNSReflector warns when a plan turns mdns_enabled on for a network
+// the site-wide mDNS reflector will not serve. The controller accepts the
+// flag either way, so this is a warning rather than an error. It only runs
+// when mdns_enabled changes to true.
+func (r *NetworkResource) checkMDNSReflector(ctx context.Context, req resource.ModifyPlanRequest, plan *NetworkResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.MDNSEnabled.IsUnknown() || !plan.MDNSEnabled.ValueBool() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var prior types.Bool
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("mdns_enabled"), &prior)...)
+		if resp.Diagnostics.HasError() || prior.ValueBool() {
+			return
+		}
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, modifyPlanLookupTimeout)
+	defer cancel()
+	setting, err := r.client.GetSettingMDNS(lookupCtx)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to check mDNS reflector",
+			fmt.Sprintf("Reading setting/mdns on the controller failed, so mdns_enabled was not checked against the site-wide mDNS reflector: %s", err),
+		)
+		return
+	}
+
+	networkID := ""
+	if !plan.ID.IsNull() && !plan.ID.IsUnknown() {
+		networkID = plan.ID.ValueString()
+	}
+	if gap := mdnsReflectorGap(setting, networkID); gap != "" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("mdns_enabled"),
+			"mDNS will not be reflected for this network",
+			gap+" If unifi_setting_mdns changes in this run, this warning reflects its current value on the controller.",
+		)
+	}
+}
+
 func (r *NetworkResource) planToSDK(ctx context.Context, plan *NetworkResourceModel, diags *diag.Diagnostics) *unifi.Network {
 	network := &unifi.Network{
 		Name:    plan.Name.ValueString(),
@@ -785,6 +1141,13 @@ func (r *NetworkResource) planToSDK(ctx context.Context, plan *NetworkResourceMo
 	if !plan.DHCPRelayEnabled.IsNull() {
 		network.DHCPRelayEnabled = boolPtr(plan.DHCPRelayEnabled.ValueBool())
 	}
+	network.DHCPRelayServers = []string{}
+	if !plan.DHCPRelayServers.IsNull() && !plan.DHCPRelayServers.IsUnknown() {
+		diags.Append(plan.DHCPRelayServers.ElementsAs(ctx, &network.DHCPRelayServers, false)...)
+		if diags.HasError() {
+			return nil
+		}
+	}
 	if !plan.DHCPTimeOffsetEnabled.IsNull() {
 		network.DHCPDTimeOffsetEnabled = boolPtr(plan.DHCPTimeOffsetEnabled.ValueBool())
 	}
@@ -797,6 +1160,10 @@ func (r *NetworkResource) planToSDK(ctx context.Context, plan *NetworkResourceMo
 	if !plan.DHCPGuardingEnabled.IsNull() {
 		network.DHCPGuardingEnabled = boolPtr(plan.DHCPGuardingEnabled.ValueBool())
 	}
+	network.DHCPDOptions = dhcpOptionsFromList(ctx, plan.DHCPOptions, diags)
+	if diags.HasError() {
+		return nil
+	}
 
 	// Multicast
 	if !plan.DomainName.IsNull() {
@@ -835,6 +1202,10 @@ func (r *NetworkResource) planToSDK(ctx context.Context, plan *NetworkResourceMo
 		}
 	}
 
+	if network.Purpose == networkPurposeVLANOnly {
+		vlanOnlyToSDK(network)
+	}
+
 	return network
 }
 
@@ -943,6 +1314,16 @@ func (r *NetworkResource) sdkToState(ctx context.Context, network *unifi.Network
 	} else {
 		state.DHCPRelayEnabled = types.BoolNull()
 	}
+	if len(network.DHCPRelayServers) > 0 {
+		relayList, d := types.ListValueFrom(ctx, types.StringType, network.DHCPRelayServers)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		state.DHCPRelayServers = relayList
+	} else {
+		state.DHCPRelayServers = types.ListNull(types.StringType)
+	}
 	if network.DHCPDTimeOffsetEnabled != nil {
 		state.DHCPTimeOffsetEnabled = types.BoolValue(*network.DHCPDTimeOffsetEnabled)
 	} else {
@@ -955,6 +1336,12 @@ func (r *NetworkResource) sdkToState(ctx context.Context, network *unifi.Network
 	} else {
 		state.DHCPGuardingEnabled = types.BoolNull()
 	}
+	dhcpOptions, d := dhcpOptionsToList(network)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	state.DHCPOptions = dhcpOptions
 
 	// Multicast
 	state.DomainName = stringValueOrNull(network.DomainName)
@@ -976,6 +1363,9 @@ func (r *NetworkResource) sdkToState(ctx context.Context, network *unifi.Network
 	state.NetworkGroup = types.StringValue(network.NetworkGroup)
 	state.FirewallZoneID = stringValueOrNull(network.FirewallZoneID)
 
+	state.DeletionProtection = deletionProtectionDefault(state.DeletionProtection,
+		isManagementNetwork(network.Purpose, network.NetworkGroup, networkAddressingFromSDK(network).VLAN != 0))
+
 	// IPv6
 	ipv6Obj, d := ipv6ToObject(ctx, network)
 	diags.Append(d...)
@@ -984,6 +1374,10 @@ func (r *NetworkResource) sdkToState(ctx context.Context, network *unifi.Network
 	}
 	state.IPv6 = ipv6Obj
 
+	if network.Purpose == networkPurposeVLANOnly {
+		suppressVLANOnlyGateway(state)
+	}
+
 	return diags
 }
 
@@ -1069,6 +1463,12 @@ func ipv6FromObject(ctx context.Context, obj types.Object, network *unifi.Networ
 	if v, ok := attrs["dhcpv6_allow_slaac"].(types.Bool); ok && !v.IsNull() && !v.IsUnknown() {
 		network.DHCPDV6AllowSlaac = boolPtr(v.ValueBool())
 	}
+	if v, ok := attrs["ra_dns_servers"].(types.List); ok && !v.IsNull() && !v.IsUnknown() {
+		diags.Append(v.ElementsAs(ctx, &network.IPV6RaDNS, false)...)
+	}
+	if v, ok := attrs["ra_dns_search_domains"].(types.List); ok && !v.IsNull() && !v.IsUnknown() {
+		diags.Append(v.ElementsAs(ctx, &network.IPV6RaDNSSL, false)...)
+	}
 }
 
 func ipv6ToObject(ctx context.Context, network *unifi.Network) (types.Object, diag.Diagnostics) {
@@ -1122,6 +1522,8 @@ func ipv6ToObject(ctx context.Context, network *unifi.Network) (types.Object, di
 		"dhcpv6_dns_auto":           types.BoolValue(derefBool(network.DHCPDV6DNSAuto)),
 		"dhcpv6_dns":                dnsVal,
 		"dhcpv6_allow_slaac":        types.BoolValue(derefBool(network.DHCPDV6AllowSlaac)),
+		"ra_dns_servers":            stringListOrNull(network.IPV6RaDNS),
+		"ra_dns_search_domains":     stringListOrNull(network.IPV6RaDNSSL),
 	}
 
 	obj, d := types.ObjectValue(ipv6AttrTypes, attrs)
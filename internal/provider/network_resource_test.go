@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
 )
 
 func TestAccNetworkResource_basic(t *testing.T) {
@@ -203,6 +206,10 @@ func TestAccNetworkResource_vlanOnly(t *testing.T) {
 					resource.TestCheckResourceAttr("unifi_network.test", "name", "tf-acc-test-network-vlan-only"),
 					resource.TestCheckResourceAttr("unifi_network.test", "purpose", "vlan-only"),
 					resource.TestCheckResourceAttr("unifi_network.test", "vlan_id", "3907"),
+					resource.TestCheckResourceAttr("unifi_network.test", "dhcp_enabled", "false"),
+					resource.TestCheckResourceAttr("unifi_network.test", "nat_enabled", "false"),
+					resource.TestCheckNoResourceAttr("unifi_network.test", "subnet"),
+					resource.TestCheckNoResourceAttr("unifi_network.test", "dhcp_lease"),
 				),
 			},
 			// ImportState
@@ -410,6 +417,29 @@ resource "unifi_network" "test" {
 `, testAccProviderConfig, name, vlanID)
 }
 
+func TestAccNetworkResource_vlanOnlyGatewayAttributesRejected(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name         = "tf-acc-test-network-vlan-only-invalid"
+  purpose      = "vlan-only"
+  vlan_id      = 3907
+  subnet       = "10.107.0.1/24"
+  dhcp_enabled = true
+}
+`, testAccProviderConfig),
+				ExpectError: regexp.MustCompile(`Gateway attribute on vlan-only network`),
+			},
+		},
+	})
+}
+
 func TestAccNetworkResource_dhcpDnsEnabled(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -578,6 +608,63 @@ func TestAccNetworkResource_dhcpAdvanced(t *testing.T) {
 	})
 }
 
+func TestAccNetworkResource_dhcpRelayAndOptions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkResourceConfig_dhcpOptions("tf-acc-test-network-opts", 3970),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_network.test", "dhcp_options.#", "2"),
+					resource.TestCheckResourceAttr("unifi_network.test", "dhcp_options.0.code", "150"),
+					resource.TestCheckResourceAttr("unifi_network.test", "dhcp_options.0.value", "10.0.0.5,10.0.0.6"),
+					resource.TestCheckResourceAttr("unifi_network.test", "dhcp_options.1.type", "text"),
+				),
+			},
+			{
+				Config: testAccNetworkResourceConfig_dhcpRelay("tf-acc-test-network-opts", 3970),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_network.test", "dhcp_relay_enabled", "true"),
+					resource.TestCheckResourceAttr("unifi_network.test", "dhcp_relay_servers.#", "2"),
+					resource.TestCheckResourceAttr("unifi_network.test", "dhcp_relay_servers.0", "10.1.1.10"),
+					resource.TestCheckNoResourceAttr("unifi_network.test", "dhcp_options"),
+				),
+			},
+			{
+				ResourceName:      "unifi_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNetworkResource_dhcpOptionReserved(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name    = "tf-acc-test-network-reserved"
+  purpose = "corporate"
+  vlan_id = 3971
+
+  dhcp_options = [
+    { code = 6, type = "ipv4", value = "1.1.1.1" },
+  ]
+}
+`, testAccProviderConfig),
+				ExpectError: regexp.MustCompile(`managed by dhcp_dns`),
+			},
+		},
+	})
+}
+
 func TestAccNetworkResource_networkAccess(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -603,12 +690,14 @@ func TestAccNetworkResource_networkAccess(t *testing.T) {
 
 // Note: there is no acceptance test setting mdns_enabled = true. On v9
 // controllers the per-network mdns_enabled field is not authoritative —
-// mDNS opt-in lives in setting/mdns.enabled_for_network_ids, which the SDK
-// does not yet expose. Sending mdns_enabled = true round-trips correctly
+// mDNS opt-in lives in setting/mdns.enabled_for_network_ids (managed by
+// unifi_setting_mdns). Sending mdns_enabled = true round-trips correctly
 // only when the network's _id is already in that list; otherwise the
 // controller returns false and the framework's consistency check fires.
-// Reliable testing requires controller-side setting/mdns plumbing first.
-// The mdns_enabled field is still covered indirectly by
+// A new network's _id is not known until it is created, so the list cannot
+// be prepared in the same test step. The plan-time warning for this case is
+// covered by TestMDNSReflectorGap. The mdns_enabled field is still covered
+// indirectly by
 // TestAccNetworkResource_mdnsUpnpComputedFromController, which verifies
 // the field round-trips when omitted from config.
 
@@ -757,6 +846,43 @@ resource "unifi_network" "test" {
 `, testAccProviderConfig, name, vlanID, vlanID%256, vlanID%256, vlanID%256, vlanID%256)
 }
 
+func testAccNetworkResourceConfig_dhcpOptions(name string, vlanID int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = %d
+  subnet       = "10.%d.0.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "10.%d.0.10"
+  dhcp_stop    = "10.%d.0.254"
+
+  dhcp_options = [
+    { code = 150, type = "ipv4", value = "10.0.0.5,10.0.0.6" },
+    { code = 119, type = "text", value = "corp.example.com" },
+  ]
+}
+`, testAccProviderConfig, name, vlanID, vlanID%256, vlanID%256, vlanID%256)
+}
+
+func testAccNetworkResourceConfig_dhcpRelay(name string, vlanID int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name               = %q
+  purpose            = "corporate"
+  vlan_id            = %d
+  subnet             = "10.%d.0.1/24"
+  dhcp_enabled       = false
+  dhcp_relay_enabled = true
+  dhcp_relay_servers = ["10.1.1.10", "10.1.1.11"]
+}
+`, testAccProviderConfig, name, vlanID, vlanID%256)
+}
+
 func testAccNetworkResourceConfig_networkAccess(name string, vlanID int) string {
 	return fmt.Sprintf(`
 %s
@@ -1161,3 +1287,277 @@ resource "unifi_network" "test" {
 }
 `, testAccProviderConfig, name, vlanID, vlanID%256, vlanID%256, vlanID%256)
 }
+
+func TestAccNetworkResource_ipv6RADNS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkResourceConfig_ipv6RADNS("tf-acc-test-network-ipv6radns", 3928, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_network.test", "ipv6.ra_enabled", "true"),
+					resource.TestCheckResourceAttr("unifi_network.test", "ipv6.ra_dns_servers.#", "2"),
+					resource.TestCheckResourceAttr("unifi_network.test", "ipv6.ra_dns_servers.0", "2606:4700:4700::1111"),
+					resource.TestCheckResourceAttr("unifi_network.test", "ipv6.ra_dns_search_domains.#", "1"),
+					resource.TestCheckResourceAttr("unifi_network.test", "ipv6.ra_dns_search_domains.0", "corp.example.com"),
+				),
+			},
+			{
+				ResourceName:      "unifi_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNetworkResource_ipv6ValidationRejected(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNetworkResourceConfig_ipv6RADNS("tf-acc-test-network-ipv6radns", 3928, false),
+				ExpectError: regexp.MustCompile(`Router Advertisements not enabled`),
+			},
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name    = "tf-acc-test-network-ipv6-invalid"
+  purpose = "corporate"
+  vlan_id = 3928
+  subnet  = "10.128.0.1/24"
+
+  ipv6 = {
+    setting_preference = "manual"
+    interface_type     = "pd"
+    pd_interface       = "wan"
+    subnet             = "fd00:3928::1/64"
+  }
+}
+`, testAccProviderConfig),
+				ExpectError: regexp.MustCompile(`ipv6.subnet can only be set when ipv6.interface_type is 'static'`),
+			},
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name    = "tf-acc-test-network-ipv6-invalid"
+  purpose = "corporate"
+  vlan_id = 3928
+  subnet  = "10.128.0.1/24"
+
+  ipv6 = {
+    setting_preference = "manual"
+    interface_type     = "static"
+    subnet             = "10.128.0.0/24"
+  }
+}
+`, testAccProviderConfig),
+				ExpectError: regexp.MustCompile(`Invalid IPv6 CIDR`),
+			},
+		},
+	})
+}
+
+func testAccNetworkResourceConfig_ipv6RADNS(name string, vlanID int, raEnabled bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = %d
+  subnet       = "10.%d.0.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "10.%d.0.10"
+  dhcp_stop    = "10.%d.0.254"
+
+  ipv6 = {
+    setting_preference    = "manual"
+    interface_type        = "static"
+    subnet                = "fd00:%d::1/64"
+    wan_delegation_type   = "none"
+    ra_enabled            = %t
+    ra_dns_servers        = ["2606:4700:4700::1111", "2606:4700:4700::1001"]
+    ra_dns_search_domains = ["corp.example.com"]
+  }
+}
+`, testAccProviderConfig, name, vlanID, vlanID%256, vlanID%256, vlanID%256, vlanID, raEnabled)
+}
+
+func TestAccNetworkResource_dhcpRangeOutsideSubnetRejected(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNetworkResourceConfig_dhcpRange("tf-acc-test-network-dhcp-range", 3961, "10.61.0.10", "10.62.0.254"),
+				ExpectError: regexp.MustCompile(`DHCP range outside subnet`),
+			},
+			{
+				Config:      testAccNetworkResourceConfig_dhcpRange("tf-acc-test-network-dhcp-range", 3961, "10.61.0.200", "10.61.0.100"),
+				ExpectError: regexp.MustCompile(`Invalid DHCP range`),
+			},
+		},
+	})
+}
+
+func TestAccNetworkResource_duplicateVlanRejected(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// A network the configuration does not manage.
+			client := testAccGetClient(t)
+			created, err := client.CreateNetwork(context.Background(), &unifi.Network{
+				Name:        "tf-acc-test-network-dup-a",
+				Purpose:     "corporate",
+				VLAN:        intPtr(3962),
+				VLANEnabled: boolPtr(true),
+				IPSubnet:    "10.122.0.1/24",
+			})
+			if err != nil {
+				t.Fatalf("creating unmanaged network: %v", err)
+			}
+			t.Cleanup(func() {
+				_ = client.DeleteNetwork(context.Background(), created.ID)
+			})
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNetworkResourceConfig_addressing("tf-acc-test-network-dup-b", 3962, "10.63.0.1/24"),
+				ExpectError: regexp.MustCompile(`Duplicate VLAN ID`),
+			},
+			{
+				Config:      testAccNetworkResourceConfig_addressing("tf-acc-test-network-dup-b", 3963, "10.122.0.129/25"),
+				ExpectError: regexp.MustCompile(`Overlapping subnet`),
+			},
+		},
+	})
+}
+
+func TestAccNetworkResource_managedConflictRejected(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkResourceConfig_basic("tf-acc-test-network-dup-a", 3962),
+			},
+			// Both networks are managed here, but the first keeps its VLAN,
+			// so the second still conflicts with it.
+			{
+				Config:      testAccNetworkResourceConfig_conflicting("tf-acc-test-network-dup-a", "tf-acc-test-network-dup-b", 3962, "10.63.0.1/24"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Duplicate VLAN ID`),
+			},
+		},
+	})
+}
+
+func TestAccNetworkResource_deletionProtection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkResourceConfig_basic("tf-acc-test-network-protected", 3964),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_network.test", "deletion_protection", "false"),
+				),
+			},
+			{
+				Config: testAccNetworkResourceConfig_deletionProtection("tf-acc-test-network-protected", 3964, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_network.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccNetworkResourceConfig_deletionProtection("tf-acc-test-network-protected", 3964, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion protection enabled`),
+			},
+			// Disable protection so the post-test destroy succeeds
+			{
+				Config: testAccNetworkResourceConfig_deletionProtection("tf-acc-test-network-protected", 3964, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_network.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNetworkResourceConfig_deletionProtection(name string, vlanID int, protected bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name                = %q
+  purpose             = "corporate"
+  vlan_id             = %d
+  subnet              = "10.%d.0.1/24"
+  deletion_protection = %t
+}
+`, testAccProviderConfig, name, vlanID, vlanID%256, protected)
+}
+
+func testAccNetworkResourceConfig_dhcpRange(name string, vlanID int, start, stop string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = %d
+  subnet       = "10.%d.0.1/24"
+  dhcp_enabled = true
+  dhcp_start   = %q
+  dhcp_stop    = %q
+}
+`, testAccProviderConfig, name, vlanID, vlanID%256, start, stop)
+}
+
+// testAccNetworkResourceConfig_conflicting keeps the network from
+// testAccNetworkResourceConfig_basic(nameA, 3962) — subnet 10.122.0.1/24 —
+// and adds a second network with the given VLAN and subnet.
+func testAccNetworkResourceConfig_addressing(name string, vlanID int, subnet string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = %q
+}
+`, testAccProviderConfig, name, vlanID, subnet)
+}
+
+func testAccNetworkResourceConfig_conflicting(nameA, nameB string, vlanB int, subnetB string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = 3962
+  subnet       = "10.122.0.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "10.122.0.10"
+  dhcp_stop    = "10.122.0.254"
+}
+
+resource "unifi_network" "conflict" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = %q
+}
+`, testAccProviderConfig, nameA, nameB, vlanB, subnetB)
+}
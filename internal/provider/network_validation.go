@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// modifyPlanLookupTimeout bounds the controller reads made from ModifyPlan so
+// an unresponsive controller cannot stall a plan indefinitely.
+const modifyPlanLookupTimeout = 30 * time.Second
+
+// networkAddressing is the subset of a network's configuration that the
+// plan-time cross-resource validators compare: VLAN ID, IPv4 subnet and
+// DHCP range.
+// It is built either from a controller object or from a planned
+// unifi_network so both sides can be compared the same way.
+type networkAddressing struct {
+	ID      string
+	Name    string
+	Purpose string
+	VLAN    int        // 0 when the network is untagged or the VLAN is unknown
+	Subnet  *net.IPNet // nil when the network has no subnet or it is unknown
+
+	// DHCPStart and DHCPStop are nil when DHCP is disabled or the range is
+	// unknown.
+	DHCPStart net.IP
+	DHCPStop  net.IP
+}
+
+// plannedNetworkKey returns the key a planned network is recorded under:
+// its ID when known, otherwise its name. An empty key means the network
+// cannot be identified yet and should not be recorded.
+func plannedNetworkKey(n networkAddressing) string {
+	switch {
+	case n.ID != "":
+		return n.ID
+	case n.Name != "":
+		return "name:" + n.Name
+	default:
+		return ""
+	}
+}
+
+// networkConflict describes a single invariant violation between a planned
+// network and another network. Attribute is the unifi_network attribute the
+// diagnostic should point at.
+type networkConflict struct {
+	Attribute string
+	Summary   string
+	Detail    string
+}
+
+// parseNetworkSubnet parses a UniFi ip_subnet value. The controller stores the
+// gateway address with the prefix length (e.g. "10.0.10.1/24"), so the host
+// part is returned separately from the masked network.
+func parseNetworkSubnet(s string) (net.IP, *net.IPNet, error) {
+	ip, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ip.To4() == nil {
+		return nil, nil, fmt.Errorf("%q is not an IPv4 subnet", s)
+	}
+	return ip.To4(), ipNet, nil
+}
+
+// subnetsOverlap reports whether two IPv4 networks share any address. Two
+// CIDR blocks either nest or are disjoint, so checking each network address
+// against the other block is sufficient.
+func subnetsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// compareIPv4 orders two IPv4 addresses numerically.
+func compareIPv4(a, b net.IP) int {
+	return bytes.Compare(a.To4(), b.To4())
+}
+
+// validateDHCPRange checks that a DHCP range lies inside the network's subnet
+// and that the start does not come after the stop. Empty start/stop values are
+// skipped so partially-known configs can still be checked.
+func validateDHCPRange(subnet *net.IPNet, start, stop string) []networkConflict {
+	var conflicts []networkConflict
+
+	inSubnet := func(attribute, value string) net.IP {
+		if value == "" {
+			return nil
+		}
+		ip := net.ParseIP(value).To4()
+		if ip == nil {
+			// Format errors are reported by the attribute's IPv4 validator.
+			return nil
+		}
+		if !subnet.Contains(ip) {
+			conflicts = append(conflicts, networkConflict{
+				Attribute: attribute,
+				Summary:   "DHCP range outside subnet",
+				Detail: fmt.Sprintf("%s %s is not inside the network's subnet %s. "+
+					"The controller rejects DHCP ranges that extend beyond the subnet.",
+					attribute, value, subnet.String()),
+			})
+			return nil
+		}
+		return ip
+	}
+
+	startIP := inSubnet("dhcp_start", start)
+	stopIP := inSubnet("dhcp_stop", stop)
+
+	if startIP != nil && stopIP != nil && compareIPv4(startIP, stopIP) > 0 {
+		conflicts = append(conflicts, networkConflict{
+			Attribute: "dhcp_stop",
+			Summary:   "Invalid DHCP range",
+			Detail:    fmt.Sprintf("dhcp_stop %s comes before dhcp_start %s.", stop, start),
+		})
+	}
+
+	return conflicts
+}
+
+// networkAddressingFromSDK extracts the validated fields from a controller
+// network. Networks without an enabled VLAN report VLAN 0.
+func networkAddressingFromSDK(n *unifi.Network) networkAddressing {
+	addr := networkAddressing{
+		ID:      n.ID,
+		Name:    n.Name,
+		Purpose: n.Purpose,
+	}
+	if n.VLAN != nil && *n.VLAN > 0 && (n.VLANEnabled == nil || *n.VLANEnabled) {
+		addr.VLAN = *n.VLAN
+	}
+	if n.IPSubnet != "" {
+		if _, ipNet, err := parseNetworkSubnet(n.IPSubnet); err == nil {
+			addr.Subnet = ipNet
+		}
+	}
+	if derefBool(n.DHCPDEnabled) {
+		addr.DHCPStart = net.ParseIP(n.DHCPDStart).To4()
+		addr.DHCPStop = net.ParseIP(n.DHCPDStop).To4()
+	}
+	return addr
+}
+
+// inDHCPRange reports whether ip falls inside the network's dynamic DHCP
+// range. Networks without a known range report false.
+func inDHCPRange(ip net.IP, n networkAddressing) bool {
+	if n.DHCPStart == nil || n.DHCPStop == nil {
+		return false
+	}
+	return compareIPv4(ip, n.DHCPStart) >= 0 && compareIPv4(ip, n.DHCPStop) <= 0
+}
+
+// sharesLANAddressSpace reports whether a network purpose takes part in the
+// site's LAN VLAN and subnet space. WAN and VPN networks are addressed
+// independently and are never compared.
+func sharesLANAddressSpace(purpose string) bool {
+	switch purpose {
+	case "corporate", "guest", "vlan-only":
+		return true
+	default:
+		return false
+	}
+}
+
+// findNetworkConflicts compares a planned network against every other known
+// network (existing on the controller or planned elsewhere in the same run)
+// and returns VLAN ID and subnet overlap violations.
+func findNetworkConflicts(candidate networkAddressing, others []networkAddressing) []networkConflict {
+	if !sharesLANAddressSpace(candidate.Purpose) {
+		return nil
+	}
+
+	var conflicts []networkConflict
+	for _, other := range others {
+		if candidate.ID != "" && other.ID == candidate.ID {
+			continue
+		}
+		if !sharesLANAddressSpace(other.Purpose) {
+			continue
+		}
+
+		if candidate.VLAN != 0 && candidate.VLAN == other.VLAN {
+			conflicts = append(conflicts, networkConflict{
+				Attribute: "vlan_id",
+				Summary:   "Duplicate VLAN ID",
+				Detail: fmt.Sprintf("VLAN %d is already used by network %q. "+
+					"Each network on a site must use a distinct VLAN ID.", candidate.VLAN, other.Name),
+			})
+		}
+
+		if candidate.Subnet != nil && other.Subnet != nil && subnetsOverlap(candidate.Subnet, other.Subnet) {
+			conflicts = append(conflicts, networkConflict{
+				Attribute: "subnet",
+				Summary:   "Overlapping subnet",
+				Detail: fmt.Sprintf("Subnet %s overlaps %s used by network %q. "+
+					"Networks on a site must use non-overlapping subnets.",
+					candidate.Subnet.String(), other.Subnet.String(), other.Name),
+			})
+		}
+	}
+
+	return conflicts
+}
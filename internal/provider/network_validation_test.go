@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"net"
+	"testing"
+
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func mustSubnet(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+	_, ipNet, err := parseNetworkSubnet(s)
+	if err != nil {
+		t.Fatalf("parseNetworkSubnet(%q): %v", s, err)
+	}
+	return ipNet
+}
+
+func TestParseNetworkSubnet(t *testing.T) {
+	cases := []struct {
+		subnet  string
+		gateway string
+		network string
+		wantErr bool
+	}{
+		{subnet: "10.0.10.1/24", gateway: "10.0.10.1", network: "10.0.10.0/24"},
+		{subnet: "192.168.3.0/24", gateway: "192.168.3.0", network: "192.168.3.0/24"},
+		{subnet: "10.0.10.1", wantErr: true},
+		{subnet: "fd00::1/64", wantErr: true},
+		{subnet: "not-a-subnet", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.subnet, func(t *testing.T) {
+			gateway, ipNet, err := parseNetworkSubnet(tc.subnet)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseNetworkSubnet(%q) succeeded, want error", tc.subnet)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseNetworkSubnet(%q): %v", tc.subnet, err)
+			}
+			if gateway.String() != tc.gateway {
+				t.Fatalf("gateway = %s, want %s", gateway, tc.gateway)
+			}
+			if ipNet.String() != tc.network {
+				t.Fatalf("network = %s, want %s", ipNet, tc.network)
+			}
+		})
+	}
+}
+
+func TestSubnetsOverlap(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "10.0.10.1/24", b: "10.0.11.1/24", want: false},
+		{a: "10.0.10.1/24", b: "10.0.10.129/25", want: true},
+		{a: "10.0.0.1/16", b: "10.0.200.1/24", want: true},
+		{a: "192.168.1.1/24", b: "192.168.1.1/24", want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.a+"_"+tc.b, func(t *testing.T) {
+			a, b := mustSubnet(t, tc.a), mustSubnet(t, tc.b)
+			if got := subnetsOverlap(a, b); got != tc.want {
+				t.Fatalf("subnetsOverlap(%s, %s) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+			if got := subnetsOverlap(b, a); got != tc.want {
+				t.Fatalf("subnetsOverlap(%s, %s) = %v, want %v", tc.b, tc.a, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateDHCPRange(t *testing.T) {
+	subnet := mustSubnet(t, "10.39.1.1/24")
+	cases := []struct {
+		name        string
+		start, stop string
+		want        []string
+	}{
+		{name: "inside", start: "10.39.1.10", stop: "10.39.1.254"},
+		{name: "unset", start: "", stop: ""},
+		{name: "start outside", start: "10.39.2.10", stop: "10.39.1.254", want: []string{"dhcp_start"}},
+		{name: "stop outside", start: "10.39.1.10", stop: "10.40.1.254", want: []string{"dhcp_stop"}},
+		{name: "both outside", start: "10.38.1.10", stop: "10.40.1.254", want: []string{"dhcp_start", "dhcp_stop"}},
+		{name: "reversed", start: "10.39.1.200", stop: "10.39.1.100", want: []string{"dhcp_stop"}},
+		{name: "malformed left to validator", start: "nope", stop: "10.39.1.100"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := validateDHCPRange(subnet, tc.start, tc.stop)
+			if len(got) != len(tc.want) {
+				t.Fatalf("validateDHCPRange(%q, %q) returned %d conflicts (%+v), want %d", tc.start, tc.stop, len(got), got, len(tc.want))
+			}
+			for i, c := range got {
+				if c.Attribute != tc.want[i] {
+					t.Fatalf("conflict %d attribute = %q, want %q", i, c.Attribute, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFindNetworkConflicts(t *testing.T) {
+	existing := []networkAddressing{
+		{ID: "default", Name: "Default", Purpose: "corporate", Subnet: mustSubnet(t, "192.168.1.1/24")},
+		{ID: "iot", Name: "IoT", Purpose: "corporate", VLAN: 20, Subnet: mustSubnet(t, "10.0.20.1/24")},
+		{ID: "wan", Name: "Internet", Purpose: "wan", VLAN: 30, Subnet: mustSubnet(t, "10.0.30.1/24")},
+		{ID: "vpn", Name: "VPN", Purpose: "remote-user-vpn", Subnet: mustSubnet(t, "10.0.40.1/24")},
+	}
+	cases := []struct {
+		name      string
+		candidate networkAddressing
+		want      []string
+	}{
+		{
+			name:      "no conflict",
+			candidate: networkAddressing{Name: "Cameras", Purpose: "corporate", VLAN: 50, Subnet: mustSubnet(t, "10.0.50.1/24")},
+		},
+		{
+			name:      "duplicate vlan",
+			candidate: networkAddressing{Name: "Cameras", Purpose: "corporate", VLAN: 20, Subnet: mustSubnet(t, "10.0.50.1/24")},
+			want:      []string{"vlan_id"},
+		},
+		{
+			name:      "overlapping subnet",
+			candidate: networkAddressing{Name: "Cameras", Purpose: "guest", VLAN: 50, Subnet: mustSubnet(t, "192.168.0.1/16")},
+			want:      []string{"subnet"},
+		},
+		{
+			name:      "duplicate vlan and subnet",
+			candidate: networkAddressing{Name: "Cameras", Purpose: "corporate", VLAN: 20, Subnet: mustSubnet(t, "10.0.20.129/25")},
+			want:      []string{"vlan_id", "subnet"},
+		},
+		{
+			name:      "self is skipped",
+			candidate: networkAddressing{ID: "iot", Name: "IoT", Purpose: "corporate", VLAN: 20, Subnet: mustSubnet(t, "10.0.20.1/24")},
+		},
+		{
+			name:      "wan and vpn networks are ignored",
+			candidate: networkAddressing{Name: "Cameras", Purpose: "corporate", VLAN: 30, Subnet: mustSubnet(t, "10.0.40.1/24")},
+		},
+		{
+			name:      "vlan-only network shares the vlan space",
+			candidate: networkAddressing{Name: "Transit", Purpose: "vlan-only", VLAN: 20},
+			want:      []string{"vlan_id"},
+		},
+		{
+			name:      "wan candidate is not checked",
+			candidate: networkAddressing{Name: "Internet 2", Purpose: "wan", VLAN: 20, Subnet: mustSubnet(t, "10.0.20.1/24")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := findNetworkConflicts(tc.candidate, existing)
+			if len(got) != len(tc.want) {
+				t.Fatalf("findNetworkConflicts returned %d conflicts (%+v), want %d", len(got), got, len(tc.want))
+			}
+			for i, c := range got {
+				if c.Attribute != tc.want[i] {
+					t.Fatalf("conflict %d attribute = %q, want %q", i, c.Attribute, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPlannedNetworkKey(t *testing.T) {
+	cases := []struct {
+		network networkAddressing
+		want    string
+	}{
+		{network: networkAddressing{ID: "abc", Name: "LAN"}, want: "abc"},
+		{network: networkAddressing{Name: "LAN"}, want: "name:LAN"},
+		{network: networkAddressing{}, want: ""},
+	}
+	for _, tc := range cases {
+		if got := plannedNetworkKey(tc.network); got != tc.want {
+			t.Fatalf("plannedNetworkKey(%+v) = %q, want %q", tc.network, got, tc.want)
+		}
+	}
+}
+
+func TestInDHCPRange(t *testing.T) {
+	network := networkAddressingFromSDK(&unifi.Network{
+		IPSubnet:     "10.0.10.1/24",
+		DHCPDEnabled: boolPtr(true),
+		DHCPDStart:   "10.0.10.100",
+		DHCPDStop:    "10.0.10.200",
+	})
+
+	cases := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "10.0.10.50"},
+		{ip: "10.0.10.100", want: true},
+		{ip: "10.0.10.150", want: true},
+		{ip: "10.0.10.200", want: true},
+		{ip: "10.0.10.201"},
+	}
+	for _, tc := range cases {
+		if got := inDHCPRange(net.ParseIP(tc.ip), network); got != tc.want {
+			t.Fatalf("inDHCPRange(%s) = %v, want %v", tc.ip, got, tc.want)
+		}
+	}
+
+	network.DHCPStart, network.DHCPStop = nil, nil
+	if inDHCPRange(net.ParseIP("10.0.10.150"), network) {
+		t.Fatalf("inDHCPRange() with DHCP disabled = true, want false")
+	}
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+const networkPurposeVLANOnly = "vlan-only"
+
+// vlanOnlyNullAttributes returns the gateway-only attributes that must be
+// left unset on a vlan-only network. A vlan-only network is routed by a
+// third-party gateway, so addressing, DHCP, DNS and firewall settings made
+// here are stored by the controller but never take effect.
+func vlanOnlyNullAttributes(m *NetworkResourceModel) map[string]attr.Value {
+	return map[string]attr.Value{
+		"subnet":                   m.Subnet,
+		"dhcp_start":               m.DHCPStart,
+		"dhcp_stop":                m.DHCPStop,
+		"dhcp_lease":               m.DHCPLease,
+		"dhcp_dns_enabled":         m.DHCPDNSEnabled,
+		"dhcp_dns":                 m.DHCPDNS,
+		"dhcp_gateway_enabled":     m.DHCPGatewayEnabled,
+		"dhcp_gateway":             m.DHCPGateway,
+		"dhcp_ntp_enabled":         m.DHCPNTPEnabled,
+		"dhcp_ntp":                 m.DHCPNTP,
+		"dhcp_boot_enabled":        m.DHCPBootEnabled,
+		"dhcp_boot_server":         m.DHCPBootServer,
+		"dhcp_tftp_server":         m.DHCPTFTPServer,
+		"dhcp_boot_filename":       m.DHCPBootFilename,
+		"dhcp_relay_enabled":       m.DHCPRelayEnabled,
+		"dhcp_relay_servers":       m.DHCPRelayServers,
+		"dhcp_time_offset_enabled": m.DHCPTimeOffsetEnabled,
+		"dhcp_unifi_controller":    m.DHCPUnifiController,
+		"dhcp_wpad_url":            m.DHCPWPADUrl,
+		"dhcp_options":             m.DHCPOptions,
+		"domain_name":              m.DomainName,
+		"igmp_proxy_upstream":      m.IGMPProxyUpstream,
+		"firewall_zone_id":         m.FirewallZoneID,
+		"ipv6":                     m.IPv6,
+	}
+}
+
+// vlanOnlyOffAttributes returns the gateway-only switches of a network. They
+// have defaults or are computed, so a vlan-only network may set them to false
+// but never to true.
+func vlanOnlyOffAttributes(m *NetworkResourceModel) map[string]types.Bool {
+	return map[string]types.Bool{
+		"dhcp_enabled":            m.DHCPEnabled,
+		"internet_access_enabled": m.InternetAccessEnabled,
+		"nat_enabled":             m.NATEnabled,
+		"mdns_enabled":            m.MDNSEnabled,
+		"upnp_lan_enabled":        m.UPnPLANEnabled,
+	}
+}
+
+// validateVLANOnlyNetwork rejects gateway-only attributes configured on a
+// vlan-only network. Conflicts are sorted by attribute name. Unknown values
+// are skipped.
+func validateVLANOnlyNetwork(config *NetworkResourceModel) []networkConflict {
+	if config.Purpose.ValueString() != networkPurposeVLANOnly {
+		return nil
+	}
+
+	var conflicts []networkConflict
+	for name, v := range vlanOnlyNullAttributes(config) {
+		if v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		conflicts = append(conflicts, networkConflict{
+			Attribute: name,
+			Summary:   "Gateway attribute on vlan-only network",
+			Detail: fmt.Sprintf("%s is handled by the gateway and has no effect on a vlan-only network, which is routed by a third-party gateway. "+
+				"Remove it, or set purpose to 'corporate' or 'guest' if a UniFi gateway routes this network.", name),
+		})
+	}
+	for name, v := range vlanOnlyOffAttributes(config) {
+		if v.IsNull() || v.IsUnknown() || !v.ValueBool() {
+			continue
+		}
+		conflicts = append(conflicts, networkConflict{
+			Attribute: name,
+			Summary:   "Gateway attribute on vlan-only network",
+			Detail: fmt.Sprintf("%s can only be false on a vlan-only network, which is routed by a third-party gateway. "+
+				"Remove it, or set purpose to 'corporate' or 'guest' if a UniFi gateway routes this network.", name),
+		})
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Attribute < conflicts[j].Attribute })
+	return conflicts
+}
+
+// suppressVLANOnlyGateway clears the gateway-only attributes of a vlan-only
+// network in a plan or state, so the defaults meant for routed networks and
+// whatever the controller kept from an earlier purpose never show up as
+// drift. firewall_zone_id and ipv6 are left alone: they are computed and the
+// controller still reports them.
+func suppressVLANOnlyGateway(m *NetworkResourceModel) {
+	m.Subnet = types.StringNull()
+	m.DHCPStart = types.StringNull()
+	m.DHCPStop = types.StringNull()
+	m.DHCPLease = types.Int64Null()
+	m.DHCPDNSEnabled = types.BoolNull()
+	m.DHCPDNS = types.SetNull(types.StringType)
+	m.DHCPGatewayEnabled = types.BoolNull()
+	m.DHCPGateway = types.StringNull()
+	m.DHCPNTPEnabled = types.BoolNull()
+	m.DHCPNTP = types.SetNull(types.StringType)
+	m.DHCPBootEnabled = types.BoolNull()
+	m.DHCPBootServer = types.StringNull()
+	m.DHCPTFTPServer = types.StringNull()
+	m.DHCPBootFilename = types.StringNull()
+	m.DHCPRelayEnabled = types.BoolNull()
+	m.DHCPRelayServers = types.ListNull(types.StringType)
+	m.DHCPTimeOffsetEnabled = types.BoolNull()
+	m.DHCPUnifiController = types.StringNull()
+	m.DHCPWPADUrl = types.StringNull()
+	m.DHCPOptions = types.ListNull(types.ObjectType{AttrTypes: dhcpOptionAttrTypes})
+	m.DomainName = types.StringNull()
+	m.IGMPProxyUpstream = types.BoolNull()
+
+	m.DHCPEnabled = types.BoolValue(false)
+	m.InternetAccessEnabled = types.BoolValue(false)
+	m.NATEnabled = types.BoolValue(false)
+	m.MDNSEnabled = types.BoolValue(false)
+	m.UPnPLANEnabled = types.BoolValue(false)
+}
+
+// vlanOnlyToSDK turns off the gateway features of a vlan-only network and
+// drops the settings that only a UniFi gateway would use.
+func vlanOnlyToSDK(network *unifi.Network) {
+	network.IPSubnet = ""
+	network.DHCPDEnabled = boolPtr(false)
+	network.DHCPDLeasetime = nil
+	network.DHCPRelayServers = nil
+	network.DHCPDOptions = nil
+	network.IsNAT = boolPtr(false)
+	network.InternetAccessEnabled = boolPtr(false)
+	network.MDNSEnabled = boolPtr(false)
+	network.UpnpLANEnabled = boolPtr(false)
+}
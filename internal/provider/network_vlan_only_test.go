@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func TestValidateVLANOnlyNetwork(t *testing.T) {
+	cases := []struct {
+		name   string
+		config NetworkResourceModel
+		want   []string
+	}{
+		{name: "vlan only", config: NetworkResourceModel{
+			Purpose: types.StringValue("vlan-only"),
+			VlanID:  types.Int64Value(200),
+		}},
+		{name: "switches off", config: NetworkResourceModel{
+			Purpose:     types.StringValue("vlan-only"),
+			DHCPEnabled: types.BoolValue(false),
+			NATEnabled:  types.BoolValue(false),
+		}},
+		{name: "gateway attributes", config: NetworkResourceModel{
+			Purpose:        types.StringValue("vlan-only"),
+			Subnet:         types.StringValue("10.0.200.1/24"),
+			DHCPEnabled:    types.BoolValue(true),
+			FirewallZoneID: types.StringValue("zone"),
+			DHCPStart:      types.StringUnknown(),
+		}, want: []string{"dhcp_enabled", "firewall_zone_id", "subnet"}},
+		{name: "corporate", config: NetworkResourceModel{
+			Purpose:     types.StringValue("corporate"),
+			Subnet:      types.StringValue("10.0.200.1/24"),
+			DHCPEnabled: types.BoolValue(true),
+		}},
+		{name: "unknown purpose", config: NetworkResourceModel{
+			Purpose: types.StringUnknown(),
+			Subnet:  types.StringValue("10.0.200.1/24"),
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := validateVLANOnlyNetwork(&tc.config)
+			if len(got) != len(tc.want) {
+				t.Fatalf("validateVLANOnlyNetwork() = %+v, want conflicts on %v", got, tc.want)
+			}
+			for i, c := range got {
+				if c.Attribute != tc.want[i] {
+					t.Fatalf("conflict %d on %q, want %q", i, c.Attribute, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSuppressVLANOnlyGateway(t *testing.T) {
+	state := NetworkResourceModel{
+		Subnet:         types.StringValue("10.0.200.1/24"),
+		DHCPEnabled:    types.BoolValue(true),
+		DHCPLease:      types.Int64Value(defaultDHCPLease),
+		NATEnabled:     types.BoolValue(true),
+		FirewallZoneID: types.StringValue("zone"),
+	}
+	suppressVLANOnlyGateway(&state)
+
+	if !state.Subnet.IsNull() || !state.DHCPLease.IsNull() {
+		t.Fatalf("subnet = %v, dhcp_lease = %v, want null", state.Subnet, state.DHCPLease)
+	}
+	if state.DHCPEnabled.ValueBool() || state.NATEnabled.ValueBool() || state.DHCPEnabled.IsNull() {
+		t.Fatalf("dhcp_enabled = %v, nat_enabled = %v, want false", state.DHCPEnabled, state.NATEnabled)
+	}
+	if state.FirewallZoneID.ValueString() != "zone" {
+		t.Fatalf("firewall_zone_id = %v, want it kept", state.FirewallZoneID)
+	}
+}
+
+func TestVLANOnlyToSDK(t *testing.T) {
+	network := &unifi.Network{
+		Purpose:        "vlan-only",
+		IPSubnet:       "10.0.200.1/24",
+		DHCPDEnabled:   boolPtr(true),
+		DHCPDLeasetime: intPtr(defaultDHCPLease),
+		IsNAT:          boolPtr(true),
+	}
+	vlanOnlyToSDK(network)
+
+	if network.IPSubnet != "" || network.DHCPDLeasetime != nil {
+		t.Fatalf("subnet = %q, lease = %v, want them cleared", network.IPSubnet, network.DHCPDLeasetime)
+	}
+	if derefBool(network.DHCPDEnabled) || derefBool(network.IsNAT) {
+		t.Fatalf("DHCPDEnabled = %v, IsNAT = %v, want false", derefBool(network.DHCPDEnabled), derefBool(network.IsNAT))
+	}
+}
+
+func TestIsGatewayDevice(t *testing.T) {
+	for deviceType, want := range map[string]bool{
+		"ugw": true,
+		"udm": true,
+		"uxg": true,
+		"usw": false,
+		"uap": false,
+		"":    false,
+	} {
+		if got := isGatewayDevice(deviceType); got != want {
+			t.Fatalf("isGatewayDevice(%q) = %v, want %v", deviceType, got, want)
+		}
+	}
+}
@@ -70,6 +70,8 @@ type PortProfileResourceModel struct {
 
 	PortKeepaliveEnabled types.Bool `tfsdk:"port_keepalive_enabled"`
 
+	ConflictDetection types.String `tfsdk:"conflict_detection"`
+
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -99,6 +101,7 @@ func (r *PortProfileResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"conflict_detection": conflictDetectionAttribute("port profile"),
 			"name": schema.StringAttribute{
 				Description: "The name of the port profile.",
 				Required:    true,
@@ -382,6 +385,7 @@ func (r *PortProfileResource) Create(ctx context.Context, req resource.CreateReq
 		handleSDKError(&resp.Diagnostics, err, "create", "port profile")
 		return
 	}
+	resp.Diagnostics.Append(saveLastApplied(ctx, resp.Private, created)...)
 
 	resp.Diagnostics.Append(r.sdkToState(ctx, created, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -416,6 +420,7 @@ func (r *PortProfileResource) Read(ctx context.Context, req resource.ReadRequest
 		handleSDKError(&resp.Diagnostics, err, "read", "port profile")
 		return
 	}
+	resp.Diagnostics.Append(saveLastApplied(ctx, resp.Private, profile)...)
 
 	resp.Diagnostics.Append(r.sdkToState(ctx, profile, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -455,6 +460,18 @@ func (r *PortProfileResource) Update(ctx context.Context, req resource.UpdateReq
 	profile.ID = state.ID.ValueString()
 	profile.SiteID = state.SiteID.ValueString()
 
+	if conflictDetectionEnabled(plan.ConflictDetection) {
+		current, err := r.client.GetPortProfile(ctx, state.ID.ValueString())
+		if err != nil {
+			handleSDKError(&resp.Diagnostics, err, "read", "port profile")
+			return
+		}
+		checkOutOfBandEdits(ctx, plan.ConflictDetection, req.Private, current, "port profile", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	_, err := r.client.UpdatePortProfile(ctx, state.ID.ValueString(), profile)
 	if err != nil {
 		handleSDKError(&resp.Diagnostics, err, "update", "port profile")
@@ -466,6 +483,7 @@ func (r *PortProfileResource) Update(ctx context.Context, req resource.UpdateReq
 		handleSDKError(&resp.Diagnostics, err, "read", "port profile")
 		return
 	}
+	resp.Diagnostics.Append(saveLastApplied(ctx, resp.Private, updated)...)
 
 	resp.Diagnostics.Append(r.sdkToState(ctx, updated, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -614,6 +632,7 @@ func (r *PortProfileResource) sdkToState(ctx context.Context, profile *unifi.Por
 	state.ID = types.StringValue(profile.ID)
 	state.SiteID = types.StringValue(profile.SiteID)
 	state.Name = types.StringValue(profile.Name)
+	state.ConflictDetection = conflictDetectionOrDefault(state.ConflictDetection)
 
 	state.NativeNetworkID = stringValueOrNull(profile.NativeNetworkconfID)
 	state.TaggedVlanMgmt = stringValueOrNull(profile.TaggedVlanMgmt)
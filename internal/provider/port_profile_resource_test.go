@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccPortProfileResource_basic(t *testing.T) {
@@ -29,6 +32,41 @@ func TestAccPortProfileResource_basic(t *testing.T) {
 	})
 }
 
+func TestAccPortProfileResource_conflictDetectionError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPortProfileResourceConfig_conflictDetection("tf-acc-test-port-profile-conflict", "error"),
+			},
+			// isolation is changed on the controller after the plan is made,
+			// so the rename must be refused.
+			{
+				Config: testAccPortProfileResourceConfig_conflictDetection("tf-acc-test-port-profile-conflict-renamed", "error"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						testAccEditBeforeApply{
+							address: "unifi_port_profile.test",
+							edit: func(ctx context.Context, id string) error {
+								client := testAccGetClient(t)
+								profile, err := client.GetPortProfile(ctx, id)
+								if err != nil {
+									return err
+								}
+								profile.Isolation = boolPtr(!derefBool(profile.Isolation))
+								_, err = client.UpdatePortProfile(ctx, id, profile)
+								return err
+							},
+						},
+					},
+				},
+				ExpectError: regexp.MustCompile(`port profile changed outside Terraform`),
+			},
+		},
+	})
+}
+
 func TestAccPortProfileResource_nativeVlan(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -153,6 +191,17 @@ resource "unifi_port_profile" "test" {
 `, testAccProviderConfig, name)
 }
 
+func testAccPortProfileResourceConfig_conflictDetection(name, mode string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_port_profile" "test" {
+  name               = %q
+  conflict_detection = %q
+}
+`, testAccProviderConfig, name, mode)
+}
+
 func testAccPortProfileResourceConfig_nativeVlan(name string, vlanBase int) string {
 	return fmt.Sprintf(`
 %s
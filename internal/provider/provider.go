@@ -26,6 +26,8 @@ type UnifiProviderModel struct {
 	Password types.String `tfsdk:"password"`
 	Site     types.String `tfsdk:"site"`
 	Insecure types.Bool   `tfsdk:"insecure"`
+
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
 }
 
 func New(version string) func() provider.Provider {
@@ -80,6 +82,14 @@ func (p *UnifiProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 					"Can also be set via the UNIFI_INSECURE environment variable.",
 				Optional: true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "When Create fails because an object with the same natural key already exists on the controller " +
+					"(for example a unifi_user with a known MAC address), update that object to match the configuration " +
+					"and manage it instead of failing. Resources that support adoption can override this with their own " +
+					"adopt_existing attribute. Defaults to false. " +
+					"Can also be set via the UNIFI_ADOPT_EXISTING environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -126,6 +136,11 @@ func (p *UnifiProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		insecure = config.Insecure.ValueBool()
 	}
 
+	adoptExisting := os.Getenv("UNIFI_ADOPT_EXISTING") == "true"
+	if !config.AdoptExisting.IsNull() {
+		adoptExisting = config.AdoptExisting.ValueBool()
+	}
+
 	// Validate required configuration
 	if baseURL == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -203,6 +218,7 @@ func (p *UnifiProvider) Configure(ctx context.Context, req provider.ConfigureReq
 
 	// Wrap client with auto-relogin capability
 	wrappedClient := NewAutoLoginClient(client, clientConfig)
+	wrappedClient.adoptExisting = adoptExisting
 
 	// Make the client available to resources and data sources
 	resp.DataSourceData = wrappedClient
@@ -214,20 +230,29 @@ func (p *UnifiProvider) Resources(ctx context.Context) []func() resource.Resourc
 		NewAccountResource,
 		NewContentFilteringResource,
 		NewDevicePortOverrideResource,
+		NewDevicePortsResource,
+		NewSwitchLAGResource,
+		NewSwitchPortMirrorResource,
 		NewDeviceResource,
+		NewDHCPReservationsResource,
+		NewDNSFilterResource,
 		NewDynamicDNSResource,
 		NewFirewallGroupResource,
 		NewFirewallPolicyResource,
 		NewFirewallRuleResource,
 		NewFirewallZoneResource,
+		NewIPAllocationResource,
 		NewNatRuleResource,
 		NewNetworkResource,
+		NewVLANAllocationResource,
+		NewWANResource,
 		NewPortForwardResource,
 		NewPortProfileResource,
 		NewRADIUSProfileResource,
 		NewSettingGuestAccessResource,
 		NewSettingIPSResource,
 		NewSettingMagicSiteToSiteVPNResource,
+		NewSettingMDNSResource,
 		NewSettingMgmtResource,
 		NewSettingRadiusResource,
 		NewSettingSNMPResource,
@@ -235,6 +260,7 @@ func (p *UnifiProvider) Resources(ctx context.Context) []func() resource.Resourc
 		NewSettingUSGResource,
 		NewSiteResource,
 		NewStaticDNSResource,
+		NewStaticDNSZoneResource,
 		NewStaticRouteResource,
 		NewTrafficRouteResource,
 		NewTrafficRuleResource,
@@ -259,11 +285,13 @@ func (p *UnifiProvider) DataSources(ctx context.Context) []func() datasource.Dat
 		NewFirewallPolicyDataSource,
 		NewFirewallRuleDataSource,
 		NewFirewallZoneDataSource,
+		NewGatewayDataSource,
 		NewNatRuleDataSource,
 		NewNetworkDataSource,
 		NewPortForwardDataSource,
 		NewPortProfileDataSource,
 		NewRADIUSProfileDataSource,
+		NewSettingMDNSDataSource,
 		NewSiteDataSource,
 		NewStaticDNSDataSource,
 		NewStaticRouteDataSource,
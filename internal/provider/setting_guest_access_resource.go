@@ -65,6 +65,7 @@ type SettingGuestAccessResourceModel struct {
 	PortalUseHostname                  types.Bool     `tfsdk:"portal_use_hostname"`
 	ECEnabled                          types.Bool     `tfsdk:"ec_enabled"`
 	TemplateEngine                     types.String   `tfsdk:"template_engine"`
+	OnDestroy                          types.String   `tfsdk:"on_destroy"`
 	Timeouts                           timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -79,7 +80,7 @@ func (r *SettingGuestAccessResource) Metadata(ctx context.Context, req resource.
 func (r *SettingGuestAccessResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages UniFi guest access/captive portal settings. " +
-			"This is a singleton resource — one per site. Delete resets to defaults.",
+			"This is a singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -347,6 +348,7 @@ func (r *SettingGuestAccessResource) Schema(ctx context.Context, req resource.Sc
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"on_destroy": onDestroyAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -389,6 +391,16 @@ func (r *SettingGuestAccessResource) Create(ctx context.Context, req resource.Cr
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	original, err := r.client.GetSettingGuestAccess(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "guest access setting")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setting := r.planToSDK(&plan)
 
 	updated, err := r.client.UpdateSettingGuestAccess(ctx, setting)
@@ -491,9 +503,19 @@ func (r *SettingGuestAccessResource) Delete(ctx context.Context, req resource.De
 		defaults.ID = state.ID.ValueString()
 	}
 
-	_, err := r.client.UpdateSettingGuestAccess(ctx, defaults)
+	setting := defaults
+	var original unifi.SettingGuestAccess
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "guest access setting", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		setting = &original
+	}
+
+	_, err := r.client.UpdateSettingGuestAccess(ctx, setting)
 	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "reset", "guest access setting")
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "guest access setting")
 		return
 	}
 }
@@ -604,6 +626,7 @@ func (r *SettingGuestAccessResource) sdkToState(setting *unifi.SettingGuestAcces
 	var diags diag.Diagnostics
 
 	state.ID = types.StringValue(setting.ID)
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
 	state.SiteID = types.StringValue(setting.SiteID)
 	state.PortalEnabled = types.BoolValue(derefBool(setting.PortalEnabled))
 	state.PortalCustomized = types.BoolValue(derefBool(setting.PortalCustomized))
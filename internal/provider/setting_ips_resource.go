@@ -45,6 +45,7 @@ type SettingIPSResourceModel struct {
 	MemoryOptimized                     types.Bool     `tfsdk:"memory_optimized"`
 	SuppressionAlerts                   types.String   `tfsdk:"suppression_alerts"`
 	SuppressionWhitelist                types.String   `tfsdk:"suppression_whitelist"`
+	OnDestroy                           types.String   `tfsdk:"on_destroy"`
 	Timeouts                            timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -81,7 +82,7 @@ func (r *SettingIPSResource) Metadata(ctx context.Context, req resource.Metadata
 func (r *SettingIPSResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages UniFi IPS/IDS and threat management settings. " +
-			"This is a singleton resource — one per site. Delete resets to defaults.",
+			"This is a singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -112,9 +113,10 @@ func (r *SettingIPSResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Computed:    true,
 			},
 			"dns_filters": schema.ListNestedAttribute{
-				Description: "DNS filter configurations.",
-				Optional:    true,
-				Computed:    true,
+				Description: "DNS filter configurations. When unset, the filters on the controller are kept, including on destroy, so per-network " +
+					"filters can be managed with unifi_dns_filter instead. Do not use both for the same network.",
+				Optional: true,
+				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"filter": schema.StringAttribute{
@@ -241,6 +243,7 @@ func (r *SettingIPSResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"on_destroy": onDestroyAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -283,10 +286,31 @@ func (r *SettingIPSResource) Create(ctx context.Context, req resource.CreateRequ
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	settingLock := r.client.getSettingLock("ips")
+	settingLock.Lock()
+	defer settingLock.Unlock()
+
+	original, err := r.client.GetSettingIPS(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "IPS setting")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setting := r.planToSDK(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if plan.DNSFilters.IsUnknown() {
+		setting.DNSFilters = original.DNSFilters
+	}
+	resp.Diagnostics.Append(setDNSFiltersConfigured(ctx, resp.Private, !plan.DNSFilters.IsUnknown())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	updated, err := r.client.UpdateSettingIPS(ctx, setting)
 	if err != nil {
@@ -346,6 +370,10 @@ func (r *SettingIPSResource) Update(ctx context.Context, req resource.UpdateRequ
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
+	settingLock := r.client.getSettingLock("ips")
+	settingLock.Lock()
+	defer settingLock.Unlock()
+
 	setting := r.planToSDK(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -353,6 +381,20 @@ func (r *SettingIPSResource) Update(ctx context.Context, req resource.UpdateRequ
 	if !plan.ID.IsNull() {
 		setting.ID = plan.ID.ValueString()
 	}
+	// Leave filters managed by unifi_dns_filter alone when dns_filters is
+	// not configured here.
+	if plan.DNSFilters.IsUnknown() {
+		current, err := r.client.GetSettingIPS(ctx)
+		if err != nil {
+			handleSDKError(&resp.Diagnostics, err, "read", "IPS setting")
+			return
+		}
+		setting.DNSFilters = current.DNSFilters
+	}
+	resp.Diagnostics.Append(setDNSFiltersConfigured(ctx, resp.Private, !plan.DNSFilters.IsUnknown())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	updated, err := r.client.UpdateSettingIPS(ctx, setting)
 	if err != nil {
@@ -391,13 +433,62 @@ func (r *SettingIPSResource) Delete(ctx context.Context, req resource.DeleteRequ
 		defaults.ID = state.ID.ValueString()
 	}
 
-	_, err := r.client.UpdateSettingIPS(ctx, defaults)
+	setting := defaults
+	var original unifi.SettingIPS
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "IPS setting", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		setting = &original
+	}
+
+	settingLock := r.client.getSettingLock("ips")
+	settingLock.Lock()
+	defer settingLock.Unlock()
+
+	// Filters added by unifi_dns_filter since the snapshot was taken are not
+	// this resource's to remove, so they are kept unless dns_filters was set
+	// here. dns_filtering stays on while any filter remains.
+	configured, d := req.Private.GetKey(ctx, dnsFiltersConfiguredPrivateKey)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(configured) == 0 {
+		current, err := r.client.GetSettingIPS(ctx)
+		if err != nil {
+			handleSDKError(&resp.Diagnostics, err, "read", "IPS setting")
+			return
+		}
+		setting.DNSFilters = current.DNSFilters
+		if len(current.DNSFilters) > 0 {
+			setting.DNSFiltering = current.DNSFiltering
+		}
+	}
+
+	_, err := r.client.UpdateSettingIPS(ctx, setting)
 	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "reset", "IPS setting")
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "IPS setting")
 		return
 	}
 }
 
+// dnsFiltersConfiguredPrivateKey is set in private state when dns_filters is
+// configured, so Delete can tell filters owned by this resource from filters
+// managed by unifi_dns_filter.
+const dnsFiltersConfiguredPrivateKey = "dns_filters_configured"
+
+// setDNSFiltersConfigured records whether dns_filters is configured. Setting
+// an empty value removes the key.
+func setDNSFiltersConfigured(ctx context.Context, private privateStateSetter, configured bool) diag.Diagnostics {
+	var data []byte
+	if configured {
+		data = []byte("true")
+	}
+	return private.SetKey(ctx, dnsFiltersConfiguredPrivateKey, data)
+}
+
 func (r *SettingIPSResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -486,6 +577,7 @@ func (r *SettingIPSResource) sdkToState(ctx context.Context, setting *unifi.Sett
 	var diags diag.Diagnostics
 
 	state.ID = types.StringValue(setting.ID)
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
 	state.SiteID = types.StringValue(setting.SiteID)
 	state.IPSMode = stringValueOrNull(setting.IPSMode)
 	state.DNSFiltering = types.BoolValue(derefBool(setting.DNSFiltering))
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -50,6 +51,67 @@ func TestAccSettingIPSResource_update(t *testing.T) {
 	})
 }
 
+func TestAccSettingIPSResource_restoreKeepsDNSFilters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingIPSResourceConfig_withDNSFilter(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_setting_ips.test", "on_destroy", "restore"),
+					resource.TestCheckResourceAttr("unifi_dns_filter.test", "filter", "work"),
+				),
+			},
+			// Destroying unifi_setting_ips restores the snapshot taken before
+			// the filter existed, which must not remove the filter. A removed
+			// filter would show up as a non-empty plan after this step.
+			{
+				Config: testAccSettingIPSResourceConfig_withDNSFilter(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_dns_filter.test", "filter", "work"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSettingIPSResourceConfig_withDNSFilter(withSetting bool) string {
+	setting := ""
+	dependsOn := ""
+	if withSetting {
+		setting = `
+resource "unifi_setting_ips" "test" {
+  ips_mode   = "disabled"
+  on_destroy = "restore"
+}
+`
+		dependsOn = "depends_on = [unifi_setting_ips.test]"
+	}
+
+	return fmt.Sprintf(`
+%s
+%s
+resource "unifi_network" "test" {
+  name         = "tf-acc-test-ips-restore"
+  purpose      = "corporate"
+  vlan_id      = 3992
+  subnet       = "10.192.0.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "10.192.0.10"
+  dhcp_stop    = "10.192.0.254"
+}
+
+resource "unifi_dns_filter" "test" {
+  network_id = unifi_network.test.id
+  filter     = "work"
+  name       = "tf-acc-test-ips-restore"
+
+  %s
+}
+`, testAccProviderConfig, setting, dependsOn)
+}
+
 func testAccSettingIPSResourceConfig_basic() string {
 	return testAccProviderConfig + `
 resource "unifi_setting_ips" "test" {
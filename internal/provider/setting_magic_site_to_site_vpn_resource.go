@@ -32,6 +32,7 @@ type SettingMagicSiteToSiteVPNResourceModel struct {
 	Enabled     types.Bool     `tfsdk:"enabled"`
 	PublicKey   types.String   `tfsdk:"public_key"`
 	XPrivateKey types.String   `tfsdk:"x_private_key"`
+	OnDestroy   types.String   `tfsdk:"on_destroy"`
 	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -46,7 +47,7 @@ func (r *SettingMagicSiteToSiteVPNResource) Metadata(ctx context.Context, req re
 func (r *SettingMagicSiteToSiteVPNResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages UniFi Magic Site-to-Site VPN settings. " +
-			"This is a singleton resource — one per site. Delete resets to defaults.",
+			"This is a singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -81,6 +82,7 @@ func (r *SettingMagicSiteToSiteVPNResource) Schema(ctx context.Context, req reso
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"on_destroy": onDestroyAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -123,6 +125,16 @@ func (r *SettingMagicSiteToSiteVPNResource) Create(ctx context.Context, req reso
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	original, err := r.client.GetSettingMagicSiteToSiteVPN(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "magic site-to-site VPN setting")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setting := r.planToSDK(&plan)
 	savedPrivateKey := plan.XPrivateKey
 
@@ -238,9 +250,19 @@ func (r *SettingMagicSiteToSiteVPNResource) Delete(ctx context.Context, req reso
 		defaults.ID = state.ID.ValueString()
 	}
 
-	_, err := r.client.UpdateSettingMagicSiteToSiteVPN(ctx, defaults)
+	setting := defaults
+	var original unifi.SettingMagicSiteToSiteVPN
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "magic site-to-site VPN setting", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		setting = &original
+	}
+
+	_, err := r.client.UpdateSettingMagicSiteToSiteVPN(ctx, setting)
 	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "reset", "magic site-to-site VPN setting")
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "magic site-to-site VPN setting")
 		return
 	}
 }
@@ -266,6 +288,7 @@ func (r *SettingMagicSiteToSiteVPNResource) sdkToState(setting *unifi.SettingMag
 	var diags diag.Diagnostics
 
 	state.ID = types.StringValue(setting.ID)
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
 	state.SiteID = types.StringValue(setting.SiteID)
 	state.Enabled = types.BoolValue(derefBool(setting.Enabled))
 	state.PublicKey = stringValueOrNull(setting.PublicKey)
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SettingMDNSDataSource{}
+
+type SettingMDNSDataSource struct {
+	client *AutoLoginClient
+}
+
+type SettingMDNSDataSourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	SiteID               types.String `tfsdk:"site_id"`
+	Mode                 types.String `tfsdk:"mode"`
+	EnabledForNetworkIDs types.Set    `tfsdk:"enabled_for_network_ids"`
+}
+
+func NewSettingMDNSDataSource() datasource.DataSource {
+	return &SettingMDNSDataSource{}
+}
+
+func (d *SettingMDNSDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_setting_mdns"
+}
+
+func (d *SettingMDNSDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the site-wide mDNS reflector settings (setting/mdns). Read-only.",
+		Attributes: map[string]schema.Attribute{
+			"id":      schema.StringAttribute{Computed: true},
+			"site_id": schema.StringAttribute{Computed: true},
+			"mode": schema.StringAttribute{
+				Description: "The mDNS reflector mode: 'auto', 'custom' or 'off'.",
+				Computed:    true,
+			},
+			"enabled_for_network_ids": schema.SetAttribute{
+				Description: "The IDs of the networks mDNS is reflected between. A network's mdns_enabled only takes effect when its ID is in this list.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *SettingMDNSDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *SettingMDNSDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	setting, err := d.client.GetSettingMDNS(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "mDNS setting")
+		return
+	}
+
+	var state SettingMDNSDataSourceModel
+	state.ID = types.StringValue(setting.ID)
+	state.SiteID = types.StringValue(setting.SiteID)
+	state.Mode = types.StringValue(setting.Mode)
+
+	networkIDs := setting.EnabledForNetworkIDs
+	if networkIDs == nil {
+		networkIDs = []string{}
+	}
+	ids, diags := types.SetValueFrom(ctx, types.StringType, networkIDs)
+	resp.Diagnostics.Append(diags...)
+	state.EnabledForNetworkIDs = ids
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
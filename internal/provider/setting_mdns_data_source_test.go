@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSettingMDNSDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingMDNSDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.unifi_setting_mdns.test", "id"),
+					resource.TestCheckResourceAttrSet("data.unifi_setting_mdns.test", "mode"),
+					resource.TestCheckResourceAttrSet("data.unifi_setting_mdns.test", "enabled_for_network_ids.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSettingMDNSDataSourceConfig() string {
+	return testAccProviderConfig + `
+data "unifi_setting_mdns" "test" {}
+`
+}
@@ -0,0 +1,380 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+var (
+	_ resource.Resource                   = &SettingMDNSResource{}
+	_ resource.ResourceWithImportState    = &SettingMDNSResource{}
+	_ resource.ResourceWithModifyPlan     = &SettingMDNSResource{}
+	_ resource.ResourceWithValidateConfig = &SettingMDNSResource{}
+)
+
+type SettingMDNSResource struct {
+	client *AutoLoginClient
+}
+
+type SettingMDNSResourceModel struct {
+	ID                   types.String   `tfsdk:"id"`
+	SiteID               types.String   `tfsdk:"site_id"`
+	Mode                 types.String   `tfsdk:"mode"`
+	EnabledForNetworkIDs types.Set      `tfsdk:"enabled_for_network_ids"`
+	OnDestroy            types.String   `tfsdk:"on_destroy"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewSettingMDNSResource() resource.Resource {
+	return &SettingMDNSResource{}
+}
+
+func (r *SettingMDNSResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_setting_mdns"
+}
+
+func (r *SettingMDNSResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the site-wide mDNS reflector settings (setting/mdns). " +
+			"This is a singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"site_id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: "The mDNS reflector mode: 'auto', 'custom' or 'off'. 'off' disables the reflector on every network.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("auto"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(mdnsModes...),
+				},
+			},
+			"enabled_for_network_ids": schema.SetAttribute{
+				Description: "The IDs of the networks mDNS is reflected between. A network's mdns_enabled only takes effect " +
+					"when its ID is in this list. Cannot be set when mode is 'off'. When unset, the list on the controller is kept.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"on_destroy": onDestroyAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *SettingMDNSResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *SettingMDNSResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config SettingMDNSResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Mode.IsUnknown() || config.EnabledForNetworkIDs.IsNull() {
+		return
+	}
+	if config.Mode.ValueString() == "off" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("enabled_for_network_ids"),
+			"Invalid mDNS configuration",
+			"enabled_for_network_ids cannot be set when mode is 'off', since the reflector serves no networks.",
+		)
+	}
+}
+
+// ModifyPlan marks an unconfigured enabled_for_network_ids as unknown when
+// mode changes, since the controller then rebuilds the list itself.
+func (r *SettingMDNSResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state, config SettingMDNSResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.EnabledForNetworkIDs.IsNull() && !plan.Mode.Equal(state.Mode) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("enabled_for_network_ids"), types.SetUnknown(types.StringType))...)
+	}
+}
+
+func (r *SettingMDNSResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SettingMDNSResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	original, err := r.client.GetSettingMDNS(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "mDNS setting")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting := r.planToSDK(ctx, &plan, original, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.client.UpdateSettingMDNS(ctx, setting)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "create", "mDNS setting")
+		return
+	}
+
+	resp.Diagnostics.Append(r.sdkToState(ctx, updated, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SettingMDNSResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SettingMDNSResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	setting, err := r.client.GetSettingMDNS(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "mDNS setting")
+		return
+	}
+
+	resp.Diagnostics.Append(r.sdkToState(ctx, setting, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *SettingMDNSResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SettingMDNSResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// An unset network list is sent back as the controller last reported it.
+	current := &unifi.SettingMDNS{}
+	if !state.EnabledForNetworkIDs.IsNull() {
+		resp.Diagnostics.Append(state.EnabledForNetworkIDs.ElementsAs(ctx, &current.EnabledForNetworkIDs, false)...)
+	}
+
+	setting := r.planToSDK(ctx, &plan, current, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.ID.IsNull() {
+		setting.ID = plan.ID.ValueString()
+	}
+
+	updated, err := r.client.UpdateSettingMDNS(ctx, setting)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "update", "mDNS setting")
+		return
+	}
+
+	resp.Diagnostics.Append(r.sdkToState(ctx, updated, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SettingMDNSResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SettingMDNSResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	defaults := &unifi.SettingMDNS{
+		Key:                  "mdns",
+		Mode:                 "auto",
+		EnabledForNetworkIDs: []string{},
+	}
+	if !state.ID.IsNull() {
+		defaults.ID = state.ID.ValueString()
+	}
+
+	setting := defaults
+	var original unifi.SettingMDNS
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "mDNS setting", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		setting = &original
+	}
+
+	_, err := r.client.UpdateSettingMDNS(ctx, setting)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "mDNS setting")
+		return
+	}
+}
+
+func (r *SettingMDNSResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// planToSDK builds the setting from plan. When enabled_for_network_ids is
+// unknown, because it is not configured, current's list is kept.
+func (r *SettingMDNSResource) planToSDK(ctx context.Context, plan *SettingMDNSResourceModel, current *unifi.SettingMDNS, diags *diag.Diagnostics) *unifi.SettingMDNS {
+	s := &unifi.SettingMDNS{
+		Key:                  "mdns",
+		Mode:                 plan.Mode.ValueString(),
+		EnabledForNetworkIDs: current.EnabledForNetworkIDs,
+	}
+
+	if !plan.EnabledForNetworkIDs.IsNull() && !plan.EnabledForNetworkIDs.IsUnknown() {
+		s.EnabledForNetworkIDs = nil
+		diags.Append(plan.EnabledForNetworkIDs.ElementsAs(ctx, &s.EnabledForNetworkIDs, false)...)
+		sort.Strings(s.EnabledForNetworkIDs)
+	}
+	if s.EnabledForNetworkIDs == nil {
+		s.EnabledForNetworkIDs = []string{}
+	}
+
+	return s
+}
+
+func (r *SettingMDNSResource) sdkToState(ctx context.Context, setting *unifi.SettingMDNS, state *SettingMDNSResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	state.ID = types.StringValue(setting.ID)
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
+	state.SiteID = types.StringValue(setting.SiteID)
+	state.Mode = types.StringValue(setting.Mode)
+
+	networkIDs := setting.EnabledForNetworkIDs
+	if networkIDs == nil {
+		networkIDs = []string{}
+	}
+	ids, d := types.SetValueFrom(ctx, types.StringType, networkIDs)
+	diags.Append(d...)
+	state.EnabledForNetworkIDs = ids
+
+	return diags
+}
+
+// mdnsModes are the values setting/mdns accepts for mode.
+var mdnsModes = []string{"auto", "custom", "off"}
+
+// mdnsReflectorGap explains why the site's mDNS reflector will not serve
+// the network with the given ID, or returns "" when it will. networkID is
+// empty for a network that has not been created yet.
+func mdnsReflectorGap(setting *unifi.SettingMDNS, networkID string) string {
+	if setting.Mode == "off" {
+		return "The site-wide mDNS reflector (unifi_setting_mdns) is off, so the controller will not keep mdns_enabled = true. " +
+			"Set its mode to 'auto' or 'custom' and add this network's ID to enabled_for_network_ids."
+	}
+	if networkID == "" {
+		return "A new network is not yet in enabled_for_network_ids of the site-wide mDNS reflector (unifi_setting_mdns), " +
+			"so the controller will not keep mdns_enabled = true when the network is created. Create the network with " +
+			"mdns_enabled unset, add its ID to enabled_for_network_ids, then enable mDNS in a later apply."
+	}
+	for _, id := range setting.EnabledForNetworkIDs {
+		if id == networkID {
+			return ""
+		}
+	}
+	return "This network is not in enabled_for_network_ids of the site-wide mDNS reflector (unifi_setting_mdns), " +
+		"so the controller will not keep mdns_enabled = true. Add the network's ID to enabled_for_network_ids first."
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func TestMDNSReflectorGap(t *testing.T) {
+	cases := []struct {
+		name      string
+		setting   unifi.SettingMDNS
+		networkID string
+		wantGap   bool
+	}{
+		{name: "listed", setting: unifi.SettingMDNS{Mode: "auto", EnabledForNetworkIDs: []string{"a", "b"}}, networkID: "b"},
+		{name: "listed custom", setting: unifi.SettingMDNS{Mode: "custom", EnabledForNetworkIDs: []string{"a"}}, networkID: "a"},
+		{name: "not listed", setting: unifi.SettingMDNS{Mode: "auto", EnabledForNetworkIDs: []string{"a"}}, networkID: "b", wantGap: true},
+		{name: "off", setting: unifi.SettingMDNS{Mode: "off", EnabledForNetworkIDs: []string{"a"}}, networkID: "a", wantGap: true},
+		{name: "new network", setting: unifi.SettingMDNS{Mode: "auto", EnabledForNetworkIDs: []string{"a"}}, wantGap: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := mdnsReflectorGap(&tc.setting, tc.networkID); (got != "") != tc.wantGap {
+				t.Fatalf("mdnsReflectorGap() = %q, wantGap %v", got, tc.wantGap)
+			}
+		})
+	}
+}
+
+func TestAccSettingMDNSResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingMDNSResourceConfig_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("unifi_setting_mdns.test", "id"),
+					resource.TestCheckResourceAttrSet("unifi_setting_mdns.test", "site_id"),
+					resource.TestCheckResourceAttr("unifi_setting_mdns.test", "mode", "auto"),
+					resource.TestCheckResourceAttrSet("unifi_setting_mdns.test", "enabled_for_network_ids.#"),
+				),
+			},
+			{
+				ResourceName:      "unifi_setting_mdns.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingMDNSResource_custom(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingMDNSResourceConfig_custom(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_setting_mdns.test", "mode", "custom"),
+					resource.TestCheckResourceAttr("unifi_setting_mdns.test", "enabled_for_network_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("unifi_setting_mdns.test", "enabled_for_network_ids.*", "unifi_network.test", "id"),
+				),
+			},
+			{
+				Config:   testAccSettingMDNSResourceConfig_custom(),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingMDNSResource_offWithNetworks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+resource "unifi_setting_mdns" "test" {
+  mode                    = "off"
+  enabled_for_network_ids = ["000000000000000000000000"]
+}
+`,
+				ExpectError: regexp.MustCompile(`cannot be set when mode is 'off'`),
+			},
+		},
+	})
+}
+
+func testAccSettingMDNSResourceConfig_basic() string {
+	return testAccProviderConfig + `
+resource "unifi_setting_mdns" "test" {
+  mode = "auto"
+}
+`
+}
+
+func testAccSettingMDNSResourceConfig_custom() string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_network" "test" {
+  name    = "tf-acc-test-mdns"
+  purpose = "vlan-only"
+  vlan_id = 3990
+}
+
+resource "unifi_setting_mdns" "test" {
+  mode                    = "custom"
+  enabled_for_network_ids = [unifi_network.test.id]
+}
+`, testAccProviderConfig)
+}
@@ -38,6 +38,7 @@ type SettingMgmtResourceModel struct {
 	XSSHAuthPasswordEnabled types.Bool     `tfsdk:"x_ssh_auth_password_enabled"`
 	XSSHUsername            types.String   `tfsdk:"x_ssh_username"`
 	XSSHPassword            types.String   `tfsdk:"x_ssh_password"`
+	OnDestroy               types.String   `tfsdk:"on_destroy"`
 	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -52,7 +53,7 @@ func (r *SettingMgmtResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *SettingMgmtResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages UniFi site management settings (auto-upgrade, LED, SSH, alerts). " +
-			"This is a singleton resource — one per site. Delete resets to defaults.",
+			"This is a singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -120,6 +121,7 @@ func (r *SettingMgmtResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"on_destroy": onDestroyAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -162,6 +164,16 @@ func (r *SettingMgmtResource) Create(ctx context.Context, req resource.CreateReq
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	original, err := r.client.GetSettingMgmt(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "management setting")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setting := r.planToSDK(&plan)
 	savedPassword := plan.XSSHPassword
 
@@ -280,9 +292,19 @@ func (r *SettingMgmtResource) Delete(ctx context.Context, req resource.DeleteReq
 		defaults.ID = state.ID.ValueString()
 	}
 
-	_, err := r.client.UpdateSettingMgmt(ctx, defaults)
+	setting := defaults
+	var original unifi.SettingMgmt
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "management setting", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		setting = &original
+	}
+
+	_, err := r.client.UpdateSettingMgmt(ctx, setting)
 	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "reset", "management setting")
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "management setting")
 		return
 	}
 }
@@ -319,6 +341,7 @@ func (r *SettingMgmtResource) sdkToState(setting *unifi.SettingMgmt, state *Sett
 	var diags diag.Diagnostics
 
 	state.ID = types.StringValue(setting.ID)
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
 	state.SiteID = types.StringValue(setting.SiteID)
 	state.AutoUpgrade = types.BoolValue(derefBool(setting.AutoUpgrade))
 	state.LEDEnabled = types.BoolValue(derefBool(setting.LEDEnabled))
@@ -40,6 +40,7 @@ type SettingRadiusResourceModel struct {
 	XSecret               types.String   `tfsdk:"x_secret"`
 	TunneledReply         types.Bool     `tfsdk:"tunneled_reply"`
 	InterimUpdateInterval types.Int64    `tfsdk:"interim_update_interval"`
+	OnDestroy             types.String   `tfsdk:"on_destroy"`
 	Timeouts              timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -53,7 +54,7 @@ func (r *SettingRadiusResource) Metadata(ctx context.Context, req resource.Metad
 
 func (r *SettingRadiusResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages UniFi site RADIUS server settings. Singleton resource — one per site. Delete resets to defaults.",
+		Description: "Manages UniFi site RADIUS server settings. Singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -116,6 +117,7 @@ func (r *SettingRadiusResource) Schema(ctx context.Context, req resource.SchemaR
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
+			"on_destroy": onDestroyAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -158,6 +160,16 @@ func (r *SettingRadiusResource) Create(ctx context.Context, req resource.CreateR
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	original, err := r.client.GetSettingRadius(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "RADIUS setting")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setting := r.planToSDK(&plan)
 	savedSecret := plan.XSecret
 
@@ -271,9 +283,19 @@ func (r *SettingRadiusResource) Delete(ctx context.Context, req resource.DeleteR
 		defaults.ID = state.ID.ValueString()
 	}
 
-	_, err := r.client.UpdateSettingRadius(ctx, defaults)
+	setting := defaults
+	var original unifi.SettingRadius
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "RADIUS setting", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		setting = &original
+	}
+
+	_, err := r.client.UpdateSettingRadius(ctx, setting)
 	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "reset", "RADIUS setting")
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "RADIUS setting")
 	}
 }
 
@@ -309,6 +331,7 @@ func (r *SettingRadiusResource) sdkToState(setting *unifi.SettingRadius, state *
 	var diags diag.Diagnostics
 
 	state.ID = types.StringValue(setting.ID)
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
 	state.SiteID = types.StringValue(setting.SiteID)
 	state.Enabled = types.BoolValue(derefBool(setting.Enabled))
 	state.AccountingEnabled = types.BoolValue(derefBool(setting.AccountingEnabled))
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values for the on_destroy attribute of singleton setting resources.
+const (
+	onDestroyRestore      = "restore"
+	onDestroyResetDefault = "reset_default"
+	onDestroyLeave        = "leave"
+)
+
+// originalSettingsPrivateKey is the private state key holding the JSON
+// snapshot of a singleton setting as it was before Terraform first wrote it.
+const originalSettingsPrivateKey = "original_settings"
+
+// privateStateGetter and privateStateSetter are satisfied by the Private
+// field of the framework's resource request and response types.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// onDestroyAttribute returns the on_destroy schema attribute shared by the
+// singleton setting resources. Singletons cannot be deleted on the
+// controller, so Delete instead writes back one of several configurations.
+func onDestroyAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "What Delete writes back to the controller. 'restore' puts back the values the controller had before " +
+			"Terraform first managed the setting; 'reset_default' writes the provider's defaults; 'leave' keeps the " +
+			"last applied values. Resources without those values, such as imported ones, are reset to the defaults by " +
+			"'restore'. Defaults to 'restore'.",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(onDestroyRestore),
+		Validators: []validator.String{
+			stringvalidator.OneOf(onDestroyRestore, onDestroyResetDefault, onDestroyLeave),
+		},
+	}
+}
+
+// onDestroyOrDefault fills in on_destroy for state written before the
+// attribute existed, and for imported resources.
+func onDestroyOrDefault(current types.String) types.String {
+	if current.IsNull() || current.IsUnknown() {
+		return types.StringValue(onDestroyRestore)
+	}
+	return current
+}
+
+// saveOriginalSettings snapshots the controller's current value of a setting
+// into private state. It is called from Create, before the first write.
+func saveOriginalSettings(ctx context.Context, private privateStateSetter, original any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		diags.AddError(
+			"Unable to save original settings",
+			fmt.Sprintf("Encoding the controller's current settings for restore on destroy failed: %s", err),
+		)
+		return diags
+	}
+
+	diags.Append(private.SetKey(ctx, originalSettingsPrivateKey, data)...)
+	return diags
+}
+
+// settingDestroyAction resolves on_destroy into the action Delete should
+// take. For onDestroyRestore the saved snapshot is decoded into original.
+// Resources without a snapshot (imported, or created by an older provider
+// version) fall back to onDestroyResetDefault with a warning, so a destroy
+// never leaves Terraform's last values behind unannounced.
+func settingDestroyAction(ctx context.Context, onDestroy types.String, private privateStateGetter, original any, resourceType string, diags *diag.Diagnostics) string {
+	switch onDestroyOrDefault(onDestroy).ValueString() {
+	case onDestroyLeave:
+		return onDestroyLeave
+	case onDestroyResetDefault:
+		return onDestroyResetDefault
+	}
+
+	data, d := private.GetKey(ctx, originalSettingsPrivateKey)
+	diags.Append(d...)
+	if diags.HasError() {
+		return onDestroyLeave
+	}
+
+	if len(data) == 0 {
+		diags.AddWarning(
+			"Original settings not available",
+			fmt.Sprintf("No snapshot of the %s from before Terraform managed it was found, so the provider's defaults were written instead. "+
+				"Snapshots are only taken when the resource is created; imported resources and resources created by older "+
+				"provider versions have none. Use on_destroy = \"leave\" to keep the current values instead.", resourceType),
+		)
+		return onDestroyResetDefault
+	}
+
+	if err := json.Unmarshal(data, original); err != nil {
+		diags.AddError(
+			"Unable to restore original settings",
+			fmt.Sprintf("Decoding the saved %s snapshot failed: %s", resourceType, err),
+		)
+		return onDestroyLeave
+	}
+
+	return onDestroyRestore
+}
+
+// settingDestroyOperation names the write Delete makes for action, for use in
+// error messages.
+func settingDestroyOperation(action string) string {
+	if action == onDestroyRestore {
+		return "restore"
+	}
+	return "reset"
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakePrivateState stands in for the framework's private state in unit tests.
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+type testSetting struct {
+	ID        string `json:"_id"`
+	Community string `json:"community"`
+	Enabled   *bool  `json:"enabled,omitempty"`
+}
+
+func TestSettingDestroyAction(t *testing.T) {
+	ctx := context.Background()
+	saved := fakePrivateState{}
+	diags := saveOriginalSettings(ctx, saved, &testSetting{ID: "abc", Community: "public", Enabled: boolPtr(true)})
+	if diags.HasError() {
+		t.Fatalf("saveOriginalSettings: %v", diags)
+	}
+
+	cases := []struct {
+		name        string
+		onDestroy   types.String
+		private     fakePrivateState
+		want        string
+		wantWarning bool
+	}{
+		{name: "restore", onDestroy: types.StringValue(onDestroyRestore), private: saved, want: onDestroyRestore},
+		{name: "null defaults to restore", onDestroy: types.StringNull(), private: saved, want: onDestroyRestore},
+		{name: "restore without snapshot", onDestroy: types.StringValue(onDestroyRestore), private: fakePrivateState{}, want: onDestroyResetDefault, wantWarning: true},
+		{name: "reset_default", onDestroy: types.StringValue(onDestroyResetDefault), private: saved, want: onDestroyResetDefault},
+		{name: "leave", onDestroy: types.StringValue(onDestroyLeave), private: saved, want: onDestroyLeave},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			var original testSetting
+			got := settingDestroyAction(ctx, tc.onDestroy, tc.private, &original, "test setting", &diags)
+			if got != tc.want {
+				t.Fatalf("settingDestroyAction = %q, want %q", got, tc.want)
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if gotWarning := diags.WarningsCount() > 0; gotWarning != tc.wantWarning {
+				t.Fatalf("warning = %v, want %v (%v)", gotWarning, tc.wantWarning, diags)
+			}
+			if got == onDestroyRestore {
+				if original.ID != "abc" || original.Community != "public" || !derefBool(original.Enabled) {
+					t.Fatalf("restored snapshot = %+v, want the saved setting", original)
+				}
+			}
+		})
+	}
+}
+
+func TestSettingDestroyActionCorruptSnapshot(t *testing.T) {
+	var diags diag.Diagnostics
+	var original testSetting
+	private := fakePrivateState{originalSettingsPrivateKey: []byte(`{"community": 5}`)}
+	got := settingDestroyAction(context.Background(), types.StringValue(onDestroyRestore), private, &original, "test setting", &diags)
+	if got != onDestroyLeave || !diags.HasError() {
+		t.Fatalf("settingDestroyAction = %q with diags %v, want leave with an error", got, diags)
+	}
+}
@@ -34,6 +34,7 @@ type SettingSNMPResourceModel struct {
 	EnabledV3 types.Bool     `tfsdk:"enabled_v3"`
 	Username  types.String   `tfsdk:"username"`
 	XPassword types.String   `tfsdk:"x_password"`
+	OnDestroy types.String   `tfsdk:"on_destroy"`
 	Timeouts  timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -48,7 +49,7 @@ func (r *SettingSNMPResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *SettingSNMPResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages UniFi SNMP settings. " +
-			"This is a singleton resource — one per site. Delete resets to defaults.",
+			"This is a singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -98,6 +99,7 @@ func (r *SettingSNMPResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"on_destroy": onDestroyAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -140,6 +142,16 @@ func (r *SettingSNMPResource) Create(ctx context.Context, req resource.CreateReq
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	original, err := r.client.GetSettingSNMP(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "SNMP setting")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setting := r.planToSDK(&plan)
 	savedPassword := plan.XPassword
 
@@ -258,9 +270,19 @@ func (r *SettingSNMPResource) Delete(ctx context.Context, req resource.DeleteReq
 		defaults.ID = state.ID.ValueString()
 	}
 
-	_, err := r.client.UpdateSettingSNMP(ctx, defaults)
+	setting := defaults
+	var original unifi.SettingSNMP
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "SNMP setting", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		setting = &original
+	}
+
+	_, err := r.client.UpdateSettingSNMP(ctx, setting)
 	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "reset", "SNMP setting")
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "SNMP setting")
 		return
 	}
 }
@@ -293,6 +315,7 @@ func (r *SettingSNMPResource) sdkToState(setting *unifi.SettingSNMP, state *Sett
 	var diags diag.Diagnostics
 
 	state.ID = types.StringValue(setting.ID)
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
 	state.SiteID = types.StringValue(setting.SiteID)
 	state.Enabled = types.BoolValue(derefBool(setting.Enabled))
 	state.Community = stringValueOrNull(setting.Community)
@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
 )
 
 func TestAccSettingSNMPResource_basic(t *testing.T) {
@@ -54,6 +58,42 @@ func TestAccSettingSNMPResource_update(t *testing.T) {
 	})
 }
 
+func TestAccSettingSNMPResource_restoreOnDestroy(t *testing.T) {
+	var original *unifi.SettingSNMP
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			setting, err := testAccGetClient(t).GetSettingSNMP(context.Background())
+			if err != nil {
+				t.Fatalf("reading SNMP setting before test: %v", err)
+			}
+			original = setting
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			setting, err := testAccGetClient(t).GetSettingSNMP(context.Background())
+			if err != nil {
+				return fmt.Errorf("reading SNMP setting after destroy: %w", err)
+			}
+			if derefBool(setting.Enabled) != derefBool(original.Enabled) || setting.Community != original.Community {
+				return fmt.Errorf("SNMP setting not restored: enabled=%v community=%q, want enabled=%v community=%q",
+					derefBool(setting.Enabled), setting.Community, derefBool(original.Enabled), original.Community)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingSNMPResourceConfig_restore(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_setting_snmp.test", "on_destroy", "restore"),
+					resource.TestCheckResourceAttr("unifi_setting_snmp.test", "community", "tf-acc-restore"),
+				),
+			},
+		},
+	})
+}
+
 func testAccSettingSNMPResourceConfig_basic() string {
 	return testAccProviderConfig + `
 resource "unifi_setting_snmp" "test" {
@@ -73,3 +113,12 @@ resource "unifi_setting_snmp" "test" {
 }
 `
 }
+
+func testAccSettingSNMPResourceConfig_restore() string {
+	return testAccProviderConfig + `
+resource "unifi_setting_snmp" "test" {
+  enabled   = true
+  community = "tf-acc-restore"
+}
+`
+}
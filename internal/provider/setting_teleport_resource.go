@@ -31,6 +31,7 @@ type SettingTeleportResourceModel struct {
 	SiteID     types.String   `tfsdk:"site_id"`
 	Enabled    types.Bool     `tfsdk:"enabled"`
 	SubnetCIDR types.String   `tfsdk:"subnet_cidr"`
+	OnDestroy  types.String   `tfsdk:"on_destroy"`
 	Timeouts   timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -45,7 +46,7 @@ func (r *SettingTeleportResource) Metadata(ctx context.Context, req resource.Met
 func (r *SettingTeleportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages UniFi Teleport settings. " +
-			"This is a singleton resource — one per site. Delete resets to defaults.",
+			"This is a singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -73,6 +74,7 @@ func (r *SettingTeleportResource) Schema(ctx context.Context, req resource.Schem
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"on_destroy": onDestroyAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -115,6 +117,16 @@ func (r *SettingTeleportResource) Create(ctx context.Context, req resource.Creat
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	original, err := r.client.GetSettingTeleport(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "teleport setting")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setting := r.planToSDK(&plan)
 
 	updated, err := r.client.UpdateSettingTeleport(ctx, setting)
@@ -230,9 +242,19 @@ func (r *SettingTeleportResource) Delete(ctx context.Context, req resource.Delet
 		defaults.ID = state.ID.ValueString()
 	}
 
-	_, err := r.client.UpdateSettingTeleport(ctx, defaults)
+	setting := defaults
+	var original unifi.SettingTeleport
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "teleport setting", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		setting = &original
+	}
+
+	_, err := r.client.UpdateSettingTeleport(ctx, setting)
 	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "reset", "teleport setting")
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "teleport setting")
 		return
 	}
 }
@@ -258,6 +280,7 @@ func (r *SettingTeleportResource) sdkToState(setting *unifi.SettingTeleport, sta
 	var diags diag.Diagnostics
 
 	state.ID = types.StringValue(setting.ID)
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
 	state.SiteID = types.StringValue(setting.SiteID)
 	state.Enabled = types.BoolValue(derefBool(setting.Enabled))
 	state.SubnetCIDR = stringValueOrNull(setting.SubnetCIDR)
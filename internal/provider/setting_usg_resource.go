@@ -50,6 +50,7 @@ type SettingUSGResourceModel struct {
 	UPnPEnabled       types.Bool     `tfsdk:"upnp_enabled"`
 	UPnPNATPMPEnabled types.Bool     `tfsdk:"upnp_nat_pmp_enabled"`
 	UPnPSecureMode    types.Bool     `tfsdk:"upnp_secure_mode"`
+	OnDestroy         types.String   `tfsdk:"on_destroy"`
 	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -63,7 +64,7 @@ func (r *SettingUSGResource) Metadata(ctx context.Context, req resource.Metadata
 
 func (r *SettingUSGResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages UniFi gateway/security settings. Singleton resource — one per site. Delete resets to defaults.",
+		Description: "Manages UniFi gateway/security settings. Singleton resource — one per site. Delete behaviour is controlled by on_destroy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -144,6 +145,7 @@ func (r *SettingUSGResource) Schema(ctx context.Context, req resource.SchemaRequ
 			"upnp_secure_mode": schema.BoolAttribute{
 				Optional: true, Computed: true, Default: booldefault.StaticBool(false),
 			},
+			"on_destroy": onDestroyAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -186,6 +188,16 @@ func (r *SettingUSGResource) Create(ctx context.Context, req resource.CreateRequ
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	original, err := r.client.GetSettingUSG(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "read", "USG setting")
+		return
+	}
+	resp.Diagnostics.Append(saveOriginalSettings(ctx, resp.Private, original)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setting := r.planToSDK(&plan)
 	updated, err := r.client.UpdateSettingUSG(ctx, setting)
 	if err != nil {
@@ -292,9 +304,19 @@ func (r *SettingUSGResource) Delete(ctx context.Context, req resource.DeleteRequ
 		defaults.ID = state.ID.ValueString()
 	}
 
-	_, err := r.client.UpdateSettingUSG(ctx, defaults)
+	setting := defaults
+	var original unifi.SettingUSG
+	action := settingDestroyAction(ctx, state.OnDestroy, req.Private, &original, "USG setting", &resp.Diagnostics)
+	switch action {
+	case onDestroyLeave:
+		return
+	case onDestroyRestore:
+		setting = &original
+	}
+
+	_, err := r.client.UpdateSettingUSG(ctx, setting)
 	if err != nil {
-		handleSDKError(&resp.Diagnostics, err, "reset", "USG setting")
+		handleSDKError(&resp.Diagnostics, err, settingDestroyOperation(action), "USG setting")
 	}
 }
 
@@ -341,6 +363,7 @@ func (r *SettingUSGResource) sdkToState(setting *unifi.SettingUSG, state *Settin
 	var diags diag.Diagnostics
 
 	state.ID = types.StringValue(setting.ID)
+	state.OnDestroy = onDestroyOrDefault(state.OnDestroy)
 	state.SiteID = types.StringValue(setting.SiteID)
 	state.BroadcastPing = types.BoolValue(derefBool(setting.BroadcastPing))
 	state.DHCPDUseDnsmasq = types.BoolValue(derefBool(setting.DHCPDUseDnsmasq))
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -26,10 +27,11 @@ type SiteResource struct {
 }
 
 type SiteResourceModel struct {
-	ID          types.String   `tfsdk:"id"`
-	Name        types.String   `tfsdk:"name"`
-	Description types.String   `tfsdk:"description"`
-	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewSiteResource() resource.Resource {
@@ -59,6 +61,12 @@ func (r *SiteResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description: "The human-readable description/name of the site.",
 				Required:    true,
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether the site is protected from deletion. While true, destroying or replacing the site fails; set to false and apply before destroying. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -196,6 +204,10 @@ func (r *SiteResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	if checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "site", state.Description.ValueString()) {
+		return
+	}
+
 	deleteTimeout, diags := state.Timeouts.Delete(ctx, 10*time.Minute)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -224,6 +236,7 @@ func (r *SiteResource) sdkToState(site *unifi.NetworkSite, state *SiteResourceMo
 	state.ID = types.StringValue(site.ID)
 	state.Name = types.StringValue(site.Name)
 	state.Description = types.StringValue(site.Desc)
+	state.DeletionProtection = deletionProtectionDefault(state.DeletionProtection, true)
 
 	return diags
 }
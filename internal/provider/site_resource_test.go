@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -18,6 +19,7 @@ func TestAccSiteResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("unifi_site.test", "id"),
 					resource.TestCheckResourceAttrSet("unifi_site.test", "name"),
 					resource.TestCheckResourceAttr("unifi_site.test", "description", "tf-acc-test-site"),
+					resource.TestCheckResourceAttr("unifi_site.test", "deletion_protection", "false"),
 				),
 			},
 			{
@@ -50,10 +52,49 @@ func TestAccSiteResource_update(t *testing.T) {
 	})
 }
 
+func TestAccSiteResource_deletionProtection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Protection is on by default
+			{
+				Config: testAccSiteResourceConfig_defaultProtection("tf-acc-test-site-protected"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_site.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccSiteResourceConfig_defaultProtection("tf-acc-test-site-protected"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion protection enabled`),
+			},
+			// Disable protection so the post-test destroy succeeds
+			{
+				Config: testAccSiteResourceConfig("tf-acc-test-site-protected"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_site.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
 func testAccSiteResourceConfig(desc string) string {
 	return fmt.Sprintf(`
 %s
 
+resource "unifi_site" "test" {
+  description         = %q
+  deletion_protection = false
+}
+`, testAccProviderConfig, desc)
+}
+
+func testAccSiteResourceConfig_defaultProtection(desc string) string {
+	return fmt.Sprintf(`
+%s
+
 resource "unifi_site" "test" {
   description = %q
 }
@@ -41,6 +41,8 @@ type StaticDNSResourceModel struct {
 	Priority   types.Int64    `tfsdk:"priority"`
 	Weight     types.Int64    `tfsdk:"weight"`
 	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
 }
 
 func NewStaticDNSResource() resource.Resource {
@@ -85,6 +87,7 @@ func (r *StaticDNSResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringvalidator.OneOf("A", "AAAA", "CNAME", "MX", "NS", "TXT", "SRV"),
 				},
 			},
+			"adopt_existing": adoptExistingAttribute("record", "key and record_type"),
 			"enabled": schema.BoolAttribute{
 				Description: "Whether the DNS record is enabled. Defaults to true.",
 				Optional:    true,
@@ -166,6 +169,9 @@ func (r *StaticDNSResource) Create(ctx context.Context, req resource.CreateReque
 	dns := r.planToSDK(&plan)
 
 	created, err := r.client.CreateStaticDNS(ctx, dns)
+	if err != nil && r.client.shouldAdoptExisting(err, plan.AdoptExisting) {
+		created, err = r.adoptExisting(ctx, dns, &resp.Diagnostics)
+	}
 	if err != nil {
 		handleSDKError(&resp.Diagnostics, err, "create", "static DNS record")
 		return
@@ -179,6 +185,29 @@ func (r *StaticDNSResource) Create(ctx context.Context, req resource.CreateReque
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// adoptExisting takes over an existing record with the planned key and
+// record type after Create was rejected as a conflict.
+func (r *StaticDNSResource) adoptExisting(ctx context.Context, dns *unifi.StaticDNS, diags *diag.Diagnostics) (*unifi.StaticDNS, error) {
+	records, err := r.client.ListStaticDNS(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := findStaticDNSByKey(records, dns.Key, dns.RecordType)
+	if existing == nil {
+		return nil, fmt.Errorf("the controller reported a conflict for %s record %q but no such record was found: %w", dns.RecordType, dns.Key, unifi.ErrConflict)
+	}
+
+	dns.ID = existing.ID
+	updated, err := r.client.UpdateStaticDNS(ctx, existing.ID, dns)
+	if err != nil {
+		return nil, err
+	}
+
+	addAdoptedWarning(diags, "static DNS record", fmt.Sprintf("key %q and type %s", dns.Key, dns.RecordType), existing.ID)
+	return updated, nil
+}
+
 func (r *StaticDNSResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state StaticDNSResourceModel
 
@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// zoneRecordTypes lists the record types unifi_static_dns_zone accepts, in
+// the order they are named in error messages.
+var zoneRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "SRV", "TXT"}
+
+type zoneToken struct {
+	text   string
+	quoted bool
+}
+
+// zoneEntry is one logical line of a zone file: a directive or a resource
+// record, with parenthesised continuations joined and comments removed.
+type zoneEntry struct {
+	line       int
+	blankOwner bool
+	tokens     []zoneToken
+}
+
+// tokenizeZone splits zone text into entries. Quoted strings keep their
+// spaces and semicolons, and backslash escapes the next character.
+func tokenizeZone(text string) ([]zoneEntry, error) {
+	var entries []zoneEntry
+	var cur zoneEntry
+	depth := 0
+
+	for n, raw := range strings.Split(text, "\n") {
+		if depth == 0 {
+			cur = zoneEntry{
+				line:       n + 1,
+				blankOwner: len(raw) > 0 && (raw[0] == ' ' || raw[0] == '\t'),
+			}
+		}
+
+		for i := 0; i < len(raw); {
+			switch c := raw[i]; c {
+			case ';':
+				i = len(raw)
+			case ' ', '\t', '\r':
+				i++
+			case '(':
+				depth++
+				i++
+			case ')':
+				if depth == 0 {
+					return nil, fmt.Errorf("line %d: unbalanced parenthesis", n+1)
+				}
+				depth--
+				i++
+			case '"':
+				var b strings.Builder
+				closed := false
+				for i++; i < len(raw); i++ {
+					if raw[i] == '\\' && i+1 < len(raw) {
+						i++
+						b.WriteByte(raw[i])
+						continue
+					}
+					if raw[i] == '"' {
+						closed = true
+						i++
+						break
+					}
+					b.WriteByte(raw[i])
+				}
+				if !closed {
+					return nil, fmt.Errorf("line %d: unterminated quoted string", n+1)
+				}
+				cur.tokens = append(cur.tokens, zoneToken{text: b.String(), quoted: true})
+			default:
+				start := i
+				for i < len(raw) && !strings.ContainsRune(" \t\r;()\"", rune(raw[i])) {
+					i++
+				}
+				cur.tokens = append(cur.tokens, zoneToken{text: raw[start:i]})
+			}
+		}
+
+		if depth == 0 && len(cur.tokens) > 0 {
+			entries = append(entries, cur)
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parenthesis", cur.line)
+	}
+
+	return entries, nil
+}
+
+// parseZoneTTL parses a TTL in seconds, also accepting the BIND unit suffixes
+// s, m, h, d and w (e.g. "1h30m").
+func parseZoneTTL(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, n >= 0
+	}
+
+	total, digits := 0, ""
+	for _, c := range strings.ToLower(s) {
+		if c >= '0' && c <= '9' {
+			digits += string(c)
+			continue
+		}
+		if digits == "" {
+			return 0, false
+		}
+		n, _ := strconv.Atoi(digits)
+		switch c {
+		case 's':
+		case 'm':
+			n *= 60
+		case 'h':
+			n *= 3600
+		case 'd':
+			n *= 86400
+		case 'w':
+			n *= 604800
+		default:
+			return 0, false
+		}
+		total += n
+		digits = ""
+	}
+	if digits != "" {
+		return 0, false
+	}
+	return total, true
+}
+
+// zoneName resolves an owner or target name against origin. "@" is the
+// origin itself, a name ending in a dot is absolute and anything else is
+// relative to origin. The result has no trailing dot.
+func zoneName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	case origin == "":
+		return name
+	default:
+		return name + "." + origin
+	}
+}
+
+func isZoneClass(s string) bool {
+	switch strings.ToUpper(s) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
+
+// parseZone parses RFC 1035 zone text into static DNS records. Names are
+// resolved against origin until a $ORIGIN directive changes it. A record
+// without a TTL takes the last $TTL, then defaultTTL, then the controller's
+// default. SOA records and NS records at the origin describe the zone
+// itself and are skipped; any other type outside zoneRecordTypes is an error.
+func parseZone(origin, text string, defaultTTL *int) ([]unifi.StaticDNS, error) {
+	entries, err := tokenizeZone(text)
+	if err != nil {
+		return nil, err
+	}
+
+	origin = strings.TrimSuffix(origin, ".")
+	apex := origin
+	ttl := defaultTTL
+	owner := ""
+	seen := make(map[string]int)
+
+	var records []unifi.StaticDNS
+	for _, e := range entries {
+		tokens := e.tokens
+
+		if !tokens[0].quoted && strings.HasPrefix(tokens[0].text, "$") {
+			directive := strings.ToUpper(tokens[0].text)
+			switch directive {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN takes one domain name", e.line)
+				}
+				origin = zoneName(tokens[1].text, origin)
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $TTL takes one value", e.line)
+				}
+				n, ok := parseZoneTTL(tokens[1].text)
+				if !ok {
+					return nil, fmt.Errorf("line %d: invalid $TTL %q", e.line, tokens[1].text)
+				}
+				ttl = intPtr(int64(n))
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive %s", e.line, tokens[0].text)
+			}
+			continue
+		}
+
+		if !e.blankOwner {
+			owner = zoneName(tokens[0].text, origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record has no owner name", e.line)
+		}
+
+		recordTTL := ttl
+		for len(tokens) > 0 && !tokens[0].quoted {
+			if isZoneClass(tokens[0].text) {
+				tokens = tokens[1:]
+				continue
+			}
+			if n, ok := parseZoneTTL(tokens[0].text); ok {
+				recordTTL = intPtr(int64(n))
+				tokens = tokens[1:]
+				continue
+			}
+			break
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: record for %s has no type", e.line, owner)
+		}
+
+		recordType := strings.ToUpper(tokens[0].text)
+		rdata := tokens[1:]
+		record := unifi.StaticDNS{
+			Key:        owner,
+			RecordType: recordType,
+			Enabled:    boolPtr(true),
+			TTL:        recordTTL,
+		}
+
+		wantFields := func(n int, form string) error {
+			if len(rdata) != n {
+				return fmt.Errorf("line %d: %s record for %s must be %s", e.line, recordType, owner, form)
+			}
+			return nil
+		}
+
+		switch recordType {
+		case "SOA":
+			continue
+		case "NS":
+			if strings.EqualFold(owner, apex) {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: NS delegation for %s is not supported; use unifi_static_dns", e.line, owner)
+		case "A":
+			if err := wantFields(1, "an IPv4 address"); err != nil {
+				return nil, err
+			}
+			if ip := net.ParseIP(rdata[0].text); ip == nil || ip.To4() == nil {
+				return nil, fmt.Errorf("line %d: %q is not an IPv4 address", e.line, rdata[0].text)
+			}
+			record.Value = rdata[0].text
+		case "AAAA":
+			if err := wantFields(1, "an IPv6 address"); err != nil {
+				return nil, err
+			}
+			if !isIPv6Address(rdata[0].text) {
+				return nil, fmt.Errorf("line %d: %q is not an IPv6 address", e.line, rdata[0].text)
+			}
+			record.Value = rdata[0].text
+		case "CNAME":
+			if err := wantFields(1, "a target name"); err != nil {
+				return nil, err
+			}
+			record.Value = zoneName(rdata[0].text, origin)
+		case "MX":
+			if err := wantFields(2, "a preference and a mail exchanger"); err != nil {
+				return nil, err
+			}
+			pref, err := strconv.ParseUint(rdata[0].text, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid MX preference %q", e.line, rdata[0].text)
+			}
+			record.Priority = intPtr(int64(pref))
+			record.Value = zoneName(rdata[1].text, origin)
+		case "SRV":
+			if err := wantFields(4, "a priority, weight, port and target"); err != nil {
+				return nil, err
+			}
+			var fields [3]int
+			for i, name := range []string{"priority", "weight", "port"} {
+				n, err := strconv.ParseUint(rdata[i].text, 10, 16)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid SRV %s %q", e.line, name, rdata[i].text)
+				}
+				fields[i] = int(n)
+			}
+			if fields[2] == 0 {
+				return nil, fmt.Errorf("line %d: SRV port for %s must be between 1 and 65535", e.line, owner)
+			}
+			record.Priority = intPtr(int64(fields[0]))
+			record.Weight = intPtr(int64(fields[1]))
+			record.Port = intPtr(int64(fields[2]))
+			record.Value = zoneName(rdata[3].text, origin)
+		case "TXT":
+			if len(rdata) == 0 {
+				return nil, fmt.Errorf("line %d: TXT record for %s has no text", e.line, owner)
+			}
+			var b strings.Builder
+			for _, t := range rdata {
+				b.WriteString(t.text)
+			}
+			record.Value = b.String()
+		default:
+			return nil, fmt.Errorf("line %d: unsupported record type %s for %s. Supported types: %s",
+				e.line, tokens[0].text, owner, strings.Join(zoneRecordTypes, ", "))
+		}
+
+		id := strings.ToLower(record.Key) + " " + record.RecordType + " " + record.Value
+		if prev, ok := seen[id]; ok {
+			return nil, fmt.Errorf("line %d: duplicate %s record for %s, first defined on line %d", e.line, recordType, owner, prev)
+		}
+		seen[id] = e.line
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// zoneRecordPlan is the outcome of matching the records of a zone against
+// the records a unifi_static_dns_zone already owns on the controller.
+type zoneRecordPlan struct {
+	// Records are the zone's records in zone order. ID is set for records
+	// matched to an owned record and empty for records to create.
+	Records []unifi.StaticDNS
+	// Update holds the indexes into Records whose owned record differs.
+	Update map[int]bool
+	// Delete holds the IDs of owned records that are no longer in the zone.
+	Delete []string
+}
+
+// planZoneRecords matches desired records to owned ones. A record is first
+// matched on key, type and value, then on key and type alone so that a
+// changed value updates the record in place. Owned records left unmatched
+// are deleted.
+func planZoneRecords(desired, owned []unifi.StaticDNS) zoneRecordPlan {
+	plan := zoneRecordPlan{
+		Records: make([]unifi.StaticDNS, len(desired)),
+		Update:  make(map[int]bool),
+	}
+	copy(plan.Records, desired)
+	used := make([]bool, len(owned))
+
+	match := func(sameValue bool) {
+		for i := range plan.Records {
+			rec := &plan.Records[i]
+			if rec.ID != "" {
+				continue
+			}
+			for j := range owned {
+				if used[j] || !strings.EqualFold(owned[j].Key, rec.Key) || owned[j].RecordType != rec.RecordType {
+					continue
+				}
+				if sameValue && owned[j].Value != rec.Value {
+					continue
+				}
+				used[j] = true
+				rec.ID = owned[j].ID
+				if !zoneRecordMatches(&owned[j], rec) {
+					plan.Update[i] = true
+				}
+				break
+			}
+		}
+	}
+	match(true)
+	match(false)
+
+	for j := range owned {
+		if !used[j] {
+			plan.Delete = append(plan.Delete, owned[j].ID)
+		}
+	}
+
+	return plan
+}
+
+// zoneRecordMatches reports whether an owned record already holds want. A
+// record without a TTL leaves the controller's TTL alone.
+func zoneRecordMatches(existing, want *unifi.StaticDNS) bool {
+	return existing.Key == want.Key &&
+		existing.Value == want.Value &&
+		derefBool(existing.Enabled) == derefBool(want.Enabled) &&
+		(want.TTL == nil || derefInt(existing.TTL) == derefInt(want.TTL)) &&
+		derefInt(existing.Priority) == derefInt(want.Priority) &&
+		derefInt(existing.Weight) == derefInt(want.Weight) &&
+		derefInt(existing.Port) == derefInt(want.Port)
+}
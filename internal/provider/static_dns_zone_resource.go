@@ -0,0 +1,549 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+var (
+	_ resource.Resource                   = &StaticDNSZoneResource{}
+	_ resource.ResourceWithModifyPlan     = &StaticDNSZoneResource{}
+	_ resource.ResourceWithValidateConfig = &StaticDNSZoneResource{}
+)
+
+type StaticDNSZoneResource struct {
+	client *AutoLoginClient
+}
+
+type StaticDNSZoneResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	Origin     types.String   `tfsdk:"origin"`
+	Zone       types.String   `tfsdk:"zone"`
+	DefaultTTL types.Int64    `tfsdk:"default_ttl"`
+	Records    types.List     `tfsdk:"records"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+var zoneRecordAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"key":         types.StringType,
+	"record_type": types.StringType,
+	"value":       types.StringType,
+	"ttl":         types.Int64Type,
+	"priority":    types.Int64Type,
+	"weight":      types.Int64Type,
+	"port":        types.Int64Type,
+}
+
+func NewStaticDNSZoneResource() resource.Resource {
+	return &StaticDNSZoneResource{}
+}
+
+func (r *StaticDNSZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_static_dns_zone"
+}
+
+func (r *StaticDNSZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of static DNS records from RFC 1035 zone file text. A, AAAA, CNAME, MX, SRV and TXT " +
+			"records are supported. The records are refreshed with a single list call and only those that differ from " +
+			"the zone are written. Records not created by this resource are left alone; do not manage the same record " +
+			"with unifi_static_dns.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the resource. The origin without a trailing dot.",
+				Computed:    true,
+			},
+			"origin": schema.StringAttribute{
+				Description: "The domain relative names in zone are resolved against, such as 'home.example.com'. " +
+					"A $ORIGIN directive in zone overrides it for the records that follow.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Description: "The zone file text. $ORIGIN and $TTL directives, '@', relative and absolute names, comments, " +
+					"parentheses and quoted strings are understood. SOA records and NS records at the origin are ignored.",
+				Required: true,
+			},
+			"default_ttl": schema.Int64Attribute{
+				Description: "The TTL in seconds for records that set none and are not covered by a $TTL directive. " +
+					"When unset, such records get the controller's default.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "The static DNS records managed for the zone, in zone order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the static DNS record.",
+							Computed:    true,
+						},
+						"key": schema.StringAttribute{
+							Description: "The fully qualified hostname of the record.",
+							Computed:    true,
+						},
+						"record_type": schema.StringAttribute{
+							Description: "The DNS record type.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "The address, target hostname or text of the record.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Time to live in seconds.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "The preference of an MX record or the priority of an SRV record.",
+							Computed:    true,
+						},
+						"weight": schema.Int64Attribute{
+							Description: "The weight of an SRV record.",
+							Computed:    true,
+						},
+						"port": schema.Int64Attribute{
+							Description: "The port of an SRV record.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *StaticDNSZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig parses zone so that syntax errors and unsupported record
+// types fail at plan time.
+func (r *StaticDNSZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config StaticDNSZoneResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Origin.IsUnknown() || config.Zone.IsUnknown() || config.DefaultTTL.IsUnknown() {
+		return
+	}
+
+	if _, err := config.parse(); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("zone"), "Invalid zone file", err.Error())
+	}
+}
+
+// ModifyPlan plans records from the zone, matched against the records in
+// state, so that record changes and drift show in the plan.
+func (r *StaticDNSZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan StaticDNSZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Origin.IsUnknown() || plan.Zone.IsUnknown() || plan.DefaultTTL.IsUnknown() {
+		return
+	}
+
+	desired, err := plan.parse()
+	if err != nil {
+		return
+	}
+
+	var owned []unifi.StaticDNS
+	if !req.State.Raw.IsNull() {
+		var state StaticDNSZoneResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		owned = zoneRecordsFromList(state.Records)
+	}
+
+	planned := planZoneRecords(desired, owned)
+	for i := range planned.Records {
+		rec := &planned.Records[i]
+		if rec.ID == "" || rec.TTL != nil {
+			continue
+		}
+		rec.TTL = intPtr(0)
+		for j := range owned {
+			if owned[j].ID == rec.ID {
+				rec.TTL = owned[j].TTL
+			}
+		}
+	}
+
+	records, diags := zoneRecordsToList(planned.Records, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), plan.zoneID())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), records)...)
+}
+
+func (r *StaticDNSZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan StaticDNSZoneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// A partly applied zone is saved even on error, so the records already
+	// created are not orphaned.
+	if !r.write(ctx, &plan, nil, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *StaticDNSZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state StaticDNSZoneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	existing, err := r.client.ListStaticDNS(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "list", "static DNS records")
+		return
+	}
+
+	// Records removed from the controller are dropped, so the next plan
+	// recreates them.
+	var owned []unifi.StaticDNS
+	for _, rec := range zoneRecordsFromList(state.Records) {
+		if found := findStaticDNSByID(existing, rec.ID); found != nil {
+			owned = append(owned, *found)
+		}
+	}
+
+	records, diags := zoneRecordsToList(owned, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Records = records
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *StaticDNSZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state StaticDNSZoneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// A partly applied zone is saved even on error, so the records already
+	// created are not orphaned.
+	if !r.write(ctx, &plan, &state, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes every record in state. Records already gone from the
+// controller are skipped.
+func (r *StaticDNSZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state StaticDNSZoneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	for _, rec := range zoneRecordsFromList(state.Records) {
+		if err := r.client.DeleteStaticDNS(ctx, rec.ID); err != nil && !isNotFoundError(err) {
+			handleSDKError(&resp.Diagnostics, err, "delete", fmt.Sprintf("%s record %q", rec.RecordType, rec.Key))
+			return
+		}
+	}
+}
+
+// write lists the controller's static DNS records once, deletes the owned
+// records that left the zone, and then creates or updates only the records
+// that differ. plan.Records is refreshed from the controller's responses.
+// When a write fails part way, plan.Records still lists every record the
+// zone owns on the controller, so the caller can save it and the next apply
+// picks up where this one stopped. write reports whether plan.Records was
+// set and should be saved.
+func (r *StaticDNSZoneResource) write(ctx context.Context, plan, prior *StaticDNSZoneResourceModel, diags *diag.Diagnostics) bool {
+	desired, err := plan.parse()
+	if err != nil {
+		diags.AddAttributeError(path.Root("zone"), "Invalid zone file", err.Error())
+		return false
+	}
+
+	existing, err := r.client.ListStaticDNS(ctx)
+	if err != nil {
+		handleSDKError(diags, err, "list", "static DNS records")
+		return false
+	}
+
+	var owned []unifi.StaticDNS
+	if prior != nil {
+		for _, rec := range zoneRecordsFromList(prior.Records) {
+			if found := findStaticDNSByID(existing, rec.ID); found != nil {
+				owned = append(owned, *found)
+			}
+		}
+	}
+
+	planned := planZoneRecords(desired, owned)
+	result := make([]unifi.StaticDNS, 0, len(planned.Records))
+	deleted := make(map[string]bool)
+
+	// record sets plan.Records to the records written so far followed by the
+	// owned records not yet deleted or reached.
+	record := func() bool {
+		records := append([]unifi.StaticDNS(nil), result...)
+		for _, o := range owned {
+			if !deleted[o.ID] && findStaticDNSByID(records, o.ID) == nil {
+				records = append(records, o)
+			}
+		}
+		list, d := zoneRecordsToList(records, false)
+		diags.Append(d...)
+		plan.ID = types.StringValue(plan.zoneID())
+		plan.Records = list
+		return true
+	}
+
+	for _, id := range planned.Delete {
+		if err := r.client.DeleteStaticDNS(ctx, id); err != nil && !isNotFoundError(err) {
+			handleSDKError(diags, err, "delete", fmt.Sprintf("static DNS record %s", id))
+			return record()
+		}
+		deleted[id] = true
+	}
+
+	for i := range planned.Records {
+		rec := planned.Records[i]
+		name := fmt.Sprintf("%s record %q", rec.RecordType, rec.Key)
+
+		if rec.ID == "" {
+			created, err := r.client.CreateStaticDNS(ctx, &rec)
+			if err != nil {
+				handleSDKError(diags, err, "create", name)
+				return record()
+			}
+			result = append(result, *created)
+			continue
+		}
+
+		current := findStaticDNSByID(owned, rec.ID)
+		if !planned.Update[i] {
+			result = append(result, *current)
+			continue
+		}
+
+		dns := *current
+		dns.Key = rec.Key
+		dns.Value = rec.Value
+		dns.Enabled = rec.Enabled
+		if rec.TTL != nil {
+			dns.TTL = rec.TTL
+		}
+		dns.Priority = rec.Priority
+		dns.Weight = rec.Weight
+		dns.Port = rec.Port
+		updated, err := r.client.UpdateStaticDNS(ctx, dns.ID, &dns)
+		if err != nil {
+			handleSDKError(diags, err, "update", name)
+			return record()
+		}
+		result = append(result, *updated)
+	}
+
+	return record()
+}
+
+func (m *StaticDNSZoneResourceModel) zoneID() string {
+	return strings.TrimSuffix(m.Origin.ValueString(), ".")
+}
+
+func (m *StaticDNSZoneResourceModel) parse() ([]unifi.StaticDNS, error) {
+	var defaultTTL *int
+	if !m.DefaultTTL.IsNull() {
+		defaultTTL = intPtr(m.DefaultTTL.ValueInt64())
+	}
+	return parseZone(m.Origin.ValueString(), m.Zone.ValueString(), defaultTTL)
+}
+
+func findStaticDNSByID(records []unifi.StaticDNS, id string) *unifi.StaticDNS {
+	for i := range records {
+		if records[i].ID == id {
+			return &records[i]
+		}
+	}
+	return nil
+}
+
+// zoneRecordsToList converts records to the records attribute. Zero numbers
+// are null, as in unifi_static_dns. When planning, records not created yet
+// have an unknown id, and an unknown ttl if the zone sets none.
+func zoneRecordsToList(records []unifi.StaticDNS, planning bool) (types.List, diag.Diagnostics) {
+	objType := types.ObjectType{AttrTypes: zoneRecordAttrTypes}
+	values := make([]attr.Value, 0, len(records))
+	var diags diag.Diagnostics
+
+	for i := range records {
+		rec := &records[i]
+		id := types.StringValue(rec.ID)
+		ttl := zoneRecordNumber(rec.TTL)
+		if planning && rec.ID == "" {
+			id = types.StringUnknown()
+			if rec.TTL == nil {
+				ttl = types.Int64Unknown()
+			}
+		}
+
+		obj, d := types.ObjectValue(zoneRecordAttrTypes, map[string]attr.Value{
+			"id":          id,
+			"key":         types.StringValue(rec.Key),
+			"record_type": types.StringValue(rec.RecordType),
+			"value":       types.StringValue(rec.Value),
+			"ttl":         ttl,
+			"priority":    zoneRecordNumber(rec.Priority),
+			"weight":      zoneRecordNumber(rec.Weight),
+			"port":        zoneRecordNumber(rec.Port),
+		})
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+
+	list, d := types.ListValue(objType, values)
+	diags.Append(d...)
+	return list, diags
+}
+
+// zoneRecordsFromList reads back the records attribute. Unknown or null
+// elements and ids are skipped.
+func zoneRecordsFromList(list types.List) []unifi.StaticDNS {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	var records []unifi.StaticDNS
+	for _, elem := range list.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		attrs := obj.Attributes()
+		id := attrs["id"].(types.String)
+		if id.IsNull() || id.IsUnknown() {
+			continue
+		}
+
+		rec := unifi.StaticDNS{
+			ID:         id.ValueString(),
+			Key:        attrs["key"].(types.String).ValueString(),
+			RecordType: attrs["record_type"].(types.String).ValueString(),
+			Value:      attrs["value"].(types.String).ValueString(),
+			Enabled:    boolPtr(true),
+		}
+		for name, dst := range map[string]**int{"ttl": &rec.TTL, "priority": &rec.Priority, "weight": &rec.Weight, "port": &rec.Port} {
+			if v := attrs[name].(types.Int64); !v.IsNull() && !v.IsUnknown() {
+				*dst = intPtr(v.ValueInt64())
+			}
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func zoneRecordNumber(v *int) types.Int64 {
+	if v == nil || *v == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*v))
+}
@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func TestParseZone(t *testing.T) {
+	zone := `
+$TTL 1h
+@       IN SOA ns1 hostmaster ( 2024010101 ; serial
+                                3600 900 604800 300 )
+        IN NS  ns1.example.net.
+@          A     10.0.0.1
+www     300 IN A 10.0.0.2
+           AAAA  fd00::2
+mail       A     10.0.0.3
+@          MX    10 mail
+@          MX    20 backup.example.net.
+_sip._tcp  SRV   10 60 5060 sip
+ftp        CNAME www
+@          TXT   "v=spf1 mx -all" "; not a comment"
+$ORIGIN lab.home.example.
+printer    A     10.0.1.5
+`
+
+	records, err := parseZone("home.example.", zone, nil)
+	if err != nil {
+		t.Fatalf("parseZone() error = %v", err)
+	}
+
+	want := []unifi.StaticDNS{
+		{Key: "home.example", RecordType: "A", Value: "10.0.0.1", TTL: intPtr(3600)},
+		{Key: "www.home.example", RecordType: "A", Value: "10.0.0.2", TTL: intPtr(300)},
+		{Key: "www.home.example", RecordType: "AAAA", Value: "fd00::2", TTL: intPtr(3600)},
+		{Key: "mail.home.example", RecordType: "A", Value: "10.0.0.3", TTL: intPtr(3600)},
+		{Key: "home.example", RecordType: "MX", Value: "mail.home.example", TTL: intPtr(3600), Priority: intPtr(10)},
+		{Key: "home.example", RecordType: "MX", Value: "backup.example.net", TTL: intPtr(3600), Priority: intPtr(20)},
+		{Key: "_sip._tcp.home.example", RecordType: "SRV", Value: "sip.home.example", TTL: intPtr(3600), Priority: intPtr(10), Weight: intPtr(60), Port: intPtr(5060)},
+		{Key: "ftp.home.example", RecordType: "CNAME", Value: "www.home.example", TTL: intPtr(3600)},
+		{Key: "home.example", RecordType: "TXT", Value: "v=spf1 mx -all; not a comment", TTL: intPtr(3600)},
+		{Key: "printer.lab.home.example", RecordType: "A", Value: "10.0.1.5", TTL: intPtr(3600)},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("parseZone() returned %d records, want %d: %+v", len(records), len(want), records)
+	}
+	for i := range want {
+		want[i].Enabled = boolPtr(true)
+		if !zoneRecordMatches(&records[i], &want[i]) || records[i].RecordType != want[i].RecordType || derefInt(records[i].TTL) != derefInt(want[i].TTL) {
+			t.Errorf("record %d = %s %s %q, want %s %s %q", i, records[i].Key, records[i].RecordType, records[i].Value, want[i].Key, want[i].RecordType, want[i].Value)
+		}
+	}
+}
+
+func TestParseZone_defaultTTL(t *testing.T) {
+	records, err := parseZone("home.example", "a A 10.0.0.1\nb 60 A 10.0.0.2", intPtr(600))
+	if err != nil {
+		t.Fatalf("parseZone() error = %v", err)
+	}
+	if derefInt(records[0].TTL) != 600 || derefInt(records[1].TTL) != 60 {
+		t.Fatalf("TTLs = %d, %d, want 600, 60", derefInt(records[0].TTL), derefInt(records[1].TTL))
+	}
+
+	records, err = parseZone("home.example", "a A 10.0.0.1", nil)
+	if err != nil {
+		t.Fatalf("parseZone() error = %v", err)
+	}
+	if records[0].TTL != nil {
+		t.Fatalf("TTL = %d, want unset", *records[0].TTL)
+	}
+}
+
+func TestParseZone_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		zone string
+		want string
+	}{
+		{"unsupported type", "a PTR host", "unsupported record type PTR"},
+		{"delegation", "sub NS ns1", "NS delegation"},
+		{"bad IPv4", "a A fd00::1", "not an IPv4 address"},
+		{"bad IPv6", "a AAAA 10.0.0.1", "not an IPv6 address"},
+		{"MX fields", "@ MX mail", "must be a preference and a mail exchanger"},
+		{"SRV port", "_x._tcp SRV 1 1 0 host", "SRV port"},
+		{"unterminated", `@ TXT "open`, "unterminated quoted string"},
+		{"parentheses", "@ MX ( 10 mail", "unbalanced parenthesis"},
+		{"no owner", "  A 10.0.0.1", "no owner name"},
+		{"include", "$INCLUDE other.zone", "unsupported directive"},
+		{"duplicate", "a A 10.0.0.1\nA.home.example. A 10.0.0.1", "duplicate A record"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseZone("home.example", tt.zone, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("parseZone() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanZoneRecords(t *testing.T) {
+	owned := []unifi.StaticDNS{
+		{ID: "1", Key: "a.home.example", RecordType: "A", Value: "10.0.0.1", Enabled: boolPtr(true)},
+		{ID: "2", Key: "b.home.example", RecordType: "A", Value: "10.0.0.2", Enabled: boolPtr(true)},
+		{ID: "3", Key: "c.home.example", RecordType: "A", Value: "10.0.0.3", Enabled: boolPtr(true)},
+		{ID: "4", Key: "a.home.example", RecordType: "A", Value: "10.0.0.4", Enabled: boolPtr(true)},
+	}
+	desired := []unifi.StaticDNS{
+		{Key: "a.home.example", RecordType: "A", Value: "10.0.0.4", Enabled: boolPtr(true)},
+		{Key: "b.home.example", RecordType: "A", Value: "10.0.0.9", Enabled: boolPtr(true)},
+		{Key: "d.home.example", RecordType: "A", Value: "10.0.0.5", Enabled: boolPtr(true)},
+		{Key: "a.home.example", RecordType: "A", Value: "10.0.0.1", Enabled: boolPtr(true), TTL: intPtr(60)},
+	}
+
+	plan := planZoneRecords(desired, owned)
+
+	ids := make([]string, len(plan.Records))
+	for i := range plan.Records {
+		ids[i] = plan.Records[i].ID
+	}
+	if got := strings.Join(ids, ","); got != "4,2,,1" {
+		t.Errorf("matched IDs = %q, want %q", got, "4,2,,1")
+	}
+	if len(plan.Update) != 2 || !plan.Update[1] || !plan.Update[3] {
+		t.Errorf("Update = %v, want records 1 and 3", plan.Update)
+	}
+	if len(plan.Delete) != 1 || plan.Delete[0] != "3" {
+		t.Errorf("Delete = %v, want [3]", plan.Delete)
+	}
+	if desired[0].ID != "" {
+		t.Errorf("planZoneRecords modified its input")
+	}
+}
+
+// fakeStaticDNSClient serves static DNS records from memory and rejects the
+// create of any record whose value is failValue. Other NetworkManager
+// methods are not implemented and panic if called.
+type fakeStaticDNSClient struct {
+	unifi.NetworkManager
+
+	records   []unifi.StaticDNS
+	nextID    int
+	failValue string
+}
+
+func (f *fakeStaticDNSClient) ListStaticDNS(ctx context.Context) ([]unifi.StaticDNS, error) {
+	return append([]unifi.StaticDNS(nil), f.records...), nil
+}
+
+func (f *fakeStaticDNSClient) CreateStaticDNS(ctx context.Context, dns *unifi.StaticDNS) (*unifi.StaticDNS, error) {
+	if dns.Value == f.failValue {
+		return nil, errors.New("controller rejected record")
+	}
+	f.nextID++
+	created := *dns
+	created.ID = fmt.Sprint(f.nextID)
+	f.records = append(f.records, created)
+	return &created, nil
+}
+
+func (f *fakeStaticDNSClient) DeleteStaticDNS(ctx context.Context, id string) error {
+	for i := range f.records {
+		if f.records[i].ID == id {
+			f.records = append(f.records[:i], f.records[i+1:]...)
+			return nil
+		}
+	}
+	return unifi.ErrNotFound
+}
+
+func TestStaticDNSZoneWrite_partialFailure(t *testing.T) {
+	owned := []unifi.StaticDNS{
+		{ID: "1", Key: "a.home.example", RecordType: "A", Value: "10.0.0.1", Enabled: boolPtr(true)},
+		{ID: "2", Key: "b.home.example", RecordType: "A", Value: "10.0.0.2", Enabled: boolPtr(true)},
+	}
+	fake := &fakeStaticDNSClient{records: owned, nextID: 2, failValue: "10.0.0.4"}
+	r := &StaticDNSZoneResource{client: &AutoLoginClient{client: fake}}
+
+	priorRecords, diags := zoneRecordsToList(owned, false)
+	if diags.HasError() {
+		t.Fatalf("zoneRecordsToList() diagnostics = %v", diags)
+	}
+	prior := &StaticDNSZoneResourceModel{Origin: types.StringValue("home.example"), Records: priorRecords}
+	plan := &StaticDNSZoneResourceModel{
+		Origin:     types.StringValue("home.example"),
+		Zone:       types.StringValue("a A 10.0.0.1\nc A 10.0.0.3\nd A 10.0.0.4"),
+		DefaultTTL: types.Int64Null(),
+	}
+
+	var writeDiags diag.Diagnostics
+	if !r.write(context.Background(), plan, prior, &writeDiags) {
+		t.Fatalf("write() did not record the partly applied zone: %v", writeDiags)
+	}
+	if !writeDiags.HasError() {
+		t.Fatalf("write() reported no error for the rejected record")
+	}
+
+	var ids []string
+	for _, rec := range zoneRecordsFromList(plan.Records) {
+		ids = append(ids, rec.ID)
+	}
+	if got := strings.Join(ids, ","); got != "1,3" {
+		t.Fatalf("recorded IDs = %q, want %q (the kept record and the one created before the failure)", got, "1,3")
+	}
+}
+
+func TestAccStaticDNSZoneResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStaticDNSZoneResourceConfig(`
+$TTL 300
+@          A     10.192.0.1
+www        A     10.192.0.2
+mail       A     10.192.0.3
+@          MX    10 mail
+_sip._tcp  SRV   10 60 5060 www
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "id", "tf-acc-test-zone.local"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.#", "5"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.0.key", "tf-acc-test-zone.local"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.0.ttl", "300"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.3.record_type", "MX"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.3.value", "mail.tf-acc-test-zone.local"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.3.priority", "10"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.4.port", "5060"),
+					resource.TestCheckResourceAttrSet("unifi_static_dns_zone.test", "records.0.id"),
+				),
+			},
+			{
+				Config: testAccStaticDNSZoneResourceConfig(`
+$TTL 300
+@          A     10.192.0.1
+www        A     10.192.0.20
+           AAAA  fd00:192::20
+ftp        CNAME www
+@          TXT   "v=spf1 mx -all"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.#", "5"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.1.value", "10.192.0.20"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.2.record_type", "AAAA"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.3.value", "www.tf-acc-test-zone.local"),
+					resource.TestCheckResourceAttr("unifi_static_dns_zone.test", "records.4.value", "v=spf1 mx -all"),
+				),
+			},
+		},
+	})
+}
+
+func testAccStaticDNSZoneResourceConfig(zone string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_static_dns_zone" "test" {
+  origin = "tf-acc-test-zone.local"
+  zone   = <<-EOT
+%s
+  EOT
+}
+`, testAccProviderConfig, zone)
+}
@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+var (
+	_ resource.Resource                = &SwitchLAGResource{}
+	_ resource.ResourceWithImportState = &SwitchLAGResource{}
+)
+
+type SwitchLAGResource struct {
+	client *AutoLoginClient
+}
+
+type SwitchLAGResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	DeviceID           types.String   `tfsdk:"device_id"`
+	MAC                types.String   `tfsdk:"mac"`
+	MemberPorts        types.Set      `tfsdk:"member_ports"`
+	PrimaryPort        types.Int64    `tfsdk:"primary_port"`
+	WaitForProvision   types.Bool     `tfsdk:"wait_for_provision"`
+	OnProvisionTimeout types.String   `tfsdk:"on_provision_timeout"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewSwitchLAGResource() resource.Resource {
+	return &SwitchLAGResource{}
+}
+
+func (r *SwitchLAGResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_switch_lag"
+}
+
+func (r *SwitchLAGResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a link aggregation group (LAG) on a UniFi switch. The LAG is stored on the port override of its " +
+			"lowest member port. Before writing, the device's existing overrides are checked so a port cannot join two LAGs " +
+			"or be both a LAG member and part of a port mirror. Do not also set op_mode or aggregate_members for the primary " +
+			"port in unifi_device_port_override or unifi_device_ports.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier (device_id:primary_port).",
+				Computed:    true,
+			},
+			"device_id": schema.StringAttribute{
+				Description: "The ID of the switch. Use the unifi_device data source to look this up.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mac": schema.StringAttribute{
+				Description: "The MAC address of the switch (computed from device_id).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"member_ports": schema.SetAttribute{
+				Description: "Port indices (1-based) that make up the LAG. At least two ports.",
+				Required:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(2),
+					setvalidator.ValueInt64sAre(int64validator.AtLeast(1)),
+				},
+			},
+			"primary_port": schema.Int64Attribute{
+				Description: "The lowest member port, whose override holds the LAG on the controller.",
+				Computed:    true,
+			},
+			"wait_for_provision":   waitForProvisionAttribute(),
+			"on_provision_timeout": onProvisionTimeoutAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *SwitchLAGResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SwitchLAGResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SwitchLAGResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.write(ctx, &plan, 0, "create", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SwitchLAGResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SwitchLAGResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	device, err := r.client.getDeviceByID(ctx, state.DeviceID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		handleSDKError(&resp.Diagnostics, err, "read", "device")
+		return
+	}
+
+	if state.WaitForProvision.IsNull() {
+		state.WaitForProvision = types.BoolValue(false)
+	}
+	if state.OnProvisionTimeout.IsNull() {
+		state.OnProvisionTimeout = types.StringValue(provisionTimeoutWarn)
+	}
+
+	if !r.sdkToState(ctx, device, int(state.PrimaryPort.ValueInt64()), &state, &resp.Diagnostics) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *SwitchLAGResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SwitchLAGResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.write(ctx, &plan, int(state.PrimaryPort.ValueInt64()), "update", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SwitchLAGResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SwitchLAGResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	primary := int(state.PrimaryPort.ValueInt64())
+	_, err := r.client.updatePortOverrides(ctx, state.DeviceID.ValueString(), func(overrides []unifi.PortOverride) ([]unifi.PortOverride, error) {
+		return clearPortOpMode(overrides, primary), nil
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		handleSDKError(&resp.Diagnostics, err, "delete", "switch LAG")
+	}
+}
+
+func (r *SwitchLAGResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected format 'device_id:primary_port', got '%s'", req.ID),
+		)
+		return
+	}
+
+	primary, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("primary_port must be a number, got '%s'", parts[1]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("device_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("primary_port"), primary)...)
+}
+
+// write checks the planned members against the device's other port roles and
+// writes the LAG in one device update. oldPrimary is the primary port in
+// state, or 0 on create.
+func (r *SwitchLAGResource) write(ctx context.Context, plan *SwitchLAGResourceModel, oldPrimary int, op string, diags *diag.Diagnostics) {
+	var members []int64
+	diags.Append(plan.MemberPorts.ElementsAs(ctx, &members, false)...)
+	if diags.HasError() {
+		return
+	}
+	ports := make([]int, len(members))
+	for i, m := range members {
+		ports[i] = int(m)
+	}
+
+	var primary int
+	updated, err := r.client.updatePortOverrides(ctx, plan.DeviceID.ValueString(), func(overrides []unifi.PortOverride) ([]unifi.PortOverride, error) {
+		if err := checkLAGConflicts(overrides, oldPrimary, ports); err != nil {
+			return nil, err
+		}
+		var result []unifi.PortOverride
+		result, primary = setLAG(overrides, oldPrimary, ports)
+		return result, nil
+	})
+	if err != nil {
+		handleSDKError(diags, err, op, "switch LAG")
+		return
+	}
+
+	if plan.WaitForProvision.ValueBool() {
+		if provisioned := r.client.waitForDeviceProvision(ctx, updated.MAC, plan.OnProvisionTimeout, diags); provisioned != nil {
+			updated = provisioned
+		}
+		if diags.HasError() {
+			return
+		}
+	}
+
+	if !r.sdkToState(ctx, updated, primary, plan, diags) {
+		diags.AddError(
+			"Switch LAG not applied",
+			fmt.Sprintf("The controller accepted the update but port %d on device %s has no LAG.", primary, updated.MAC),
+		)
+	}
+}
+
+// sdkToState fills state from the LAG on the primary port's override. It
+// returns false when that port no longer holds a LAG.
+func (r *SwitchLAGResource) sdkToState(ctx context.Context, device *unifi.DeviceConfig, primary int, state *SwitchLAGResourceModel, diags *diag.Diagnostics) bool {
+	var members []int
+	for _, o := range device.PortOverrides {
+		if o.PortIdx != nil && *o.PortIdx == primary {
+			members = lagMembers(o)
+		}
+	}
+	if members == nil {
+		return false
+	}
+
+	sorted := make([]int64, len(members))
+	for i, m := range members {
+		sorted[i] = int64(m)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	memberSet, d := types.SetValueFrom(ctx, types.Int64Type, sorted)
+	diags.Append(d...)
+
+	state.ID = types.StringValue(fmt.Sprintf("%s:%d", device.ID, primary))
+	state.DeviceID = types.StringValue(device.ID)
+	state.MAC = types.StringValue(device.MAC)
+	state.MemberPorts = memberSet
+	state.PrimaryPort = types.Int64Value(int64(primary))
+	return true
+}
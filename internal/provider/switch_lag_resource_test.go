@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSwitchLAGResource_basic(t *testing.T) {
+	testAccPreCheckSwitch(t)
+	mac := testAccGetFirstSwitchMAC(t)
+	if mac == "" {
+		t.Skip("No switch MAC available")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSwitchLAGResourceConfig(mac, "7, 8"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("unifi_switch_lag.test", "id"),
+					resource.TestCheckResourceAttr("unifi_switch_lag.test", "primary_port", "7"),
+					resource.TestCheckResourceAttr("unifi_switch_lag.test", "member_ports.#", "2"),
+					resource.TestCheckTypeSetElemAttr("unifi_switch_lag.test", "member_ports.*", "8"),
+				),
+			},
+			{
+				Config: testAccSwitchLAGResourceConfig(mac, "7, 8, 9"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_switch_lag.test", "primary_port", "7"),
+					resource.TestCheckResourceAttr("unifi_switch_lag.test", "member_ports.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "unifi_switch_lag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestAccSwitchLAGResource_conflict checks that a mirror of a LAG member is
+// refused before anything is written.
+func TestAccSwitchLAGResource_conflict(t *testing.T) {
+	testAccPreCheckSwitch(t)
+	mac := testAccGetFirstSwitchMAC(t)
+	if mac == "" {
+		t.Skip("No switch MAC available")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSwitchLAGResourceConfig(mac, "7, 8"),
+			},
+			{
+				Config:      testAccSwitchLAGResourceConfig_mirrorMember(mac),
+				ExpectError: regexp.MustCompile(`source port 8 is a member of the LAG on port 7`),
+			},
+		},
+	})
+}
+
+func testAccSwitchLAGResourceConfig(mac, members string) string {
+	return fmt.Sprintf(`
+%s
+
+data "unifi_device" "test" {
+  mac = %q
+}
+
+resource "unifi_switch_lag" "test" {
+  device_id    = data.unifi_device.test.id
+  member_ports = [%s]
+}
+`, testAccProviderConfig, mac, members)
+}
+
+func testAccSwitchLAGResourceConfig_mirrorMember(mac string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_switch_port_mirror" "test" {
+  device_id        = data.unifi_device.test.id
+  destination_port = 6
+  source_port      = 8
+
+  depends_on = [unifi_switch_lag.test]
+}
+`, testAccSwitchLAGResourceConfig(mac, "7, 8"))
+}
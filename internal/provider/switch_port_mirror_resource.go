@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+var (
+	_ resource.Resource                   = &SwitchPortMirrorResource{}
+	_ resource.ResourceWithImportState    = &SwitchPortMirrorResource{}
+	_ resource.ResourceWithValidateConfig = &SwitchPortMirrorResource{}
+)
+
+type SwitchPortMirrorResource struct {
+	client *AutoLoginClient
+}
+
+type SwitchPortMirrorResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	DeviceID           types.String   `tfsdk:"device_id"`
+	MAC                types.String   `tfsdk:"mac"`
+	DestinationPort    types.Int64    `tfsdk:"destination_port"`
+	SourcePort         types.Int64    `tfsdk:"source_port"`
+	WaitForProvision   types.Bool     `tfsdk:"wait_for_provision"`
+	OnProvisionTimeout types.String   `tfsdk:"on_provision_timeout"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewSwitchPortMirrorResource() resource.Resource {
+	return &SwitchPortMirrorResource{}
+}
+
+func (r *SwitchPortMirrorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_switch_port_mirror"
+}
+
+func (r *SwitchPortMirrorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Mirrors the traffic of one switch port to another, where an analyzer is connected. The mirror is stored " +
+			"on the port override of the destination port. Before writing, the device's existing overrides are checked so " +
+			"neither port is a LAG member and the destination is not already in use by another mirror. Do not also set " +
+			"op_mode for the destination port in unifi_device_port_override or unifi_device_ports.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier (device_id:destination_port).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				Description: "The ID of the switch. Use the unifi_device data source to look this up.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mac": schema.StringAttribute{
+				Description: "The MAC address of the switch (computed from device_id).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"destination_port": schema.Int64Attribute{
+				Description: "The port index (1-based) that receives the mirrored traffic.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"source_port": schema.Int64Attribute{
+				Description: "The port index (1-based) whose traffic is mirrored.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"wait_for_provision":   waitForProvisionAttribute(),
+			"on_provision_timeout": onProvisionTimeoutAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *SwitchPortMirrorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SwitchPortMirrorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config SwitchPortMirrorResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.DestinationPort.IsNull() || config.DestinationPort.IsUnknown() || config.SourcePort.IsNull() || config.SourcePort.IsUnknown() {
+		return
+	}
+	if config.DestinationPort.ValueInt64() == config.SourcePort.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_port"),
+			"Invalid port mirror",
+			fmt.Sprintf("Port %d cannot mirror itself. Set source_port and destination_port to different ports.", config.SourcePort.ValueInt64()),
+		)
+	}
+}
+
+func (r *SwitchPortMirrorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SwitchPortMirrorResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.write(ctx, &plan, 0, "create", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SwitchPortMirrorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SwitchPortMirrorResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	device, err := r.client.getDeviceByID(ctx, state.DeviceID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		handleSDKError(&resp.Diagnostics, err, "read", "device")
+		return
+	}
+
+	if state.WaitForProvision.IsNull() {
+		state.WaitForProvision = types.BoolValue(false)
+	}
+	if state.OnProvisionTimeout.IsNull() {
+		state.OnProvisionTimeout = types.StringValue(provisionTimeoutWarn)
+	}
+
+	if !r.sdkToState(device, &state) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *SwitchPortMirrorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SwitchPortMirrorResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.write(ctx, &plan, int(plan.DestinationPort.ValueInt64()), "update", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SwitchPortMirrorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SwitchPortMirrorResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	destination := int(state.DestinationPort.ValueInt64())
+	_, err := r.client.updatePortOverrides(ctx, state.DeviceID.ValueString(), func(overrides []unifi.PortOverride) ([]unifi.PortOverride, error) {
+		return clearPortOpMode(overrides, destination), nil
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		handleSDKError(&resp.Diagnostics, err, "delete", "switch port mirror")
+	}
+}
+
+func (r *SwitchPortMirrorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected format 'device_id:destination_port', got '%s'", req.ID),
+		)
+		return
+	}
+
+	destination, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("destination_port must be a number, got '%s'", parts[1]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("device_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("destination_port"), destination)...)
+}
+
+// write checks the planned ports against the device's other port roles and
+// writes the mirror in one device update. ownDestination is the destination
+// in state, or 0 on create.
+func (r *SwitchPortMirrorResource) write(ctx context.Context, plan *SwitchPortMirrorResourceModel, ownDestination int, op string, diags *diag.Diagnostics) {
+	destination := int(plan.DestinationPort.ValueInt64())
+	source := int(plan.SourcePort.ValueInt64())
+
+	updated, err := r.client.updatePortOverrides(ctx, plan.DeviceID.ValueString(), func(overrides []unifi.PortOverride) ([]unifi.PortOverride, error) {
+		if err := checkMirrorConflicts(overrides, ownDestination, destination, source); err != nil {
+			return nil, err
+		}
+		return setMirror(overrides, destination, source), nil
+	})
+	if err != nil {
+		handleSDKError(diags, err, op, "switch port mirror")
+		return
+	}
+
+	if plan.WaitForProvision.ValueBool() {
+		if provisioned := r.client.waitForDeviceProvision(ctx, updated.MAC, plan.OnProvisionTimeout, diags); provisioned != nil {
+			updated = provisioned
+		}
+		if diags.HasError() {
+			return
+		}
+	}
+
+	if !r.sdkToState(updated, plan) {
+		diags.AddError(
+			"Switch port mirror not applied",
+			fmt.Sprintf("The controller accepted the update but port %d on device %s is not mirroring.", destination, updated.MAC),
+		)
+	}
+}
+
+// sdkToState fills state from the destination port's override. It returns
+// false when that port is no longer a mirror destination.
+func (r *SwitchPortMirrorResource) sdkToState(device *unifi.DeviceConfig, state *SwitchPortMirrorResourceModel) bool {
+	destination := int(state.DestinationPort.ValueInt64())
+	for _, o := range device.PortOverrides {
+		if o.PortIdx == nil || *o.PortIdx != destination {
+			continue
+		}
+		if o.OpMode != portOpModeMirror || o.MirrorPortIdx == nil {
+			return false
+		}
+
+		state.ID = types.StringValue(fmt.Sprintf("%s:%d", device.ID, destination))
+		state.DeviceID = types.StringValue(device.ID)
+		state.MAC = types.StringValue(device.MAC)
+		state.SourcePort = types.Int64Value(int64(*o.MirrorPortIdx))
+		return true
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSwitchPortMirrorResource_basic(t *testing.T) {
+	testAccPreCheckSwitch(t)
+	mac := testAccGetFirstSwitchMAC(t)
+	if mac == "" {
+		t.Skip("No switch MAC available")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSwitchPortMirrorResourceConfig(mac, 6, 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("unifi_switch_port_mirror.test", "id"),
+					resource.TestCheckResourceAttr("unifi_switch_port_mirror.test", "destination_port", "6"),
+					resource.TestCheckResourceAttr("unifi_switch_port_mirror.test", "source_port", "5"),
+				),
+			},
+			{
+				Config: testAccSwitchPortMirrorResourceConfig(mac, 6, 4),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_switch_port_mirror.test", "source_port", "4"),
+				),
+			},
+			{
+				ResourceName:      "unifi_switch_port_mirror.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSwitchPortMirrorResource_mirrorsItself(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSwitchPortMirrorResourceConfig("aa:bb:cc:dd:ee:ff", 6, 6),
+				ExpectError: regexp.MustCompile(`cannot mirror itself`),
+			},
+		},
+	})
+}
+
+func testAccSwitchPortMirrorResourceConfig(mac string, destination, source int) string {
+	return fmt.Sprintf(`
+%s
+
+data "unifi_device" "test" {
+  mac = %q
+}
+
+resource "unifi_switch_port_mirror" "test" {
+  device_id        = data.unifi_device.test.id
+  destination_port = %d
+  source_port      = %d
+}
+`, testAccProviderConfig, mac, destination, source)
+}
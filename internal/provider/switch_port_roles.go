@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+// Port operation modes used by link aggregation and port mirroring.
+const (
+	portOpModeSwitch    = "switch"
+	portOpModeAggregate = "aggregate"
+	portOpModeMirror    = "mirror"
+)
+
+// lagMembers returns the member ports of the LAG defined by an override, or
+// nil when the override does not define one. The controller keeps a LAG on
+// the override of its lowest port.
+func lagMembers(o unifi.PortOverride) []int {
+	if o.OpMode != portOpModeAggregate || o.PortIdx == nil {
+		return nil
+	}
+	if len(o.AggregateMembers) == 0 {
+		return []int{*o.PortIdx}
+	}
+	return o.AggregateMembers
+}
+
+func containsPort(ports []int, port int) bool {
+	for _, p := range ports {
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLAGConflicts reports the first port in members that already has a role
+// on the device: a member of another LAG, a mirror destination or a mirrored
+// port. ownPrimary is the primary port of the LAG being updated, or 0 when it
+// is being created.
+func checkLAGConflicts(overrides []unifi.PortOverride, ownPrimary int, members []int) error {
+	for _, o := range overrides {
+		if o.PortIdx == nil {
+			continue
+		}
+		port := *o.PortIdx
+
+		if lag := lagMembers(o); lag != nil && port != ownPrimary {
+			for _, m := range members {
+				if containsPort(lag, m) {
+					return fmt.Errorf("port %d is already a member of the LAG on port %d", m, port)
+				}
+			}
+		}
+
+		if o.OpMode == portOpModeMirror {
+			if containsPort(members, port) {
+				return fmt.Errorf("port %d is a mirror destination and cannot join a LAG", port)
+			}
+			if o.MirrorPortIdx != nil && containsPort(members, *o.MirrorPortIdx) {
+				return fmt.Errorf("port %d is mirrored to port %d and cannot join a LAG", *o.MirrorPortIdx, port)
+			}
+		}
+	}
+	return nil
+}
+
+// checkMirrorConflicts reports why destination cannot mirror source on the
+// device. ownDestination is the destination of the mirror being updated, or
+// 0 when it is being created.
+func checkMirrorConflicts(overrides []unifi.PortOverride, ownDestination, destination, source int) error {
+	if destination == source {
+		return fmt.Errorf("port %d cannot mirror itself", source)
+	}
+
+	for _, o := range overrides {
+		if o.PortIdx == nil {
+			continue
+		}
+		port := *o.PortIdx
+
+		if lag := lagMembers(o); lag != nil {
+			if containsPort(lag, destination) {
+				return fmt.Errorf("destination port %d is a member of the LAG on port %d", destination, port)
+			}
+			if containsPort(lag, source) {
+				return fmt.Errorf("source port %d is a member of the LAG on port %d", source, port)
+			}
+		}
+
+		if o.OpMode == portOpModeMirror && port != ownDestination {
+			if port == destination {
+				return fmt.Errorf("port %d is already a mirror destination", destination)
+			}
+			if port == source {
+				return fmt.Errorf("source port %d is itself a mirror destination", source)
+			}
+			if o.MirrorPortIdx != nil && *o.MirrorPortIdx == destination {
+				return fmt.Errorf("port %d is mirrored to port %d and cannot be a mirror destination", destination, port)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPortOverrideRoles reports why a unifi_device_port_override cannot
+// give portIdx the operation mode opMode, given the roles the other
+// overrides on the device already give its ports. members are the LAG
+// members of an aggregate override. The port's own override is replaced by
+// the write, so it is not compared.
+func checkPortOverrideRoles(overrides []unifi.PortOverride, portIdx int, opMode string, members []int) error {
+	others := make([]unifi.PortOverride, 0, len(overrides))
+	for _, o := range overrides {
+		if o.PortIdx == nil || *o.PortIdx != portIdx {
+			others = append(others, o)
+		}
+	}
+
+	switch opMode {
+	case portOpModeAggregate:
+		if len(members) == 0 {
+			members = []int{portIdx}
+		}
+		return checkLAGConflicts(others, 0, members)
+	case portOpModeMirror:
+		for _, o := range others {
+			if o.PortIdx == nil {
+				continue
+			}
+			port := *o.PortIdx
+			if lag := lagMembers(o); lag != nil && containsPort(lag, portIdx) {
+				return fmt.Errorf("port %d is a member of the LAG on port %d and cannot be a mirror destination", portIdx, port)
+			}
+			if o.OpMode == portOpModeMirror && o.MirrorPortIdx != nil && *o.MirrorPortIdx == portIdx {
+				return fmt.Errorf("port %d is mirrored to port %d and cannot be a mirror destination", portIdx, port)
+			}
+		}
+	}
+	return nil
+}
+
+// portOverrideFor returns the override for a port, appending an empty one
+// when the port has none yet.
+func portOverrideFor(overrides []unifi.PortOverride, portIdx int) ([]unifi.PortOverride, *unifi.PortOverride) {
+	for i := range overrides {
+		if overrides[i].PortIdx != nil && *overrides[i].PortIdx == portIdx {
+			return overrides, &overrides[i]
+		}
+	}
+	idx := portIdx
+	overrides = append(overrides, unifi.PortOverride{
+		PortIdx:           &idx,
+		SettingPreference: "manual",
+	})
+	return overrides, &overrides[len(overrides)-1]
+}
+
+// setLAG writes a LAG onto the override of its lowest member and returns the
+// primary port. When the LAG used to start on another port, that port goes
+// back to switching. Other settings on the overrides are kept.
+func setLAG(overrides []unifi.PortOverride, oldPrimary int, members []int) ([]unifi.PortOverride, int) {
+	sorted := append([]int(nil), members...)
+	sort.Ints(sorted)
+	primary := sorted[0]
+
+	if oldPrimary != 0 && oldPrimary != primary {
+		overrides = clearPortOpMode(overrides, oldPrimary)
+	}
+
+	overrides, o := portOverrideFor(overrides, primary)
+	o.OpMode = portOpModeAggregate
+	o.AggregateMembers = sorted
+	o.MirrorPortIdx = nil
+	return overrides, primary
+}
+
+// setMirror makes destination mirror source.
+func setMirror(overrides []unifi.PortOverride, destination, source int) []unifi.PortOverride {
+	overrides, o := portOverrideFor(overrides, destination)
+	src := source
+	o.OpMode = portOpModeMirror
+	o.MirrorPortIdx = &src
+	o.AggregateMembers = nil
+	return overrides
+}
+
+// clearPortOpMode returns a port to switching. The override itself stays, so
+// names, profiles and other settings on the port are not lost.
+func clearPortOpMode(overrides []unifi.PortOverride, portIdx int) []unifi.PortOverride {
+	for i := range overrides {
+		if overrides[i].PortIdx != nil && *overrides[i].PortIdx == portIdx {
+			overrides[i].OpMode = portOpModeSwitch
+			overrides[i].AggregateMembers = nil
+			overrides[i].MirrorPortIdx = nil
+		}
+	}
+	return overrides
+}
+
+// updatePortOverrides re-reads a device under its device lock, lets apply
+// change its port overrides, and writes the device back. An error from apply
+// aborts the write. Holding the lock across the read, check and write keeps
+// the conflict checks valid against concurrent writers, including batched
+// port override writes.
+func (c *AutoLoginClient) updatePortOverrides(ctx context.Context, deviceID string, apply func([]unifi.PortOverride) ([]unifi.PortOverride, error)) (*unifi.DeviceConfig, error) {
+	deviceLock := c.getDeviceLock(deviceID)
+	deviceLock.Lock()
+	defer deviceLock.Unlock()
+
+	device, err := c.getDeviceByID(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := apply(device.PortOverrides)
+	if err != nil {
+		return nil, err
+	}
+	device.PortOverrides = overrides
+
+	return c.UpdateDevice(ctx, device.ID, device)
+}
+
+// getDeviceByID looks a device up by ID. The controller only returns the full
+// device configuration by MAC, so the ID is resolved through the device list.
+func (c *AutoLoginClient) getDeviceByID(ctx context.Context, id string) (*unifi.DeviceConfig, error) {
+	devices, err := c.ListDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range devices.NetworkDevices {
+		if d.ID == id {
+			return c.GetDeviceByMAC(ctx, d.MAC)
+		}
+	}
+
+	return nil, fmt.Errorf("device with ID %q: %w", id, unifi.ErrNotFound)
+}
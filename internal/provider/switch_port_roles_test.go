@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func testLAGOverride(members ...int) unifi.PortOverride {
+	primary := members[0]
+	return unifi.PortOverride{PortIdx: &primary, OpMode: portOpModeAggregate, AggregateMembers: members}
+}
+
+func testMirrorOverride(destination, source int) unifi.PortOverride {
+	return unifi.PortOverride{PortIdx: &destination, OpMode: portOpModeMirror, MirrorPortIdx: &source}
+}
+
+func testSwitchOverride(port int, name string) unifi.PortOverride {
+	return unifi.PortOverride{PortIdx: &port, OpMode: portOpModeSwitch, Name: name}
+}
+
+func TestCheckLAGConflicts(t *testing.T) {
+	overrides := []unifi.PortOverride{
+		testLAGOverride(1, 2),
+		testMirrorOverride(10, 11),
+		testSwitchOverride(5, "uplink"),
+	}
+
+	cases := []struct {
+		name       string
+		ownPrimary int
+		members    []int
+		wantErr    bool
+	}{
+		{name: "free ports", members: []int{5, 6}},
+		{name: "joins other LAG", members: []int{2, 3}, wantErr: true},
+		{name: "own LAG grows", ownPrimary: 1, members: []int{1, 2, 3}},
+		{name: "mirror destination", members: []int{9, 10}, wantErr: true},
+		{name: "mirrored port", members: []int{11, 12}, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkLAGConflicts(overrides, tc.ownPrimary, tc.members)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("checkLAGConflicts(%v) error = %v, wantErr %v", tc.members, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckMirrorConflicts(t *testing.T) {
+	overrides := []unifi.PortOverride{
+		testLAGOverride(1, 2),
+		testMirrorOverride(10, 11),
+	}
+
+	cases := []struct {
+		name           string
+		ownDestination int
+		destination    int
+		source         int
+		wantErr        bool
+	}{
+		{name: "free ports", destination: 20, source: 21},
+		{name: "mirrors itself", destination: 20, source: 20, wantErr: true},
+		{name: "mirrors LAG member", destination: 20, source: 2, wantErr: true},
+		{name: "destination in LAG", destination: 1, source: 20, wantErr: true},
+		{name: "destination taken", destination: 10, source: 20, wantErr: true},
+		{name: "source is a destination", destination: 20, source: 10, wantErr: true},
+		{name: "destination is mirrored", destination: 11, source: 20, wantErr: true},
+		{name: "own mirror changes source", ownDestination: 10, destination: 10, source: 12},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkMirrorConflicts(overrides, tc.ownDestination, tc.destination, tc.source)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("checkMirrorConflicts(%d, %d) error = %v, wantErr %v", tc.destination, tc.source, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckPortOverrideRoles(t *testing.T) {
+	overrides := []unifi.PortOverride{
+		testLAGOverride(1, 2),
+		testMirrorOverride(10, 11),
+		testSwitchOverride(5, "uplink"),
+	}
+
+	cases := []struct {
+		name    string
+		port    int
+		opMode  string
+		members []int
+		wantErr bool
+	}{
+		{name: "switch on LAG member", port: 2, opMode: portOpModeSwitch},
+		{name: "aggregate on free ports", port: 5, opMode: portOpModeAggregate, members: []int{5, 6}},
+		{name: "aggregate over other LAG", port: 3, opMode: portOpModeAggregate, members: []int{2, 3}, wantErr: true},
+		{name: "aggregate own LAG", port: 1, opMode: portOpModeAggregate, members: []int{1, 2, 3}},
+		{name: "aggregate over mirror destination", port: 9, opMode: portOpModeAggregate, members: []int{9, 10}, wantErr: true},
+		{name: "aggregate replaces own mirror", port: 10, opMode: portOpModeAggregate, members: []int{10, 12}},
+		{name: "mirror on free port", port: 6, opMode: portOpModeMirror},
+		{name: "mirror on LAG member", port: 2, opMode: portOpModeMirror, wantErr: true},
+		{name: "mirror on mirrored port", port: 11, opMode: portOpModeMirror, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkPortOverrideRoles(overrides, tc.port, tc.opMode, tc.members)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("checkPortOverrideRoles(%d, %s) error = %v, wantErr %v", tc.port, tc.opMode, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetLAG(t *testing.T) {
+	overrides := []unifi.PortOverride{testSwitchOverride(3, "server")}
+
+	overrides, primary := setLAG(overrides, 0, []int{4, 3})
+	if primary != 3 {
+		t.Fatalf("setLAG() primary = %d, want 3", primary)
+	}
+	if len(overrides) != 1 {
+		t.Fatalf("setLAG() returned %d overrides, want 1", len(overrides))
+	}
+	if got := overrides[0]; got.OpMode != portOpModeAggregate || got.Name != "server" || !reflect.DeepEqual(got.AggregateMembers, []int{3, 4}) {
+		t.Fatalf("setLAG() override = %+v, want aggregate of [3 4] keeping the name", got)
+	}
+
+	// Moving the LAG off its old primary returns that port to switching.
+	overrides, primary = setLAG(overrides, 3, []int{5, 6})
+	if primary != 5 || len(overrides) != 2 {
+		t.Fatalf("setLAG() primary = %d with %d overrides, want 5 with 2", primary, len(overrides))
+	}
+	if got := overrides[0]; got.OpMode != portOpModeSwitch || got.AggregateMembers != nil {
+		t.Fatalf("old primary override = %+v, want switching", got)
+	}
+	if got := lagMembers(overrides[1]); !reflect.DeepEqual(got, []int{5, 6}) {
+		t.Fatalf("new LAG members = %v, want [5 6]", got)
+	}
+}
+
+func TestSetMirrorAndClear(t *testing.T) {
+	overrides := setMirror(nil, 8, 2)
+	if len(overrides) != 1 || overrides[0].OpMode != portOpModeMirror || *overrides[0].MirrorPortIdx != 2 {
+		t.Fatalf("setMirror() = %+v, want port 8 mirroring port 2", overrides)
+	}
+
+	overrides = clearPortOpMode(overrides, 8)
+	if len(overrides) != 1 || overrides[0].OpMode != portOpModeSwitch || overrides[0].MirrorPortIdx != nil {
+		t.Fatalf("clearPortOpMode() = %+v, want port 8 switching", overrides)
+	}
+}
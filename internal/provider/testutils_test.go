@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
 )
 
@@ -39,6 +40,31 @@ func testAccGetClient(t *testing.T) *unifi.NetworkClient {
 	return client
 }
 
+// testAccEditBeforeApply is a plan check that calls edit with the ID of the
+// resource at address after the plan is made and before it is applied, to
+// simulate a change made on the controller in between.
+type testAccEditBeforeApply struct {
+	address string
+	edit    func(ctx context.Context, id string) error
+}
+
+func (e testAccEditBeforeApply) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Address != e.address {
+			continue
+		}
+		before, ok := rc.Change.Before.(map[string]any)
+		if !ok {
+			resp.Error = fmt.Errorf("%s has no prior state in the plan", e.address)
+			return
+		}
+		id, _ := before["id"].(string)
+		resp.Error = e.edit(ctx, id)
+		return
+	}
+	resp.Error = fmt.Errorf("%s not found in the plan", e.address)
+}
+
 // testAccCheckControllerSupportsZones checks if the controller supports zone-based firewall.
 func testAccCheckControllerSupportsZones(t *testing.T) {
 	testAccPreCheck(t)
@@ -180,6 +206,26 @@ func testAccGetFirstDeviceMAC(t *testing.T) string {
 	return devices.NetworkDevices[0].MAC
 }
 
+// testAccGetFirstAPMAC returns the MAC of the first access point.
+func testAccGetFirstAPMAC(t *testing.T) string {
+	client := testAccGetClient(t)
+	if client == nil {
+		return ""
+	}
+
+	devices, err := client.ListDevices(context.Background())
+	if err != nil || devices == nil {
+		return ""
+	}
+
+	for _, d := range devices.NetworkDevices {
+		if d.Type == "uap" {
+			return d.MAC
+		}
+	}
+	return ""
+}
+
 // testAccGetFirstSwitchMAC returns the MAC of the first switch device.
 func testAccGetFirstSwitchMAC(t *testing.T) string {
 	client := testAccGetClient(t)
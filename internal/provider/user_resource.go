@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
 )
@@ -20,6 +22,7 @@ import (
 var (
 	_ resource.Resource                = &UserResource{}
 	_ resource.ResourceWithImportState = &UserResource{}
+	_ resource.ResourceWithModifyPlan  = &UserResource{}
 )
 
 type UserResource struct {
@@ -45,6 +48,7 @@ type UserResourceModel struct {
 	OUI                   types.String   `tfsdk:"oui"`
 	FirstSeen             types.Int64    `tfsdk:"first_seen"`
 	LastSeen              types.Int64    `tfsdk:"last_seen"`
+	AdoptExisting         types.Bool     `tfsdk:"adopt_existing"`
 	Timeouts              timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -91,6 +95,7 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"adopt_existing": adoptExistingAttribute("client", "MAC address"),
 			"name": schema.StringAttribute{
 				Description: "A friendly name for the client device.",
 				Optional:    true,
@@ -124,9 +129,12 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"fixed_ip": schema.StringAttribute{
-				Description: "The fixed IP address for DHCP reservation. Requires use_fixed_ip to be true.",
+				Description: "The fixed IP address for DHCP reservation. Requires use_fixed_ip to be true. Must be inside the subnet of network_id.",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					IPv4Address(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -231,6 +239,9 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	user := r.planToSDK(&plan)
 
 	created, err := r.client.CreateUser(ctx, user)
+	if err != nil && r.client.shouldAdoptExisting(err, plan.AdoptExisting) {
+		created, err = r.adoptExisting(ctx, user, &resp.Diagnostics)
+	}
 	if err != nil {
 		handleSDKError(&resp.Diagnostics, err, "create", "user")
 		return
@@ -244,6 +255,31 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// adoptExisting takes over the controller's existing record for the planned
+// MAC address after Create was rejected as a conflict. Clients appear in the
+// controller as soon as they connect, usually long before they are codified.
+func (r *UserResource) adoptExisting(ctx context.Context, user *unifi.User, diags *diag.Diagnostics) (*unifi.User, error) {
+	users, err := r.client.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := findUserByMAC(users, user.MAC)
+	if existing == nil {
+		return nil, fmt.Errorf("the controller reported a conflict for MAC %s but no client with that MAC was found: %w", user.MAC, unifi.ErrConflict)
+	}
+
+	user.ID = existing.ID
+	user.SiteID = existing.SiteID
+	updated, err := r.client.UpdateUser(ctx, existing.ID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	addAdoptedWarning(diags, "client", fmt.Sprintf("MAC %s", user.MAC), existing.ID)
+	return updated, nil
+}
+
 func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state UserResourceModel
 
@@ -348,6 +384,66 @@ func (r *UserResource) ImportState(ctx context.Context, req resource.ImportState
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// ModifyPlan rejects a fixed_ip that falls outside the subnet of the network
+// it is reserved on. The network's subnet is taken from the current plan when
+// that unifi_network is planned in the same run, otherwise from the
+// controller. Unknown values and lookup failures skip the check.
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan UserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.FixedIP.IsNull() || plan.FixedIP.IsUnknown() || plan.NetworkID.IsNull() || plan.NetworkID.IsUnknown() {
+		return
+	}
+	if !plan.UseFixedIP.IsNull() && !plan.UseFixedIP.IsUnknown() && !plan.UseFixedIP.ValueBool() {
+		return
+	}
+
+	fixedIP := net.ParseIP(plan.FixedIP.ValueString()).To4()
+	if fixedIP == nil {
+		return
+	}
+
+	networkID := plan.NetworkID.ValueString()
+	network, ok := r.client.plannedNetworkSnapshot()[networkID]
+	if !ok {
+		lookupCtx, cancel := context.WithTimeout(ctx, modifyPlanLookupTimeout)
+		defer cancel()
+		existing, err := r.client.GetNetwork(lookupCtx, networkID)
+		if err != nil {
+			if !isNotFoundError(err) {
+				resp.Diagnostics.AddWarning(
+					"Unable to check fixed_ip against network",
+					fmt.Sprintf("Reading network %q from the controller failed, so fixed_ip was not checked against its subnet: %s", networkID, err),
+				)
+			}
+			return
+		}
+		network = networkAddressingFromSDK(existing)
+	}
+
+	if network.Subnet == nil {
+		return
+	}
+
+	if !network.Subnet.Contains(fixedIP) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fixed_ip"),
+			"Fixed IP outside network",
+			fmt.Sprintf("fixed_ip %s is not inside subnet %s of network %q. "+
+				"The controller rejects DHCP reservations outside the network's subnet.",
+				plan.FixedIP.ValueString(), network.Subnet.String(), network.Name),
+		)
+	}
+}
+
 func (r *UserResource) planToSDK(plan *UserResourceModel) *unifi.User {
 	user := &unifi.User{
 		MAC: plan.MAC.ValueString(),
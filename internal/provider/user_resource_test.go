@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
 )
 
 func TestAccUserResource_basic(t *testing.T) {
@@ -58,6 +61,30 @@ func TestAccUserResource_fixedIP(t *testing.T) {
 	})
 }
 
+func TestAccUserResource_fixedIPOutsideNetworkRejected(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceConfig_fixedIP(
+					"aa:bb:cc:00:00:0a",
+					"tf-acc-test-user-fixedip-outside",
+					"192.168.3.100",
+				),
+			},
+			{
+				Config: testAccUserResourceConfig_fixedIP(
+					"aa:bb:cc:00:00:0a",
+					"tf-acc-test-user-fixedip-outside",
+					"192.168.4.100",
+				),
+				ExpectError: regexp.MustCompile(`Fixed IP outside network`),
+			},
+		},
+	})
+}
+
 func TestAccUserResource_localDNS(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -220,6 +247,57 @@ func TestAccUserResource_note(t *testing.T) {
 	})
 }
 
+func TestAccUserResource_adoptExisting(t *testing.T) {
+	mac := "aa:bb:cc:00:00:0b"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// Simulate a client the controller already knows about.
+			client := testAccGetClient(t)
+			created, err := client.CreateUser(context.Background(), &unifi.User{
+				MAC:  mac,
+				Name: "tf-acc-test-user-preexisting",
+			})
+			if err != nil {
+				t.Fatalf("creating pre-existing client: %v", err)
+			}
+			// The adopting step normally takes over and destroys the client;
+			// this removes it if that step never ran or failed.
+			t.Cleanup(func() {
+				_ = client.DeleteUser(context.Background(), created.ID)
+			})
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserResourceConfig_basic(mac, "tf-acc-test-user-adopted"),
+				ExpectError: regexp.MustCompile(`Resource conflict`),
+			},
+			{
+				Config: testAccUserResourceConfig_adoptExisting(mac, "tf-acc-test-user-adopted"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_user.test", "mac", mac),
+					resource.TestCheckResourceAttr("unifi_user.test", "name", "tf-acc-test-user-adopted"),
+					resource.TestCheckResourceAttrSet("unifi_user.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserResourceConfig_adoptExisting(mac, name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_user" "test" {
+  mac            = %q
+  name           = %q
+  adopt_existing = true
+}
+`, testAccProviderConfig, mac, name)
+}
+
 func testAccUserResourceConfig_basic(mac, name string) string {
 	return fmt.Sprintf(`
 %s
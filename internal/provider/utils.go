@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -181,6 +182,32 @@ func stringValueOrNull(s string) types.String {
 	return types.StringValue(s)
 }
 
+// stringListOrNull returns a null list for an empty slice, otherwise a list
+// of the strings. It plays the same role as stringValueOrNull for optional
+// list fields.
+func stringListOrNull(values []string) types.List {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	elems := make([]attr.Value, len(values))
+	for i, v := range values {
+		elems[i] = types.StringValue(v)
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
+
+// stringSetOrNull is the set counterpart of stringListOrNull.
+func stringSetOrNull(values []string) types.Set {
+	if len(values) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	elems := make([]attr.Value, len(values))
+	for i, v := range values {
+		elems[i] = types.StringValue(v)
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
 // ipv4Validator validates that a string is a valid IPv4 address.
 type ipv4Validator struct{}
 
@@ -212,3 +239,66 @@ func (v ipv4Validator) ValidateString(ctx context.Context, req validator.StringR
 func IPv4Address() validator.String {
 	return ipv4Validator{}
 }
+
+// ipv6Validator validates that a string is a valid IPv6 address, or an IPv6
+// network in CIDR notation when cidr is set.
+type ipv6Validator struct {
+	cidr bool
+}
+
+func (v ipv6Validator) Description(ctx context.Context) string {
+	if v.cidr {
+		return "must be a valid IPv6 CIDR"
+	}
+	return "must be a valid IPv6 address"
+}
+
+func (v ipv6Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipv6Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if v.cidr {
+		if !isIPv6CIDR(value) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid IPv6 CIDR",
+				fmt.Sprintf("The value %q is not a valid IPv6 CIDR (e.g., '2001:db8:10::/64').", value),
+			)
+		}
+		return
+	}
+	if !isIPv6Address(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IPv6 Address",
+			fmt.Sprintf("The value %q is not a valid IPv6 address.", value),
+		)
+	}
+}
+
+// IPv6Address returns a validator that checks if a string is a valid IPv6 address.
+func IPv6Address() validator.String {
+	return ipv6Validator{}
+}
+
+// IPv6CIDR returns a validator that checks if a string is a valid IPv6
+// network in CIDR notation.
+func IPv6CIDR() validator.String {
+	return ipv6Validator{cidr: true}
+}
+
+func isIPv6Address(s string) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() == nil
+}
+
+func isIPv6CIDR(s string) bool {
+	ip, _, err := net.ParseCIDR(s)
+	return err == nil && ip.To4() == nil
+}
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &VLANAllocationResource{}
+	_ resource.ResourceWithImportState    = &VLANAllocationResource{}
+	_ resource.ResourceWithValidateConfig = &VLANAllocationResource{}
+)
+
+type VLANAllocationResource struct {
+	client *AutoLoginClient
+}
+
+type VLANAllocationResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	PoolStart    types.Int64    `tfsdk:"pool_start"`
+	PoolEnd      types.Int64    `tfsdk:"pool_end"`
+	ExcludeVLANs types.Set      `tfsdk:"exclude_vlans"`
+	VLANID       types.Int64    `tfsdk:"vlan_id"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewVLANAllocationResource() resource.Resource {
+	return &VLANAllocationResource{}
+}
+
+func (r *VLANAllocationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vlan_allocation"
+}
+
+func (r *VLANAllocationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Allocates the next unused VLAN ID from a pool. VLAN IDs used by networks on the controller, " +
+			"networks planned in the same run and other unifi_vlan_allocation resources are skipped. The VLAN ID is " +
+			"picked once on create and kept until the resource is replaced. It is recorded on the controller as a " +
+			"firewall port group named 'tf-allocation:vlan:<vlan_id>', which is deleted with the resource.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the allocation, in the form 'pool_start:pool_end:vlan_id'.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pool_start": schema.Int64Attribute{
+				Description: "The lowest VLAN ID in the pool (1-4094). Changing this allocates a new VLAN ID.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4094),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"pool_end": schema.Int64Attribute{
+				Description: "The highest VLAN ID in the pool (1-4094). Changing this allocates a new VLAN ID.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4094),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"exclude_vlans": schema.SetAttribute{
+				Description: "Additional VLAN IDs in the pool that must not be allocated, such as VLANs used only " +
+					"upstream of the gateway. Changing this allocates a new VLAN ID.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.Set{
+					setvalidator.ValueInt64sAre(int64validator.Between(1, 4094)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"vlan_id": schema.Int64Attribute{
+				Description: "The allocated VLAN ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *VLANAllocationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *VLANAllocationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config VLANAllocationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.PoolStart.IsNull() || config.PoolStart.IsUnknown() || config.PoolEnd.IsNull() || config.PoolEnd.IsUnknown() {
+		return
+	}
+	if config.PoolEnd.ValueInt64() < config.PoolStart.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pool_end"),
+			"Invalid VLAN pool",
+			fmt.Sprintf("pool_end (%d) must not be lower than pool_start (%d).", config.PoolEnd.ValueInt64(), config.PoolStart.ValueInt64()),
+		)
+	}
+}
+
+func (r *VLANAllocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan VLANAllocationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	taken := make(map[int]bool)
+	if !plan.ExcludeVLANs.IsNull() {
+		var excluded []int64
+		resp.Diagnostics.Append(plan.ExcludeVLANs.ElementsAs(ctx, &excluded, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, vlan := range excluded {
+			taken[int(vlan)] = true
+		}
+	}
+
+	networks, err := r.client.ListNetworks(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "list", "networks")
+		return
+	}
+	for i := range networks {
+		if vlan := networkAddressingFromSDK(&networks[i]).VLAN; vlan > 0 {
+			taken[vlan] = true
+		}
+	}
+	for _, n := range r.client.plannedNetworkSnapshot() {
+		if n.VLAN > 0 {
+			taken[n.VLAN] = true
+		}
+	}
+
+	recorded, err := r.client.listAllocations(ctx)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "list", "allocations")
+		return
+	}
+
+	start, end := int(plan.PoolStart.ValueInt64()), int(plan.PoolEnd.ValueInt64())
+	vlan := nextFreeVLAN(start, end, func(vlan int) bool {
+		key := vlanAllocationKey(vlan)
+		if _, ok := recorded[key]; ok {
+			return true
+		}
+		return taken[vlan] || !r.client.claimAllocation(key)
+	})
+	if vlan == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pool_start"),
+			"Unable to allocate VLAN ID",
+			fmt.Sprintf("Every VLAN ID from %d to %d is already in use. Widen the pool or free a VLAN.", start, end),
+		)
+		return
+	}
+
+	key := vlanAllocationKey(vlan)
+	if err := r.client.recordAllocation(ctx, key, "port-group", strconv.Itoa(vlan)); err != nil {
+		r.client.releaseAllocation(key)
+		handleSDKError(&resp.Diagnostics, err, "record", "VLAN allocation")
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%d:%d:%d", start, end, vlan))
+	plan.VLANID = types.Int64Value(int64(vlan))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read keeps the allocated VLAN ID. The firewall group recording it is not
+// checked, since the VLAN ID is kept whether or not it exists.
+func (r *VLANAllocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state VLANAllocationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only changes timeouts, since every other attribute forces
+// replacement.
+func (r *VLANAllocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan VLANAllocationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *VLANAllocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state VLANAllocationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := vlanAllocationKey(int(state.VLANID.ValueInt64()))
+	if err := r.client.deleteAllocation(ctx, key); err != nil {
+		handleSDKError(&resp.Diagnostics, err, "delete", "VLAN allocation")
+		return
+	}
+	r.client.releaseAllocation(key)
+}
+
+func (r *VLANAllocationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 3 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected format 'pool_start:pool_end:vlan_id', got '%s'", req.ID),
+		)
+		return
+	}
+
+	values := make([]int64, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("pool_start, pool_end and vlan_id must be numbers, got '%s'", req.ID),
+			)
+			return
+		}
+		values[i] = v
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pool_start"), values[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pool_end"), values[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vlan_id"), values[2])...)
+}
+
+func vlanAllocationKey(vlan int) string {
+	return "vlan:" + strconv.Itoa(vlan)
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVLANAllocationResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVLANAllocationResourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_vlan_allocation.first", "vlan_id", "3975"),
+					resource.TestCheckResourceAttr("unifi_vlan_allocation.second", "vlan_id", "3976"),
+					resource.TestCheckResourceAttr("unifi_network.test", "vlan_id", "3975"),
+				),
+			},
+			{
+				// The network now uses the first VLAN; the allocation must keep it.
+				Config:   testAccVLANAllocationResourceConfig(),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "unifi_vlan_allocation.first",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"exclude_vlans"},
+			},
+		},
+	})
+}
+
+func TestAccVLANAllocationResource_invalidPool(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "unifi_vlan_allocation" "test" {
+  pool_start = 3979
+  pool_end   = 3974
+}
+`, testAccProviderConfig),
+				ExpectError: regexp.MustCompile(`Invalid VLAN pool`),
+			},
+		},
+	})
+}
+
+func TestAccVLANAllocationResource_earlierApply(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVLANAllocationResourceConfig_earlierApply(false),
+				Check:  resource.TestCheckResourceAttr("unifi_vlan_allocation.first", "vlan_id", "3981"),
+			},
+			// No network uses the first VLAN ID, but it was recorded on the
+			// controller, so an allocation made by a later apply skips it.
+			{
+				Config: testAccVLANAllocationResourceConfig_earlierApply(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_vlan_allocation.first", "vlan_id", "3981"),
+					resource.TestCheckResourceAttr("unifi_vlan_allocation.second[0]", "vlan_id", "3982"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVLANAllocationResourceConfig() string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_vlan_allocation" "first" {
+  pool_start    = 3974
+  pool_end      = 3979
+  exclude_vlans = [3974]
+}
+
+resource "unifi_vlan_allocation" "second" {
+  pool_start    = 3974
+  pool_end      = 3979
+  exclude_vlans = [3974]
+
+  depends_on = [unifi_vlan_allocation.first]
+}
+
+resource "unifi_network" "test" {
+  name    = "tf-acc-test-vlan-allocation"
+  purpose = "vlan-only"
+  vlan_id = unifi_vlan_allocation.first.vlan_id
+}
+`, testAccProviderConfig)
+}
+
+func testAccVLANAllocationResourceConfig_earlierApply(second bool) string {
+	count := 0
+	if second {
+		count = 1
+	}
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_vlan_allocation" "first" {
+  pool_start = 3981
+  pool_end   = 3986
+}
+
+resource "unifi_vlan_allocation" "second" {
+  count      = %d
+  pool_start = 3981
+  pool_end   = 3986
+}
+`, testAccProviderConfig, count)
+}
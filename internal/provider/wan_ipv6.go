@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+const (
+	wanIPv6Disabled = "disabled"
+	wanIPv6DHCPv6   = "dhcpv6"
+	wanIPv6Static   = "static"
+)
+
+// wanIPv6AttrTypes describes the ipv6 object of unifi_wan, also reported as
+// wan_ipv6 by the unifi_network data source.
+var wanIPv6AttrTypes = map[string]attr.Type{
+	"connection_type": types.StringType,
+	"pd_size":         types.Int64Type,
+	"ip_address":      types.StringType,
+	"prefix_length":   types.Int64Type,
+	"gateway":         types.StringType,
+	"dns_servers":     types.ListType{ElemType: types.StringType},
+}
+
+// validateWANIPv6 checks that the attributes for the chosen IPv6 connection
+// type are set, and that those of the other type are not. Conflicts are keyed
+// by the nested attribute name. Unknown values are skipped.
+func validateWANIPv6(attrs map[string]attr.Value) []networkConflict {
+	mode, ok := attrs["connection_type"].(types.String)
+	if !ok || mode.IsNull() || mode.IsUnknown() {
+		return nil
+	}
+
+	var conflicts []networkConflict
+	for _, name := range []string{"ip_address", "prefix_length", "gateway", "pd_size"} {
+		v, ok := attrs[name]
+		if !ok || v.IsUnknown() {
+			continue
+		}
+
+		owner := wanIPv6Static
+		if name == "pd_size" {
+			owner = wanIPv6DHCPv6
+		}
+		switch {
+		case owner == wanIPv6Static && mode.ValueString() == wanIPv6Static && v.IsNull():
+			conflicts = append(conflicts, networkConflict{
+				Attribute: name,
+				Summary:   "Missing WAN IPv6 attribute",
+				Detail:    fmt.Sprintf("ipv6.%s is required when ipv6.connection_type is '%s'.", name, wanIPv6Static),
+			})
+		case mode.ValueString() != owner && !v.IsNull():
+			conflicts = append(conflicts, networkConflict{
+				Attribute: name,
+				Summary:   "Invalid WAN IPv6 attribute",
+				Detail:    fmt.Sprintf("ipv6.%s can only be set when ipv6.connection_type is '%s'.", name, owner),
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// wanIPv6FromObject sets the WAN's IPv6 fields from the ipv6 object. A null
+// object disables IPv6 on the WAN.
+func wanIPv6FromObject(ctx context.Context, obj types.Object, network *unifi.Network, diags *diag.Diagnostics) {
+	network.WANType6 = wanIPv6Disabled
+	if obj.IsNull() || obj.IsUnknown() {
+		return
+	}
+	attrs := obj.Attributes()
+
+	if v, ok := attrs["connection_type"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		network.WANType6 = v.ValueString()
+	}
+	if v, ok := attrs["pd_size"].(types.Int64); ok && !v.IsNull() && !v.IsUnknown() {
+		network.WANDHCPv6PDSize = intPtr(v.ValueInt64())
+	}
+	if v, ok := attrs["ip_address"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		network.WANIPV6 = v.ValueString()
+	}
+	if v, ok := attrs["prefix_length"].(types.Int64); ok && !v.IsNull() && !v.IsUnknown() {
+		network.WANPrefixlen = intPtr(v.ValueInt64())
+	}
+	if v, ok := attrs["gateway"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		network.WANGatewayV6 = v.ValueString()
+	}
+	if v, ok := attrs["dns_servers"].(types.List); ok && !v.IsNull() && !v.IsUnknown() {
+		var servers []string
+		diags.Append(v.ElementsAs(ctx, &servers, false)...)
+		if len(servers) > 0 {
+			network.WANIPV6DNS1 = servers[0]
+		}
+		if len(servers) > 1 {
+			network.WANIPV6DNS2 = servers[1]
+		}
+	}
+}
+
+// wanIPv6ToObject converts the WAN's IPv6 fields into the ipv6 object,
+// returning null when IPv6 is disabled. pd_size is only reported for
+// DHCPv6 and the static fields only for static.
+func wanIPv6ToObject(network *unifi.Network) (types.Object, diag.Diagnostics) {
+	if network.WANType6 == "" || network.WANType6 == wanIPv6Disabled {
+		return types.ObjectNull(wanIPv6AttrTypes), nil
+	}
+
+	pdSize := types.Int64Null()
+	if network.WANType6 == wanIPv6DHCPv6 && network.WANDHCPv6PDSize != nil {
+		pdSize = types.Int64Value(int64(*network.WANDHCPv6PDSize))
+	}
+
+	ipAddress, gateway := types.StringNull(), types.StringNull()
+	prefixLength := types.Int64Null()
+	if network.WANType6 == wanIPv6Static {
+		ipAddress = stringValueOrNull(network.WANIPV6)
+		gateway = stringValueOrNull(network.WANGatewayV6)
+		if network.WANPrefixlen != nil {
+			prefixLength = types.Int64Value(int64(*network.WANPrefixlen))
+		}
+	}
+
+	var servers []string
+	for _, s := range []string{network.WANIPV6DNS1, network.WANIPV6DNS2} {
+		if s != "" {
+			servers = append(servers, s)
+		}
+	}
+
+	return types.ObjectValue(wanIPv6AttrTypes, map[string]attr.Value{
+		"connection_type": types.StringValue(network.WANType6),
+		"pd_size":         pdSize,
+		"ip_address":      ipAddress,
+		"prefix_length":   prefixLength,
+		"gateway":         gateway,
+		"dns_servers":     stringListOrNull(servers),
+	})
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+func testWANIPv6Attrs(connectionType string, overrides map[string]attr.Value) map[string]attr.Value {
+	attrs := map[string]attr.Value{
+		"connection_type": types.StringValue(connectionType),
+		"pd_size":         types.Int64Null(),
+		"ip_address":      types.StringNull(),
+		"prefix_length":   types.Int64Null(),
+		"gateway":         types.StringNull(),
+		"dns_servers":     types.ListNull(types.StringType),
+	}
+	for k, v := range overrides {
+		attrs[k] = v
+	}
+	return attrs
+}
+
+func TestValidateWANIPv6(t *testing.T) {
+	static := map[string]attr.Value{
+		"ip_address":    types.StringValue("2001:db8:1::2"),
+		"prefix_length": types.Int64Value(64),
+		"gateway":       types.StringValue("2001:db8:1::1"),
+	}
+
+	cases := []struct {
+		name  string
+		attrs map[string]attr.Value
+		want  []string
+	}{
+		{name: "dhcpv6", attrs: testWANIPv6Attrs("dhcpv6", nil)},
+		{name: "dhcpv6 with pd size", attrs: testWANIPv6Attrs("dhcpv6", map[string]attr.Value{
+			"pd_size": types.Int64Value(56),
+		})},
+		{name: "dhcpv6 with static fields", attrs: testWANIPv6Attrs("dhcpv6", static), want: []string{"ip_address", "prefix_length", "gateway"}},
+		{name: "static", attrs: testWANIPv6Attrs("static", static)},
+		{name: "static missing fields", attrs: testWANIPv6Attrs("static", map[string]attr.Value{
+			"ip_address": types.StringValue("2001:db8:1::2"),
+		}), want: []string{"prefix_length", "gateway"}},
+		{name: "static with pd size", attrs: testWANIPv6Attrs("static", map[string]attr.Value{
+			"ip_address":    types.StringValue("2001:db8:1::2"),
+			"prefix_length": types.Int64Value(64),
+			"gateway":       types.StringValue("2001:db8:1::1"),
+			"pd_size":       types.Int64Value(56),
+		}), want: []string{"pd_size"}},
+		{name: "static with unknown fields", attrs: testWANIPv6Attrs("static", map[string]attr.Value{
+			"ip_address":    types.StringUnknown(),
+			"prefix_length": types.Int64Unknown(),
+			"gateway":       types.StringUnknown(),
+		})},
+		{name: "unknown type", attrs: map[string]attr.Value{
+			"connection_type": types.StringUnknown(),
+			"pd_size":         types.Int64Value(56),
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := validateWANIPv6(tc.attrs)
+			if len(got) != len(tc.want) {
+				t.Fatalf("validateWANIPv6() = %+v, want conflicts on %v", got, tc.want)
+			}
+			for i, c := range got {
+				if c.Attribute != tc.want[i] {
+					t.Fatalf("conflict %d on %q, want %q", i, c.Attribute, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWANIPv6RoundTrip(t *testing.T) {
+	dns := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("2606:4700:4700::1111"),
+		types.StringValue("2606:4700:4700::1001"),
+	})
+
+	cases := []struct {
+		name  string
+		attrs map[string]attr.Value
+	}{
+		{name: "dhcpv6", attrs: testWANIPv6Attrs("dhcpv6", map[string]attr.Value{
+			"pd_size": types.Int64Value(56),
+		})},
+		{name: "static", attrs: testWANIPv6Attrs("static", map[string]attr.Value{
+			"ip_address":    types.StringValue("2001:db8:1::2"),
+			"prefix_length": types.Int64Value(64),
+			"gateway":       types.StringValue("2001:db8:1::1"),
+			"dns_servers":   dns,
+		})},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			planned, d := types.ObjectValue(wanIPv6AttrTypes, tc.attrs)
+			if d.HasError() {
+				t.Fatalf("building ipv6 object: %v", d)
+			}
+
+			var diags diag.Diagnostics
+			network := &unifi.Network{}
+			wanIPv6FromObject(context.Background(), planned, network, &diags)
+			if diags.HasError() {
+				t.Fatalf("wanIPv6FromObject() = %v", diags)
+			}
+
+			state, d := wanIPv6ToObject(network)
+			if d.HasError() {
+				t.Fatalf("wanIPv6ToObject() = %v", d)
+			}
+			if !state.Equal(planned) {
+				t.Fatalf("wanIPv6ToObject() = %v, want %v", state, planned)
+			}
+		})
+	}
+
+	// A removed ipv6 attribute must disable IPv6 on the WAN.
+	var diags diag.Diagnostics
+	network := &unifi.Network{WANType6: wanIPv6DHCPv6}
+	wanIPv6FromObject(context.Background(), types.ObjectNull(wanIPv6AttrTypes), network, &diags)
+	if network.WANType6 != wanIPv6Disabled {
+		t.Fatalf("WANType6 = %q, want %q", network.WANType6, wanIPv6Disabled)
+	}
+	if state, _ := wanIPv6ToObject(network); !state.IsNull() {
+		t.Fatalf("wanIPv6ToObject() = %v, want null", state)
+	}
+}
@@ -0,0 +1,705 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resnickio/unifi-go-sdk/pkg/unifi"
+)
+
+const (
+	wanPurpose = "wan"
+
+	wanTypeDHCP   = "dhcp"
+	wanTypeStatic = "static"
+	wanTypePPPoE  = "pppoe"
+
+	wanLoadBalanceFailover = "failover-only"
+	wanLoadBalanceWeighted = "weighted"
+)
+
+var (
+	_ resource.Resource                   = &WANResource{}
+	_ resource.ResourceWithImportState    = &WANResource{}
+	_ resource.ResourceWithValidateConfig = &WANResource{}
+)
+
+type WANResource struct {
+	client *AutoLoginClient
+}
+
+type WANResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	SiteID         types.String   `tfsdk:"site_id"`
+	Name           types.String   `tfsdk:"name"`
+	NetworkGroup   types.String   `tfsdk:"network_group"`
+	Enabled        types.Bool     `tfsdk:"enabled"`
+	ConnectionType types.String   `tfsdk:"connection_type"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+
+	// Static
+	IPAddress types.String `tfsdk:"ip_address"`
+	Netmask   types.String `tfsdk:"netmask"`
+	Gateway   types.String `tfsdk:"gateway"`
+
+	// PPPoE
+	PPPoEUsername types.String `tfsdk:"pppoe_username"`
+	PPPoEPassword types.String `tfsdk:"pppoe_password"`
+
+	VlanID     types.Int64 `tfsdk:"vlan_id"`
+	DNSServers types.List  `tfsdk:"dns_servers"`
+
+	// Smart queues
+	SmartQueueEnabled  types.Bool  `tfsdk:"smart_queue_enabled"`
+	SmartQueueUpRate   types.Int64 `tfsdk:"smart_queue_up_rate"`
+	SmartQueueDownRate types.Int64 `tfsdk:"smart_queue_down_rate"`
+
+	// Failover and load balancing
+	LoadBalanceType   types.String `tfsdk:"load_balance_type"`
+	LoadBalanceWeight types.Int64  `tfsdk:"load_balance_weight"`
+	FailoverPriority  types.Int64  `tfsdk:"failover_priority"`
+
+	IPv6 types.Object `tfsdk:"ipv6"`
+}
+
+func NewWANResource() resource.Resource {
+	return &WANResource{}
+}
+
+func (r *WANResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wan"
+}
+
+func (r *WANResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a UniFi WAN interface: connection type (DHCP, static or PPPoE), IPv6 (DHCPv6 with prefix delegation, or static), WAN VLAN tagging, DNS override, smart queues and failover/load-balancing.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the WAN network.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"site_id": schema.StringAttribute{
+				Description: "The site ID where the WAN is configured.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the WAN.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+			"network_group": schema.StringAttribute{
+				Description: "The WAN interface this configuration applies to. Valid values: 'WAN', 'WAN2'.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("WAN", "WAN2"),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the WAN is enabled. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"connection_type": schema.StringAttribute{
+				Description: "How the WAN obtains its address. Valid values: 'dhcp', 'static', 'pppoe'. Defaults to 'dhcp'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(wanTypeDHCP),
+				Validators: []validator.String{
+					stringvalidator.OneOf(wanTypeDHCP, wanTypeStatic, wanTypePPPoE),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Description: "The static WAN IP address. Required when connection_type is 'static'.",
+				Optional:    true,
+				Validators: []validator.String{
+					IPv4Address(),
+				},
+			},
+			"netmask": schema.StringAttribute{
+				Description: "The static WAN netmask (e.g., '255.255.255.248'). Required when connection_type is 'static'.",
+				Optional:    true,
+				Validators: []validator.String{
+					IPv4Address(),
+				},
+			},
+			"gateway": schema.StringAttribute{
+				Description: "The static WAN gateway. Required when connection_type is 'static'.",
+				Optional:    true,
+				Validators: []validator.String{
+					IPv4Address(),
+				},
+			},
+			"pppoe_username": schema.StringAttribute{
+				Description: "The PPPoE username. Required when connection_type is 'pppoe'.",
+				Optional:    true,
+			},
+			"pppoe_password": schema.StringAttribute{
+				Description: "The PPPoE password. Required when connection_type is 'pppoe'. Note: This value is write-only and cannot be read back from the controller.",
+				Optional:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vlan_id": schema.Int64Attribute{
+				Description: "The VLAN ID to tag WAN traffic with, for ISPs that require it. Leave unset for untagged.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4094),
+				},
+			},
+			"dns_servers": schema.ListAttribute{
+				Description: "DNS servers to use instead of those provided by the ISP (maximum 2). Leave unset to use the ISP's servers.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 2),
+					listvalidator.ValueStringsAre(IPv4Address()),
+				},
+			},
+			"smart_queue_enabled": schema.BoolAttribute{
+				Description: "Whether smart queues (traffic shaping to reduce bufferbloat) are enabled. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"smart_queue_up_rate": schema.Int64Attribute{
+				Description: "The upload rate for smart queues, in kbps. Required when smart_queue_enabled is true.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"smart_queue_down_rate": schema.Int64Attribute{
+				Description: "The download rate for smart queues, in kbps. Required when smart_queue_enabled is true.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"load_balance_type": schema.StringAttribute{
+				Description: "How this WAN shares traffic with the other WANs. Valid values: 'failover-only', 'weighted'. Defaults to 'failover-only'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(wanLoadBalanceFailover),
+				Validators: []validator.String{
+					stringvalidator.OneOf(wanLoadBalanceFailover, wanLoadBalanceWeighted),
+				},
+			},
+			"load_balance_weight": schema.Int64Attribute{
+				Description: "The share of traffic sent over this WAN, as a percentage (1-99). Only valid when load_balance_type is 'weighted'.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 99),
+				},
+			},
+			"failover_priority": schema.Int64Attribute{
+				Description: "The failover priority of this WAN. Lower values are preferred.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ipv6": schema.SingleNestedAttribute{
+				Description: "IPv6 on this WAN. Leave unset to disable IPv6 on the WAN.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"connection_type": schema.StringAttribute{
+						Description: "How the WAN obtains IPv6. Valid values: 'dhcpv6' (DHCPv6 client with prefix delegation), 'static'.",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(wanIPv6DHCPv6, wanIPv6Static),
+						},
+					},
+					"pd_size": schema.Int64Attribute{
+						Description: "The prefix length to request from the ISP through DHCPv6 prefix delegation (48-64). " +
+							"A shorter prefix leaves room for more networks with ipv6.interface_type 'pd'. Only valid when connection_type is 'dhcpv6'.",
+						Optional: true,
+						Computed: true,
+						Validators: []validator.Int64{
+							int64validator.Between(48, 64),
+						},
+					},
+					"ip_address": schema.StringAttribute{
+						Description: "The static IPv6 address of the WAN. Required when connection_type is 'static'.",
+						Optional:    true,
+						Validators: []validator.String{
+							IPv6Address(),
+						},
+					},
+					"prefix_length": schema.Int64Attribute{
+						Description: "The prefix length of the static IPv6 address. Required when connection_type is 'static'.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 128),
+						},
+					},
+					"gateway": schema.StringAttribute{
+						Description: "The static IPv6 gateway. Required when connection_type is 'static'.",
+						Optional:    true,
+						Validators: []validator.String{
+							IPv6Address(),
+						},
+					},
+					"dns_servers": schema.ListAttribute{
+						Description: "IPv6 DNS servers to use instead of those provided by the ISP (maximum 2).",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeBetween(1, 2),
+							listvalidator.ValueStringsAre(IPv6Address()),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *WANResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AutoLoginClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AutoLoginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that the attributes for the chosen connection type,
+// smart queues and load balancing are set together, and that attributes for
+// other modes are not set at all, since the controller would silently keep
+// them.
+func (r *WANResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config WANResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.ConnectionType.IsUnknown() {
+		connectionType := config.ConnectionType.ValueString()
+		if config.ConnectionType.IsNull() {
+			connectionType = wanTypeDHCP
+		}
+
+		static := map[string]attr.Value{
+			"ip_address": config.IPAddress,
+			"netmask":    config.Netmask,
+			"gateway":    config.Gateway,
+		}
+		pppoe := map[string]attr.Value{
+			"pppoe_username": config.PPPoEUsername,
+			"pppoe_password": config.PPPoEPassword,
+		}
+		requireWANAttributes(&resp.Diagnostics, static, connectionType == wanTypeStatic, "connection_type is 'static'")
+		requireWANAttributes(&resp.Diagnostics, pppoe, connectionType == wanTypePPPoE, "connection_type is 'pppoe'")
+	}
+
+	if !config.SmartQueueEnabled.IsUnknown() {
+		rates := map[string]attr.Value{
+			"smart_queue_up_rate":   config.SmartQueueUpRate,
+			"smart_queue_down_rate": config.SmartQueueDownRate,
+		}
+		requireWANAttributes(&resp.Diagnostics, rates, config.SmartQueueEnabled.ValueBool(), "smart_queue_enabled is true")
+	}
+
+	if !config.LoadBalanceType.IsUnknown() && config.LoadBalanceType.ValueString() != wanLoadBalanceWeighted &&
+		!config.LoadBalanceWeight.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("load_balance_weight"),
+			"Invalid load balancing configuration",
+			"load_balance_weight can only be set when load_balance_type is 'weighted'.",
+		)
+	}
+
+	if !config.IPv6.IsNull() && !config.IPv6.IsUnknown() {
+		for _, c := range validateWANIPv6(config.IPv6.Attributes()) {
+			resp.Diagnostics.AddAttributeError(path.Root("ipv6").AtName(c.Attribute), c.Summary, c.Detail)
+		}
+	}
+}
+
+// requireWANAttributes reports each attribute in attrs that is missing while
+// required is true, or set while it is false. condition describes when the
+// attributes apply, for the error message.
+func requireWANAttributes(diags *diag.Diagnostics, attrs map[string]attr.Value, required bool, condition string) {
+	for name, v := range attrs {
+		if v.IsUnknown() {
+			continue
+		}
+		switch {
+		case required && v.IsNull():
+			diags.AddAttributeError(
+				path.Root(name),
+				"Missing WAN attribute",
+				fmt.Sprintf("%s is required when %s.", name, condition),
+			)
+		case !required && !v.IsNull():
+			diags.AddAttributeError(
+				path.Root(name),
+				"Invalid WAN attribute",
+				fmt.Sprintf("%s can only be set when %s.", name, condition),
+			)
+		}
+	}
+}
+
+func (r *WANResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan WANResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	network := r.planToSDK(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateNetwork(ctx, network)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "create", "WAN")
+		return
+	}
+
+	// Save password from plan (API won't return it)
+	originalPassword := plan.PPPoEPassword
+
+	resp.Diagnostics.Append(r.sdkToState(ctx, created, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.PPPoEPassword = originalPassword
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *WANResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state WANResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	network, err := r.client.GetNetwork(ctx, state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		handleSDKError(&resp.Diagnostics, err, "read", "WAN")
+		return
+	}
+
+	if network.Purpose != wanPurpose {
+		resp.Diagnostics.AddError(
+			"Not a WAN network",
+			fmt.Sprintf("Network %s has purpose %q. unifi_wan only manages WAN networks; use unifi_network for the others.", network.ID, network.Purpose),
+		)
+		return
+	}
+
+	// Save prior state for password preservation
+	priorState := state
+
+	resp.Diagnostics.Append(r.sdkToState(ctx, network, &state, &priorState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *WANResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan WANResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state WANResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	network := r.planToSDK(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	network.ID = state.ID.ValueString()
+	network.SiteID = state.SiteID.ValueString()
+
+	updated, err := r.client.UpdateNetwork(ctx, state.ID.ValueString(), network)
+	if err != nil {
+		handleSDKError(&resp.Diagnostics, err, "update", "WAN")
+		return
+	}
+
+	// Save password from plan (API won't return it)
+	originalPassword := plan.PPPoEPassword
+
+	resp.Diagnostics.Append(r.sdkToState(ctx, updated, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.PPPoEPassword = originalPassword
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *WANResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state WANResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 10*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteNetwork(ctx, state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		handleSDKError(&resp.Diagnostics, err, "delete", "WAN")
+		return
+	}
+}
+
+func (r *WANResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *WANResource) planToSDK(ctx context.Context, plan *WANResourceModel, diags *diag.Diagnostics) *unifi.Network {
+	network := &unifi.Network{
+		Name:            plan.Name.ValueString(),
+		Purpose:         wanPurpose,
+		Enabled:         boolPtr(plan.Enabled.ValueBool()),
+		WANNetworkGroup: plan.NetworkGroup.ValueString(),
+		WANType:         plan.ConnectionType.ValueString(),
+	}
+
+	// Static
+	if !plan.IPAddress.IsNull() {
+		network.WANIP = plan.IPAddress.ValueString()
+	}
+	if !plan.Netmask.IsNull() {
+		network.WANNetmask = plan.Netmask.ValueString()
+	}
+	if !plan.Gateway.IsNull() {
+		network.WANGateway = plan.Gateway.ValueString()
+	}
+
+	// PPPoE
+	if !plan.PPPoEUsername.IsNull() {
+		network.WANUsername = plan.PPPoEUsername.ValueString()
+	}
+	if !plan.PPPoEPassword.IsNull() && !plan.PPPoEPassword.IsUnknown() {
+		network.XWANPassword = plan.PPPoEPassword.ValueString()
+	}
+
+	// VLAN
+	network.WANVLANEnabled = boolPtr(false)
+	if !plan.VlanID.IsNull() && !plan.VlanID.IsUnknown() {
+		network.WANVLANEnabled = boolPtr(true)
+		network.WANVLAN = intPtr(plan.VlanID.ValueInt64())
+	}
+
+	// DNS
+	if !plan.DNSServers.IsNull() && !plan.DNSServers.IsUnknown() {
+		var servers []string
+		diags.Append(plan.DNSServers.ElementsAs(ctx, &servers, false)...)
+		if len(servers) > 0 {
+			network.WANDNS1 = servers[0]
+		}
+		if len(servers) > 1 {
+			network.WANDNS2 = servers[1]
+		}
+	}
+
+	// Smart queues
+	network.WANSmartqEnabled = boolPtr(plan.SmartQueueEnabled.ValueBool())
+	if !plan.SmartQueueUpRate.IsNull() && !plan.SmartQueueUpRate.IsUnknown() {
+		network.WANSmartqUpRate = intPtr(plan.SmartQueueUpRate.ValueInt64())
+	}
+	if !plan.SmartQueueDownRate.IsNull() && !plan.SmartQueueDownRate.IsUnknown() {
+		network.WANSmartqDownRate = intPtr(plan.SmartQueueDownRate.ValueInt64())
+	}
+
+	// Failover and load balancing
+	network.WANLoadBalanceType = plan.LoadBalanceType.ValueString()
+	if !plan.LoadBalanceWeight.IsNull() && !plan.LoadBalanceWeight.IsUnknown() {
+		network.WANLoadBalanceWeight = intPtr(plan.LoadBalanceWeight.ValueInt64())
+	}
+	if !plan.FailoverPriority.IsNull() && !plan.FailoverPriority.IsUnknown() {
+		network.WANFailoverPriority = intPtr(plan.FailoverPriority.ValueInt64())
+	}
+
+	// IPv6
+	wanIPv6FromObject(ctx, plan.IPv6, network, diags)
+
+	return network
+}
+
+func (r *WANResource) sdkToState(ctx context.Context, network *unifi.Network, state *WANResourceModel, priorState *WANResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	state.ID = types.StringValue(network.ID)
+	state.SiteID = stringValueOrNull(network.SiteID)
+	state.Name = types.StringValue(network.Name)
+	state.NetworkGroup = types.StringValue(network.WANNetworkGroup)
+	state.Enabled = types.BoolValue(derefBool(network.Enabled))
+
+	state.ConnectionType = types.StringValue(network.WANType)
+	if network.WANType == "" {
+		state.ConnectionType = types.StringValue(wanTypeDHCP)
+	}
+
+	// Static
+	state.IPAddress = stringValueOrNull(network.WANIP)
+	state.Netmask = stringValueOrNull(network.WANNetmask)
+	state.Gateway = stringValueOrNull(network.WANGateway)
+
+	// PPPoE
+	state.PPPoEUsername = stringValueOrNull(network.WANUsername)
+
+	// Password is write-only - preserve from prior state to prevent drift
+	if priorState != nil && !priorState.PPPoEPassword.IsNull() {
+		state.PPPoEPassword = priorState.PPPoEPassword
+	}
+
+	// VLAN
+	state.VlanID = types.Int64Null()
+	if derefBool(network.WANVLANEnabled) && network.WANVLAN != nil {
+		state.VlanID = types.Int64Value(int64(*network.WANVLAN))
+	}
+
+	// DNS
+	var servers []string
+	for _, s := range []string{network.WANDNS1, network.WANDNS2} {
+		if s != "" {
+			servers = append(servers, s)
+		}
+	}
+	if len(servers) > 0 {
+		list, d := types.ListValueFrom(ctx, types.StringType, servers)
+		diags.Append(d...)
+		state.DNSServers = list
+	} else {
+		state.DNSServers = types.ListNull(types.StringType)
+	}
+
+	// Smart queues
+	state.SmartQueueEnabled = types.BoolValue(derefBool(network.WANSmartqEnabled))
+	state.SmartQueueUpRate = types.Int64Null()
+	state.SmartQueueDownRate = types.Int64Null()
+	if derefBool(network.WANSmartqEnabled) {
+		if network.WANSmartqUpRate != nil {
+			state.SmartQueueUpRate = types.Int64Value(int64(*network.WANSmartqUpRate))
+		}
+		if network.WANSmartqDownRate != nil {
+			state.SmartQueueDownRate = types.Int64Value(int64(*network.WANSmartqDownRate))
+		}
+	}
+
+	// Failover and load balancing
+	state.LoadBalanceType = types.StringValue(wanLoadBalanceFailover)
+	if network.WANLoadBalanceType != "" {
+		state.LoadBalanceType = types.StringValue(network.WANLoadBalanceType)
+	}
+	state.LoadBalanceWeight = types.Int64Null()
+	if network.WANLoadBalanceType == wanLoadBalanceWeighted && network.WANLoadBalanceWeight != nil {
+		state.LoadBalanceWeight = types.Int64Value(int64(*network.WANLoadBalanceWeight))
+	}
+	state.FailoverPriority = types.Int64Null()
+	if network.WANFailoverPriority != nil {
+		state.FailoverPriority = types.Int64Value(int64(*network.WANFailoverPriority))
+	}
+
+	// IPv6
+	ipv6, d := wanIPv6ToObject(network)
+	diags.Append(d...)
+	state.IPv6 = ipv6
+
+	return diags
+}
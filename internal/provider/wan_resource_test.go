@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWANResource_static(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWANResourceConfig_static("tf-acc-test-wan", "203.0.113.10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("unifi_wan.test", "id"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "connection_type", "static"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "ip_address", "203.0.113.10"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "gateway", "203.0.113.1"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "dns_servers.#", "2"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "smart_queue_enabled", "true"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "smart_queue_down_rate", "100000"),
+				),
+			},
+			{
+				Config: testAccWANResourceConfig_static("tf-acc-test-wan", "203.0.113.11"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_wan.test", "ip_address", "203.0.113.11"),
+				),
+			},
+			{
+				ResourceName:      "unifi_wan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccWANResource_pppoe(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWANResourceConfig_pppoe("tf-acc-test-wan-pppoe"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_wan.test", "connection_type", "pppoe"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "pppoe_username", "tf-acc-user"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "vlan_id", "7"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "load_balance_type", "weighted"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "load_balance_weight", "30"),
+				),
+			},
+			{
+				ResourceName:            "unifi_wan.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"pppoe_password"},
+			},
+		},
+	})
+}
+
+func TestAccWANResource_ipv6(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWANResourceConfig_ipv6DHCPv6("tf-acc-test-wan-ipv6", 56),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_wan.test", "ipv6.connection_type", "dhcpv6"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "ipv6.pd_size", "56"),
+				),
+			},
+			{
+				Config: testAccWANResourceConfig_ipv6Static("tf-acc-test-wan-ipv6"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("unifi_wan.test", "ipv6.connection_type", "static"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "ipv6.ip_address", "2001:db8:1::2"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "ipv6.prefix_length", "64"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "ipv6.gateway", "2001:db8:1::1"),
+					resource.TestCheckResourceAttr("unifi_wan.test", "ipv6.dns_servers.#", "2"),
+					resource.TestCheckNoResourceAttr("unifi_wan.test", "ipv6.pd_size"),
+				),
+			},
+			{
+				ResourceName:      "unifi_wan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Removing the block disables IPv6 on the WAN.
+			{
+				Config: testAccWANResourceConfig_static("tf-acc-test-wan-ipv6", "203.0.113.10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("unifi_wan.test", "ipv6.connection_type"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWANResource_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "unifi_wan" "test" {
+  name            = "tf-acc-test-wan-invalid"
+  network_group   = "WAN2"
+  connection_type = "static"
+  ip_address      = "203.0.113.10"
+  pppoe_username  = "tf-acc-user"
+}
+`, testAccProviderConfig),
+				ExpectError: regexp.MustCompile(`(gateway is required|pppoe_username can only be set)`),
+			},
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "unifi_wan" "test" {
+  name                = "tf-acc-test-wan-invalid"
+  network_group       = "WAN2"
+  load_balance_weight = 50
+}
+`, testAccProviderConfig),
+				ExpectError: regexp.MustCompile(`load_balance_weight can only be set`),
+			},
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "unifi_wan" "test" {
+  name          = "tf-acc-test-wan-invalid"
+  network_group = "WAN2"
+
+  ipv6 = {
+    connection_type = "static"
+    ip_address      = "2001:db8:1::2"
+    pd_size         = 56
+  }
+}
+`, testAccProviderConfig),
+				ExpectError: regexp.MustCompile(`(ipv6.gateway is required|ipv6.pd_size can only be set)`),
+			},
+		},
+	})
+}
+
+func testAccWANResourceConfig_static(name, ip string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_wan" "test" {
+  name            = %q
+  network_group   = "WAN2"
+  connection_type = "static"
+  ip_address      = %q
+  netmask         = "255.255.255.0"
+  gateway         = "203.0.113.1"
+  dns_servers     = ["1.1.1.1", "9.9.9.9"]
+
+  smart_queue_enabled   = true
+  smart_queue_up_rate   = 20000
+  smart_queue_down_rate = 100000
+}
+`, testAccProviderConfig, name, ip)
+}
+
+func testAccWANResourceConfig_pppoe(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_wan" "test" {
+  name            = %q
+  network_group   = "WAN2"
+  connection_type = "pppoe"
+  pppoe_username  = "tf-acc-user"
+  pppoe_password  = "tf-acc-password"
+  vlan_id         = 7
+
+  load_balance_type   = "weighted"
+  load_balance_weight = 30
+}
+`, testAccProviderConfig, name)
+}
+
+func testAccWANResourceConfig_ipv6DHCPv6(name string, pdSize int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_wan" "test" {
+  name          = %q
+  network_group = "WAN2"
+
+  ipv6 = {
+    connection_type = "dhcpv6"
+    pd_size         = %d
+  }
+}
+`, testAccProviderConfig, name, pdSize)
+}
+
+func testAccWANResourceConfig_ipv6Static(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_wan" "test" {
+  name          = %q
+  network_group = "WAN2"
+
+  ipv6 = {
+    connection_type = "static"
+    ip_address      = "2001:db8:1::2"
+    prefix_length   = 64
+    gateway         = "2001:db8:1::1"
+    dns_servers     = ["2606:4700:4700::1111", "2606:4700:4700::1001"]
+  }
+}
+`, testAccProviderConfig, name)
+}
@@ -32,37 +32,39 @@ type WLANResource struct {
 }
 
 type WLANResourceModel struct {
-	ID               types.String   `tfsdk:"id"`
-	SiteID           types.String   `tfsdk:"site_id"`
-	Name             types.String   `tfsdk:"name"`
-	Enabled          types.Bool     `tfsdk:"enabled"`
-	Security         types.String   `tfsdk:"security"`
-	WPAMode          types.String   `tfsdk:"wpa_mode"`
-	WPAEnc           types.String   `tfsdk:"wpa_enc"`
-	Passphrase       types.String   `tfsdk:"passphrase"`
-	NetworkID        types.String   `tfsdk:"network_id"`
-	UserGroupID      types.String   `tfsdk:"user_group_id"`
-	APGroupIDs       types.Set      `tfsdk:"ap_group_ids"`
-	IsGuest          types.Bool     `tfsdk:"is_guest"`
-	HideSsid         types.Bool     `tfsdk:"hide_ssid"`
-	WLANBand         types.String   `tfsdk:"wlan_band"`
-	WLANBands        types.Set      `tfsdk:"wlan_bands"`
-	Vlan             types.Int64    `tfsdk:"vlan"`
-	VlanEnabled      types.Bool     `tfsdk:"vlan_enabled"`
-	MacFilterEnabled types.Bool     `tfsdk:"mac_filter_enabled"`
-	MacFilterList    types.Set      `tfsdk:"mac_filter_list"`
-	MacFilterPolicy  types.String   `tfsdk:"mac_filter_policy"`
-	ScheduleEnabled  types.Bool     `tfsdk:"schedule_enabled"`
-	Schedule         types.Set      `tfsdk:"schedule"`
-	L2Isolation      types.Bool     `tfsdk:"l2_isolation"`
-	FastRoaming      types.Bool     `tfsdk:"fast_roaming_enabled"`
-	ProxyArp         types.Bool     `tfsdk:"proxy_arp"`
-	BssTransition    types.Bool     `tfsdk:"bss_transition"`
-	Uapsd            types.Bool     `tfsdk:"uapsd_enabled"`
-	PmfMode          types.String   `tfsdk:"pmf_mode"`
-	WPA3Support      types.Bool     `tfsdk:"wpa3_support"`
-	WPA3Transition   types.Bool     `tfsdk:"wpa3_transition"`
-	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	ID                 types.String   `tfsdk:"id"`
+	SiteID             types.String   `tfsdk:"site_id"`
+	Name               types.String   `tfsdk:"name"`
+	Enabled            types.Bool     `tfsdk:"enabled"`
+	Security           types.String   `tfsdk:"security"`
+	WPAMode            types.String   `tfsdk:"wpa_mode"`
+	WPAEnc             types.String   `tfsdk:"wpa_enc"`
+	Passphrase         types.String   `tfsdk:"passphrase"`
+	NetworkID          types.String   `tfsdk:"network_id"`
+	UserGroupID        types.String   `tfsdk:"user_group_id"`
+	APGroupIDs         types.Set      `tfsdk:"ap_group_ids"`
+	IsGuest            types.Bool     `tfsdk:"is_guest"`
+	HideSsid           types.Bool     `tfsdk:"hide_ssid"`
+	WLANBand           types.String   `tfsdk:"wlan_band"`
+	WLANBands          types.Set      `tfsdk:"wlan_bands"`
+	Vlan               types.Int64    `tfsdk:"vlan"`
+	VlanEnabled        types.Bool     `tfsdk:"vlan_enabled"`
+	MacFilterEnabled   types.Bool     `tfsdk:"mac_filter_enabled"`
+	MacFilterList      types.Set      `tfsdk:"mac_filter_list"`
+	MacFilterPolicy    types.String   `tfsdk:"mac_filter_policy"`
+	ScheduleEnabled    types.Bool     `tfsdk:"schedule_enabled"`
+	Schedule           types.Set      `tfsdk:"schedule"`
+	L2Isolation        types.Bool     `tfsdk:"l2_isolation"`
+	FastRoaming        types.Bool     `tfsdk:"fast_roaming_enabled"`
+	ProxyArp           types.Bool     `tfsdk:"proxy_arp"`
+	BssTransition      types.Bool     `tfsdk:"bss_transition"`
+	Uapsd              types.Bool     `tfsdk:"uapsd_enabled"`
+	PmfMode            types.String   `tfsdk:"pmf_mode"`
+	WPA3Support        types.Bool     `tfsdk:"wpa3_support"`
+	WPA3Transition     types.Bool     `tfsdk:"wpa3_transition"`
+	ConflictDetection  types.String   `tfsdk:"conflict_detection"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewWLANResource() resource.Resource {
@@ -277,6 +279,13 @@ func (r *WLANResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"conflict_detection": conflictDetectionAttribute("WLAN"),
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether the WLAN is protected from deletion. While true, destroying or replacing the WLAN fails; set to false and apply before destroying. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -332,6 +341,7 @@ func (r *WLANResource) Create(ctx context.Context, req resource.CreateRequest, r
 		handleSDKError(&resp.Diagnostics, err, "create", "WLAN")
 		return
 	}
+	resp.Diagnostics.Append(saveLastApplied(ctx, resp.Private, created)...)
 
 	// The UniFi API does not return the passphrase on read operations for security.
 	// We preserve the passphrase from the plan to avoid drift.
@@ -376,6 +386,7 @@ func (r *WLANResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		handleSDKError(&resp.Diagnostics, err, "read", "WLAN")
 		return
 	}
+	resp.Diagnostics.Append(saveLastApplied(ctx, resp.Private, wlan)...)
 
 	resp.Diagnostics.Append(r.sdkToState(ctx, wlan, &state, &priorState)...)
 	if resp.Diagnostics.HasError() {
@@ -414,11 +425,24 @@ func (r *WLANResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	wlan.ID = state.ID.ValueString()
 	wlan.SiteID = state.SiteID.ValueString()
 
+	if conflictDetectionEnabled(plan.ConflictDetection) {
+		current, err := r.client.GetWLAN(ctx, state.ID.ValueString())
+		if err != nil {
+			handleSDKError(&resp.Diagnostics, err, "read", "WLAN")
+			return
+		}
+		checkOutOfBandEdits(ctx, plan.ConflictDetection, req.Private, current, "WLAN", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	updated, err := r.client.UpdateWLAN(ctx, state.ID.ValueString(), wlan)
 	if err != nil {
 		handleSDKError(&resp.Diagnostics, err, "update", "WLAN")
 		return
 	}
+	resp.Diagnostics.Append(saveLastApplied(ctx, resp.Private, updated)...)
 
 	originalPassphrase := plan.Passphrase
 
@@ -442,6 +466,10 @@ func (r *WLANResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	if checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "WLAN", state.Name.ValueString()) {
+		return
+	}
+
 	deleteTimeout, diags := state.Timeouts.Delete(ctx, 10*time.Minute)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -550,6 +578,8 @@ func (r *WLANResource) sdkToState(ctx context.Context, wlan *unifi.WLANConf, sta
 	state.SiteID = types.StringValue(wlan.SiteID)
 	state.Name = types.StringValue(wlan.Name)
 	state.Enabled = types.BoolValue(derefBool(wlan.Enabled))
+	state.DeletionProtection = deletionProtectionDefault(state.DeletionProtection, false)
+	state.ConflictDetection = conflictDetectionOrDefault(state.ConflictDetection)
 	state.Security = types.StringValue(wlan.Security)
 	state.WPAMode = types.StringValue(wlan.WPAMode)
 	state.WPAEnc = types.StringValue(wlan.WPAEnc)
@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func getDefaultAPGroupID(t *testing.T) string {
@@ -257,6 +259,43 @@ func TestAccWLANResource_macFilter(t *testing.T) {
 	})
 }
 
+func TestAccWLANResource_conflictDetectionError(t *testing.T) {
+	apGroupID := getDefaultAPGroupID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWLANResourceConfig_conflictDetection("tf-acc-test-wlan-conflict", "TestPassword123!", apGroupID),
+			},
+			// l2_isolation is changed on the controller after the plan is
+			// made, so the rename must be refused.
+			{
+				Config: testAccWLANResourceConfig_conflictDetection("tf-acc-test-wlan-conflict-renamed", "TestPassword123!", apGroupID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						testAccEditBeforeApply{
+							address: "unifi_wlan.test",
+							edit: func(ctx context.Context, id string) error {
+								client := testAccGetClient(t)
+								wlan, err := client.GetWLAN(ctx, id)
+								if err != nil {
+									return err
+								}
+								wlan.L2Isolation = boolPtr(!derefBool(wlan.L2Isolation))
+								_, err = client.UpdateWLAN(ctx, id, wlan)
+								return err
+							},
+						},
+					},
+				},
+				ExpectError: regexp.MustCompile(`WLAN changed outside Terraform`),
+			},
+		},
+	})
+}
+
 func testAccWLANResourceConfig_basic(name, passphrase, apGroupID string) string {
 	return fmt.Sprintf(`
 %s
@@ -270,6 +309,20 @@ resource "unifi_wlan" "test" {
 `, testAccProviderConfig, name, passphrase, apGroupID)
 }
 
+func testAccWLANResourceConfig_conflictDetection(name, passphrase, apGroupID string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "unifi_wlan" "test" {
+  name               = %q
+  security           = "wpapsk"
+  passphrase         = %q
+  ap_group_ids       = [%q]
+  conflict_detection = "error"
+}
+`, testAccProviderConfig, name, passphrase, apGroupID)
+}
+
 func testAccWLANResourceConfig_full(name, passphrase, apGroupID string) string {
 	return fmt.Sprintf(`
 %s